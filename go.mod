@@ -16,6 +16,7 @@ require (
 	github.com/goccy/go-yaml v1.10.0
 	github.com/golang/protobuf v1.5.4
 	github.com/google/gops v0.3.28
+	github.com/google/jsonschema-go v0.4.2
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/jclem/sseparser v0.5.0
 	github.com/modelcontextprotocol/go-sdk v1.4.1
@@ -79,7 +80,6 @@ require (
 	github.com/godbus/dbus/v5 v5.1.0 // indirect
 	github.com/golang/groupcache v0.0.0-20241129210726-2c02b8208cf8 // indirect
 	github.com/google/gnostic-models v0.6.9 // indirect
-	github.com/google/jsonschema-go v0.4.2 // indirect
 	github.com/gorilla/css v1.0.1 // indirect
 	github.com/gorilla/websocket v1.5.4-0.20250319132907-e064f32e3674 // indirect
 	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
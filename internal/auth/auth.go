@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/runtime"
@@ -30,6 +33,27 @@ type ResolvedAuth struct {
 	Source AuthSource `json:"source"`
 }
 
+// Email returns the email of the authenticated user, read from the claims of
+// the bearer token. It returns "" when authenticated with an API key, which
+// does not belong to a user, or when the token carries no email.
+func (a *Auth) Email() string {
+	parts := strings.Split(a.BearerToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Email
+}
+
 func IsAuthenticated(authInfo *ResolvedAuth) bool {
 	if authInfo == nil {
 		var err error
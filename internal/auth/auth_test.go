@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestAuthEmail(t *testing.T) {
+	token := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2ln"
+	}
+	cases := []struct {
+		auth Auth
+		want string
+	}{
+		{auth: Auth{BearerToken: token(`{"email":"alice@example.com","sub":"u-1"}`)}, want: "alice@example.com"},
+		{auth: Auth{BearerToken: token(`{"sub":"u-1"}`)}},
+		{auth: Auth{BearerToken: token(`not json`)}},
+		{auth: Auth{BearerToken: "opaque-token"}},
+		{auth: Auth{APIKey: "key"}},
+	}
+	for i, c := range cases {
+		if got := c.auth.Email(); got != c.want {
+			t.Errorf("case %d: got %q, want %q", i, got, c.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/signadot/go-sdk/models"
@@ -9,6 +10,8 @@ import (
 
 var (
 	ErrOverrideNotFound = errors.New("override not found in the sandbox")
+	ErrForkNotFound     = errors.New("fork not found in the sandbox")
+	ErrForkCustomized   = errors.New("fork has customizations a local workload cannot keep")
 )
 
 type SandboxBuilder struct {
@@ -87,6 +90,117 @@ func (sb *SandboxBuilder) SetDevboxID(id string) *SandboxBuilder {
 	})
 }
 
+// SetName renames the sandbox
+func (sb *SandboxBuilder) SetName(name string) *SandboxBuilder {
+	return sb.withError(func() error {
+		sb.internal.Name = name
+		return nil
+	})
+}
+
+// SetLabel sets a label of the sandbox, replacing any previous value
+func (sb *SandboxBuilder) SetLabel(key, value string) *SandboxBuilder {
+	return sb.withError(func() error {
+		if sb.internal.Spec.Labels == nil {
+			sb.internal.Spec.Labels = map[string]string{}
+		}
+		sb.internal.Spec.Labels[key] = value
+		return nil
+	})
+}
+
+// ReplaceForkWithLocal replaces the fork of the named workload with a local
+// workload of the same baseline, whose ports are mapped to the given local
+// addresses. Forks with customizations (images, env or patch) are not
+// replaced, as the local workload would not keep them.
+func (sb *SandboxBuilder) ReplaceForkWithLocal(workloadName string, mappings []*models.LocalMapping) *SandboxBuilder {
+	if sb.checkError() {
+		return sb
+	}
+
+	spec := sb.internal.Spec
+	forks := make([]*models.SandboxFork, 0, len(spec.Forks))
+	var fork *models.SandboxFork
+	for _, f := range spec.Forks {
+		if fork == nil && f.ForkOf != nil && f.ForkOf.Name != nil && *f.ForkOf.Name == workloadName {
+			fork = f
+			continue
+		}
+		forks = append(forks, f)
+	}
+	if fork == nil {
+		return sb.setError(fmt.Errorf("%w: %s", ErrForkNotFound, workloadName))
+	}
+	if c := fork.Customizations; c != nil && (len(c.Images) > 0 || len(c.Env) > 0 || c.Patch != nil) {
+		return sb.setError(fmt.Errorf("%w: %s", ErrForkCustomized, workloadName))
+	}
+	spec.Forks = forks
+	spec.Local = append(spec.Local, &models.Local{
+		Name: getLocalName(spec.Local, workloadName),
+		From: &models.LocalFrom{
+			Kind:      fork.ForkOf.Kind,
+			Namespace: fork.ForkOf.Namespace,
+			Name:      fork.ForkOf.Name,
+		},
+		Mappings: mappings,
+	})
+	return sb
+}
+
+// AddFork adds a fork of the given workload
+func (sb *SandboxBuilder) AddFork(kind, namespace, name string) *SandboxBuilder {
+	return sb.withError(func() error {
+		sb.internal.Spec.Forks = append(sb.internal.Spec.Forks, &models.SandboxFork{
+			ForkOf: &models.SandboxForkOf{
+				Kind:      &kind,
+				Namespace: &namespace,
+				Name:      &name,
+			},
+		})
+		return nil
+	})
+}
+
+// AddLocal adds a local workload of the given baseline, whose ports are
+// mapped to the given local addresses
+func (sb *SandboxBuilder) AddLocal(kind, namespace, name string, mappings []*models.LocalMapping) *SandboxBuilder {
+	return sb.withError(func() error {
+		spec := sb.internal.Spec
+		spec.Local = append(spec.Local, &models.Local{
+			Name: getLocalName(spec.Local, name),
+			From: &models.LocalFrom{
+				Kind:      &kind,
+				Namespace: &namespace,
+				Name:      &name,
+			},
+			Mappings: mappings,
+		})
+		return nil
+	})
+}
+
+// DeleteLocals removes all the local workloads
+func (sb *SandboxBuilder) DeleteLocals() *SandboxBuilder {
+	return sb.withError(func() error {
+		sb.internal.Spec.Local = nil
+		return nil
+	})
+}
+
+// getLocalName returns a name for the local of the given workload which is
+// not used by any of the locals
+func getLocalName(locals []*models.Local, workloadName string) string {
+	used := make(map[string]bool, len(locals))
+	for _, l := range locals {
+		used[l.Name] = true
+	}
+	name := "local-" + workloadName
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("local-%s-%d", workloadName, i)
+	}
+	return name
+}
+
 func (sb *SandboxBuilder) AddOverrideMiddleware(worklaodPort int64, toLocal string,
 	workloadNames []string, args ...*MiddlewareOverrideArg) *SandboxBuilder {
 	if sb.checkError() {
@@ -178,3 +292,71 @@ func (sb *SandboxBuilder) DeleteOverrideMiddleware(overrideName string) *Sandbox
 	spec.Middleware = removeMiddleareByValueFrom(spec.Middleware, overrideName)
 	return sb
 }
+
+// SetOverrideTarget points the override to the given local address,
+// replacing the one it forwards to
+func (sb *SandboxBuilder) SetOverrideTarget(overrideName, toLocal string) *SandboxBuilder {
+	if sb.checkError() {
+		return sb
+	}
+
+	spec := sb.internal.Spec
+	if spec.Routing == nil || !hasOverrideMiddleware(spec.Middleware, spec.Routing.Forwards, overrideName) {
+		return sb.setError(ErrOverrideNotFound)
+	}
+	for _, fw := range spec.Routing.Forwards {
+		if fw.Name == overrideName {
+			fw.ToLocal = toLocal
+		}
+	}
+	return sb
+}
+
+// AttachOverrideLog points the log of the override to a log server listening
+// on the given local port, replacing any previous log destination
+func (sb *SandboxBuilder) AttachOverrideLog(overrideName string, logListenerPort int) *SandboxBuilder {
+	sb.DetachOverrideLog(overrideName)
+	if sb.checkError() {
+		return sb
+	}
+
+	logArg, err := NewOverrideLogArg(logListenerPort)
+	if err != nil {
+		return sb.setError(err)
+	}
+	for _, mw := range sb.internal.Spec.Middleware {
+		if hasOverrideHostArgWithValue(mw, overrideName) {
+			mw.Args = append(mw.Args, logArg.internal(sb, overrideName))
+		}
+	}
+	return sb
+}
+
+// DetachOverrideLog removes the log destination of the override, if any, so
+// that the override keeps running without a log server
+func (sb *SandboxBuilder) DetachOverrideLog(overrideName string) *SandboxBuilder {
+	if sb.checkError() {
+		return sb
+	}
+
+	spec := sb.internal.Spec
+	if spec.Routing == nil || !hasOverrideMiddleware(spec.Middleware, spec.Routing.Forwards, overrideName) {
+		return sb.setError(ErrOverrideNotFound)
+	}
+
+	spec.Routing.Forwards = removeForwardByName(spec.Routing.Forwards, getLogForwardName(overrideName))
+	for _, mw := range spec.Middleware {
+		if !hasOverrideHostArgWithValue(mw, overrideName) {
+			continue
+		}
+		args := make([]*models.SandboxesArgument, 0, len(mw.Args))
+		for _, arg := range mw.Args {
+			if arg.Name == "logHost" {
+				continue
+			}
+			args = append(args, arg)
+		}
+		mw.Args = args
+	}
+	return sb
+}
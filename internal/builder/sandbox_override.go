@@ -2,6 +2,7 @@ package builder
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -21,14 +22,42 @@ type MiddlewareOverrideArg struct {
 	isSet    bool
 }
 
-func NewOverrideArgPolicy(excludedStatusCodes []int) (*MiddlewareOverrideArg, error) {
+// OverridePolicy defines how the responses of the local service of an
+// override are used, as declared with --except-status or in an override file.
+type OverridePolicy struct {
+	// ExcludedStatusCodes are the status codes of local responses that fall
+	// through to the sandbox. When set, all other responses are overridden.
+	ExcludedStatusCodes []int `json:"exceptStatus,omitempty"`
+}
+
+func (p *OverridePolicy) Validate() error {
+	for _, code := range p.ExcludedStatusCodes {
+		if code < 100 || code > 599 {
+			return errors.New("invalid except-status response code, should be between 100 and 599")
+		}
+	}
+	return nil
+}
+
+func (p *OverridePolicy) value() (string, error) {
 	policy := override.Policy{}
-	if len(excludedStatusCodes) > 0 {
+	if len(p.ExcludedStatusCodes) > 0 {
 		policy.OverrideByDefault = true
-		policy.ExcludedStatusCodes = excludedStatusCodes
+		policy.ExcludedStatusCodes = p.ExcludedStatusCodes
 	}
 
 	policyValue, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(policyValue), nil
+}
+
+func NewOverrideArgPolicy(policy *OverridePolicy) (*MiddlewareOverrideArg, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	policyValue, err := policy.value()
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +65,7 @@ func NewOverrideArgPolicy(excludedStatusCodes []int) (*MiddlewareOverrideArg, er
 	applyInternal := func(sb *SandboxBuilder, overrideName string) *models.SandboxesArgument {
 		return &models.SandboxesArgument{
 			Name:  "policy",
-			Value: string(policyValue),
+			Value: policyValue,
 		}
 	}
 
@@ -80,6 +109,7 @@ func NewOverrideLogArg(logListenerPort int) (*MiddlewareOverrideArg, error) {
 type DetailedOverrideMiddleware struct {
 	Forward    *models.SandboxesForward
 	LogForward *models.SandboxesForward
+	Workloads  []string
 }
 
 // GetAvailableOverrideMiddlewares returns all available override forwards from a sandbox
@@ -115,9 +145,15 @@ func GetAvailableOverrideMiddlewares(sandbox *models.Sandbox) []*DetailedOverrid
 				logForwardName := getLogForwardName(forwardName)
 				logForward := forwardMap[logForwardName]
 
+				workloads := make([]string, 0, len(middleware.Match))
+				for _, match := range middleware.Match {
+					workloads = append(workloads, match.Workload)
+				}
+
 				overrides = append(overrides, &DetailedOverrideMiddleware{
 					Forward:    forward,
 					LogForward: logForward,
+					Workloads:  workloads,
 				})
 			}
 		}
@@ -126,6 +162,24 @@ func GetAvailableOverrideMiddlewares(sandbox *models.Sandbox) []*DetailedOverrid
 	return overrides
 }
 
+// GetConflictingOverride returns the override intercepting the given port of
+// any of the given workloads, if any
+func GetConflictingOverride(sb *models.Sandbox, port int64, workloadNames []string) *DetailedOverrideMiddleware {
+	for _, override := range GetAvailableOverrideMiddlewares(sb) {
+		if override.Forward.Port != port {
+			continue
+		}
+		for _, w := range override.Workloads {
+			for _, name := range workloadNames {
+				if w == name {
+					return override
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // HasOverrideMiddleware checks if a specific override middleware exists by name
 func HasOverrideMiddleware(sb *models.Sandbox, overrideName string) bool {
 	overrides := GetAvailableOverrideMiddlewares(sb)
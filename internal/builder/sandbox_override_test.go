@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/signadot/libconnect/common/override"
+)
+
+func TestOverridePolicyValue(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  OverridePolicy
+		byDef   bool
+		codes   []int
+		wantErr bool
+	}{
+		{name: "default"},
+		{
+			name:   "except status",
+			policy: OverridePolicy{ExcludedStatusCodes: []int{404, 503}},
+			byDef:  true,
+			codes:  []int{404, 503},
+		},
+		{
+			name:    "invalid status",
+			policy:  OverridePolicy{ExcludedStatusCodes: []int{404, 99}},
+			wantErr: true,
+		},
+		{
+			name:    "status too high",
+			policy:  OverridePolicy{ExcludedStatusCodes: []int{600}},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			arg, err := NewOverrideArgPolicy(&c.policy)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			sbArg := arg.internal(nil, "override-0")
+			if sbArg.Name != "policy" {
+				t.Errorf("got argument %q, want policy", sbArg.Name)
+			}
+			// the value must be decodable by the override middleware
+			var got override.Policy
+			dec := json.NewDecoder(strings.NewReader(sbArg.Value))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&got); err != nil {
+				t.Fatalf("decoding %s: %v", sbArg.Value, err)
+			}
+			if got.OverrideByDefault != c.byDef {
+				t.Errorf("OverrideByDefault: got %v, want %v", got.OverrideByDefault, c.byDef)
+			}
+			if !slices.Equal(got.ExcludedStatusCodes, c.codes) {
+				t.Errorf("ExcludedStatusCodes: got %v, want %v", got.ExcludedStatusCodes, c.codes)
+			}
+		})
+	}
+}
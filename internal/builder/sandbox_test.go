@@ -0,0 +1,182 @@
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/signadot/go-sdk/models"
+)
+
+func newTestSandbox() *SandboxBuilder {
+	return BuildSandbox("test", WithData(models.Sandbox{
+		Name: "test",
+		Spec: &models.SandboxSpec{},
+	}))
+}
+
+func countLogArgs(sb *models.Sandbox) int {
+	n := 0
+	for _, mw := range sb.Spec.Middleware {
+		for _, arg := range mw.Args {
+			if arg.Name == "logHost" {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func findForward(sb *models.Sandbox, name string) *models.SandboxesForward {
+	for _, fw := range sb.Spec.Routing.Forwards {
+		if fw.Name == name {
+			return fw
+		}
+	}
+	return nil
+}
+
+func TestAttachDetachOverrideLog(t *testing.T) {
+	b := newTestSandbox().AddOverrideMiddleware(8080, "localhost:9999", []string{"frontend"})
+	name := *b.GetLastAddedOverrideName()
+
+	sb, err := b.AttachOverrideLog(name, 7000).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logFw := findForward(&sb, getLogForwardName(name))
+	if logFw == nil || logFw.ToLocal != "localhost:7000" {
+		t.Fatalf("got log forward %+v, want one to localhost:7000", logFw)
+	}
+	if n := countLogArgs(&sb); n != 1 {
+		t.Fatalf("got %d logHost args, want 1", n)
+	}
+
+	// re-attaching replaces the log destination
+	sb, err = b.AttachOverrideLog(name, 7001).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logFw = findForward(&sb, getLogForwardName(name))
+	if logFw == nil || logFw.ToLocal != "localhost:7001" {
+		t.Fatalf("got log forward %+v, want one to localhost:7001", logFw)
+	}
+	if n := countLogArgs(&sb); n != 1 {
+		t.Fatalf("got %d logHost args after re-attaching, want 1", n)
+	}
+	if n := len(sb.Spec.Routing.Forwards); n != 2 {
+		t.Fatalf("got %d forwards, want 2", n)
+	}
+
+	// detaching keeps the override
+	sb, err = b.DetachOverrideLog(name).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findForward(&sb, getLogForwardName(name)) != nil {
+		t.Errorf("log forward still present after detaching")
+	}
+	if n := countLogArgs(&sb); n != 0 {
+		t.Errorf("got %d logHost args after detaching, want 0", n)
+	}
+	if !HasOverrideMiddleware(&sb, name) {
+		t.Errorf("override removed by detaching its log")
+	}
+}
+
+func TestDetachOverrideLogNotFound(t *testing.T) {
+	b := newTestSandbox().AddOverrideMiddleware(8080, "localhost:9999", []string{"frontend"})
+	_, err := b.DetachOverrideLog("override-7").Build()
+	if !errors.Is(err, ErrOverrideNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrOverrideNotFound)
+	}
+}
+
+func TestGetConflictingOverride(t *testing.T) {
+	sb, err := newTestSandbox().
+		AddOverrideMiddleware(8080, "localhost:9999", []string{"frontend", "backend"}).
+		AddOverrideMiddleware(9090, "localhost:9998", []string{"backend"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		port      int64
+		workloads []string
+		want      string
+	}{
+		{port: 8080, workloads: []string{"frontend"}, want: "override-0"},
+		{port: 8080, workloads: []string{"other", "backend"}, want: "override-0"},
+		{port: 9090, workloads: []string{"backend"}, want: "override-1"},
+		{port: 9090, workloads: []string{"frontend"}},
+		{port: 7070, workloads: []string{"frontend", "backend"}},
+		{port: 8080},
+	}
+	for _, c := range cases {
+		o := GetConflictingOverride(&sb, c.port, c.workloads)
+		got := ""
+		if o != nil {
+			got = o.Forward.Name
+		}
+		if got != c.want {
+			t.Errorf("port %d, workloads %v: got %q, want %q", c.port, c.workloads, got, c.want)
+		}
+	}
+}
+
+func TestReplaceForkWithLocal(t *testing.T) {
+	newSandbox := func(t *testing.T) *SandboxBuilder {
+		var spec models.SandboxSpec
+		err := json.Unmarshal([]byte(`{
+			"forks": [
+				{"forkOf": {"kind": "Deployment", "namespace": "hotrod", "name": "route"}},
+				{"forkOf": {"kind": "Deployment", "namespace": "hotrod", "name": "frontend"},
+				 "customizations": {"images": [{"image": "frontend:dev"}]}},
+				{"forkOf": {"kind": "Deployment", "namespace": "hotrod", "name": "driver"}}
+			],
+			"local": [
+				{"name": "local-route", "from": {"kind": "Deployment", "namespace": "other", "name": "route"}}
+			]
+		}`), &spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return BuildSandbox("test", WithData(models.Sandbox{Name: "test", Spec: &spec}))
+	}
+	mappings := []*models.LocalMapping{{Port: 8083, ToLocal: "localhost:8083"}}
+
+	sb, err := newSandbox(t).ReplaceForkWithLocal("route", mappings).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(sb.Spec.Forks); n != 2 {
+		t.Errorf("got %d forks, want 2", n)
+	}
+	for _, f := range sb.Spec.Forks {
+		if *f.ForkOf.Name == "route" {
+			t.Errorf("fork of route still present")
+		}
+	}
+	if n := len(sb.Spec.Local); n != 2 {
+		t.Fatalf("got %d locals, want 2", n)
+	}
+	local := sb.Spec.Local[1]
+	if local.Name != "local-route-2" {
+		t.Errorf("got local %q, want local-route-2", local.Name)
+	}
+	if *local.From.Namespace != "hotrod" || *local.From.Name != "route" {
+		t.Errorf("got local from %s/%s, want hotrod/route", *local.From.Namespace, *local.From.Name)
+	}
+	if len(local.Mappings) != 1 || local.Mappings[0].ToLocal != "localhost:8083" {
+		t.Errorf("got mappings %+v", local.Mappings)
+	}
+
+	_, err = newSandbox(t).ReplaceForkWithLocal("frontend", mappings).Build()
+	if !errors.Is(err, ErrForkCustomized) {
+		t.Errorf("got error %v, want %v", err, ErrForkCustomized)
+	}
+	_, err = newSandbox(t).ReplaceForkWithLocal("location", mappings).Build()
+	if !errors.Is(err, ErrForkNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrForkNotFound)
+	}
+}
@@ -0,0 +1,69 @@
+package artifact
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/go-sdk/client"
+	"github.com/signadot/go-sdk/client/artifacts"
+	"github.com/signadot/go-sdk/models"
+)
+
+// artifactName returns the path of an artifact as given on the command
+// line: prefixed with @ for system artifacts.
+func artifactName(a *models.JobArtifact) string {
+	if a.Space == "system" {
+		return "@" + a.Path
+	}
+	return a.Path
+}
+
+// fetchArtifact writes an artifact, named as by artifactName, to w. When
+// contentType isn't nil, it is set to the media type of the response before
+// anything is written to w.
+func fetchArtifact(ctx context.Context, cfg *config.Artifact, job *config.ArtifactJob, name string, w io.Writer, contentType *string) error {
+	// If path starts with @ means is system based, otherwise user
+	space := "user"
+	artifactPath := name
+	if strings.HasPrefix(artifactPath, "@") {
+		space = "system"
+		artifactPath = strings.TrimPrefix(artifactPath, "@")
+	}
+
+	params := artifacts.
+		NewDownloadJobAttemptArtifactParams().
+		WithContext(ctx).
+		WithTimeout(4 * time.Minute).
+		WithOrgName(cfg.Org).
+		WithJobName(job.Job).
+		WithJobAttempt(job.Attempt).
+		WithPath(artifactPath).
+		WithSpace(&space)
+
+	// create a custom transport to treat everything as a byte stream
+	transportCfg := cfg.GetBaseTransport()
+	transportCfg.OverrideConsumers = true
+	transportCfg.Consumers = map[string]runtime.Consumer{
+		"*/*": runtime.ByteStreamConsumer(),
+	}
+	if contentType != nil {
+		// The consumer is picked by the media type of the response: register
+		// one per type cat knows how to render, recording the type.
+		for _, mt := range renderedMediaTypes {
+			transportCfg.Consumers[mt] = runtime.ConsumerFunc(func(r io.Reader, data any) error {
+				*contentType = mt
+				return runtime.ByteStreamConsumer().Consume(r, data)
+			})
+		}
+	}
+
+	return cfg.APIClientWithCustomTransport(transportCfg,
+		func(c *client.SignadotAPI) error {
+			_, _, err := c.Artifacts.DownloadJobAttemptArtifact(params, nil, w)
+			return err
+		})
+}
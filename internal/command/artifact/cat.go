@@ -0,0 +1,182 @@
+package artifact
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/signadot/cli/internal/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newCat(artifact *config.Artifact) *cobra.Command {
+	cfg := &config.ArtifactCat{Artifact: artifact}
+
+	cmd := &cobra.Command{
+		Use:   "cat PATH",
+		Short: "Write a job artifact to stdout",
+		Long: `Stream an artifact of a job attempt to stdout.
+
+On a terminal, JSON is indented, Markdown is rendered and binary artifacts
+are refused; use --raw to write the artifact as-is. When stdout is not a
+terminal, the artifact is always written as-is.`,
+		Example: `  # Show the stdout of a job
+  signadot artifact cat --job my-job @stdout
+
+  # Pipe a JSON artifact to jq
+  signadot artifact cat --job my-job results.json | jq .summary`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cat(cmd.Context(), cfg, cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+type contentKind int
+
+const (
+	kindText contentKind = iota
+	kindJSON
+	kindMarkdown
+	kindBinary
+)
+
+func cat(ctx context.Context, cfg *config.ArtifactCat, out io.Writer, name string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	width, isTerm := terminalWidth(out)
+	if cfg.Raw || !isTerm {
+		return fetchArtifact(ctx, cfg.Artifact, &cfg.ArtifactJob, name, out, nil)
+	}
+
+	// Stream the artifact through a pipe, to look at its head before
+	// deciding how to render it. The content type is set before the first
+	// write to the pipe, so it is known once the head is.
+	var contentType string
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(fetchArtifact(ctx, cfg.Artifact, &cfg.ArtifactJob, name, pw, &contentType))
+	}()
+	defer pr.Close()
+
+	br := bufio.NewReader(pr)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return err
+	}
+
+	switch detectKind(name, contentType, head) {
+	case kindBinary:
+		return fmt.Errorf("%s looks binary (%s); use --raw to write it anyway or 'signadot artifact download' to save it",
+			name, http.DetectContentType(head))
+	case kindJSON:
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if json.Indent(&buf, data, "", "  ") != nil {
+			// Not valid JSON after all: show it as-is.
+			_, err = out.Write(data)
+			return err
+		}
+		buf.WriteString("\n")
+		_, err = buf.WriteTo(out)
+		return err
+	case kindMarkdown:
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return err
+		}
+		r, err := glamour.NewTermRenderer(
+			glamour.WithAutoStyle(),
+			glamour.WithWordWrap(width),
+		)
+		if err == nil {
+			if rendered, err := r.Render(string(data)); err == nil {
+				_, err = fmt.Fprint(out, rendered)
+				return err
+			}
+		}
+		_, err = out.Write(data)
+		return err
+	default:
+		_, err := io.Copy(out, br)
+		return err
+	}
+}
+
+// renderedMediaTypes are the media types of artifacts which tell how to
+// render them, see detectKind.
+var renderedMediaTypes = []string{
+	"application/json",
+	"text/markdown",
+	"text/x-markdown",
+	"text/plain",
+}
+
+// detectKind tells how to render an artifact from its media type, as sent
+// by the API, falling back to its name, and the first bytes of its content.
+func detectKind(name, contentType string, head []byte) contentKind {
+	if len(head) > 0 && !isText(head) {
+		return kindBinary
+	}
+	switch contentType {
+	case "application/json":
+		return kindJSON
+	case "text/markdown", "text/x-markdown":
+		return kindMarkdown
+	case "text/plain":
+		return kindText
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		return kindJSON
+	case ".md", ".markdown":
+		return kindMarkdown
+	}
+	return kindText
+}
+
+// isText reports whether the head of a content is text: valid UTF-8, but
+// for a rune cut at the end, without NUL bytes.
+func isText(head []byte) bool {
+	if bytes.IndexByte(head, 0) >= 0 {
+		return false
+	}
+	for i := 0; i < utf8.UTFMax && len(head) > 0; i++ {
+		if utf8.Valid(head) {
+			return true
+		}
+		head = head[:len(head)-1]
+	}
+	return utf8.Valid(head)
+}
+
+func terminalWidth(out io.Writer) (int, bool) {
+	f, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0, false
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		width = 100
+	}
+	return width, true
+}
@@ -0,0 +1,30 @@
+package artifact
+
+import "testing"
+
+func TestDetectKind(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		head        string
+		want        contentKind
+	}{
+		{"results.json", "", `{"ok": true}`, kindJSON},
+		{"README.md", "", "# Title", kindMarkdown},
+		{"@stdout", "", "[INFO] started\n", kindText},
+		{"empty.json", "", "", kindJSON},
+		{"image.png", "", "\x89PNG\r\n\x1a\n\x00\x00", kindBinary},
+		// A multi-byte rune cut by the end of the head is still text.
+		{"notes.txt", "", "caf\xc3", kindText},
+		// The media type sent by the API wins over the name.
+		{"results", "application/json", `{"ok": true}`, kindJSON},
+		{"report.out", "text/markdown", "# Title", kindMarkdown},
+		{"data.json", "text/plain", "not json", kindText},
+		{"image.png", "text/plain", "\x89PNG\r\n\x1a\n\x00\x00", kindBinary},
+	}
+	for _, tt := range tests {
+		if got := detectKind(tt.name, tt.contentType, []byte(tt.head)); got != tt.want {
+			t.Errorf("detectKind(%q, %q, %q) = %v, want %v", tt.name, tt.contentType, tt.head, got, tt.want)
+		}
+	}
+}
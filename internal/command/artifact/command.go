@@ -15,7 +15,9 @@ func New(api *config.API) *cobra.Command {
 
 	// Subcommands
 	cmd.AddCommand(
+		newList(cfg),
 		newDownload(cfg),
+		newCat(cfg),
 	)
 
 	return cmd
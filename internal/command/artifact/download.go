@@ -6,13 +6,8 @@ import (
 	"io"
 	"os"
 	"path"
-	"strings"
-	"time"
 
-	"github.com/go-openapi/runtime"
 	"github.com/signadot/cli/internal/config"
-	"github.com/signadot/go-sdk/client"
-	"github.com/signadot/go-sdk/client/artifacts"
 	"github.com/spf13/cobra"
 )
 
@@ -20,10 +15,34 @@ func newDownload(artifact *config.Artifact) *cobra.Command {
 	cfg := &config.ArtifactDownload{Artifact: artifact}
 
 	cmd := &cobra.Command{
-		Use:   "download PATH",
+		Use:   "download [PATH | --all --dir DIR]",
 		Short: "Download job artifacts",
-		Args:  cobra.ExactArgs(1),
+		Long: `Download an artifact of a job attempt, or with --all, all of them to a
+directory, at most --parallel at once. A bulk download writes the SHA-256
+checksums of the files to SHA256SUMS in the directory, which
+'sha256sum -c SHA256SUMS' verifies.`,
+		Example: `  # Download a report
+  signadot artifact download --job my-job reports/summary.html
+
+  # Download the artifacts of the second attempt of a job
+  signadot artifact download --job my-job --attempt 1 --all --dir ./artifacts`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.All {
+				if len(args) != 0 {
+					return fmt.Errorf("--all does not take a PATH argument")
+				}
+				if cfg.Dir == "" {
+					return fmt.Errorf("--all requires --dir")
+				}
+				return downloadAll(cmd.Context(), cfg, cmd.OutOrStdout())
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("expected a PATH argument, or --all")
+			}
+			if cfg.Dir != "" {
+				return fmt.Errorf("--dir requires --all")
+			}
 			return download(cmd.Context(), cfg, cmd.OutOrStdout(), args[0])
 		},
 	}
@@ -43,41 +62,17 @@ func download(ctx context.Context, cfg *config.ArtifactDownload, out io.Writer,
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
-	// If path starts with @ means is system based, otherwise user
-	space := "user"
-	if strings.HasPrefix(artifactPath, "@") {
-		space = "system"
-		artifactPath = strings.TrimPrefix(artifactPath, "@")
+	if err := fetchArtifact(ctx, cfg.Artifact, &cfg.ArtifactJob, artifactPath, f, nil); err != nil {
+		return err
 	}
-
-	params := artifacts.
-		NewDownloadJobAttemptArtifactParams().
-		WithContext(ctx).
-		WithTimeout(4 * time.Minute).
-		WithOrgName(cfg.Org).
-		WithJobName(cfg.Job).
-		WithJobAttempt(0).
-		WithPath(artifactPath).
-		WithSpace(&space)
-
-	// create a custom transport to treat everything as a byte stream
-	transportCfg := cfg.GetBaseTransport()
-	transportCfg.OverrideConsumers = true
-	transportCfg.Consumers = map[string]runtime.Consumer{
-		"*/*": runtime.ByteStreamConsumer(),
+	if err := f.Close(); err != nil {
+		return err
 	}
 
-	return cfg.APIClientWithCustomTransport(transportCfg,
-		func(c *client.SignadotAPI) error {
-			_, _, err = c.Artifacts.DownloadJobAttemptArtifact(params, nil, f)
-			if err != nil {
-				return err
-			}
-
-			fmt.Fprintf(out, "File saved successfully at %s\n", outputFilename)
-			return nil
-		})
+	fmt.Fprintf(out, "File saved successfully at %s\n", outputFilename)
+	return nil
 }
 
 func getOutputFilename(cfg *config.ArtifactDownload, artifactPath string) string {
@@ -0,0 +1,155 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/signadot/go-sdk/models"
+)
+
+// checksumsFile is the name of the file listing the checksums of the
+// artifacts of a bulk download, in the format of sha256sum.
+const checksumsFile = "SHA256SUMS"
+
+type downloadResult struct {
+	Name   string
+	Path   string
+	Size   int64
+	SHA256 string
+	Err    error
+}
+
+func downloadAll(ctx context.Context, cfg *config.ArtifactDownload, out io.Writer) error {
+	if cfg.Parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	artifacts, err := utils.GetJobArtifacts(ctx, cfg.API, cfg.Job, cfg.Attempt)
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 {
+		fmt.Fprintln(out, "No artifacts.")
+		return nil
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return err
+	}
+
+	results := make([]downloadResult, len(artifacts))
+	sem := make(chan struct{}, cfg.Parallel)
+	var wg sync.WaitGroup
+	for i, a := range artifacts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = downloadTo(ctx, cfg, a)
+		}()
+	}
+	wg.Wait()
+
+	var (
+		sums strings.Builder
+		errs []error
+	)
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, r.Err))
+			continue
+		}
+		rel, _ := filepath.Rel(cfg.Dir, r.Path)
+		fmt.Fprintf(&sums, "%s  %s\n", r.SHA256, filepath.ToSlash(rel))
+		fmt.Fprintf(out, "Saved %s (%s)\n", r.Path, utils.ByteCountSI(r.Size))
+	}
+	if sums.Len() > 0 {
+		sumsPath := filepath.Join(cfg.Dir, checksumsFile)
+		if err := os.WriteFile(sumsPath, []byte(sums.String()), 0o644); err != nil {
+			errs = append(errs, err)
+		} else {
+			fmt.Fprintf(out, "Wrote checksums to %s\n", sumsPath)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// downloadTo downloads an artifact under the --dir directory, at its path
+// with system artifacts keeping their @ prefix, and checks it has the
+// listed size.
+func downloadTo(ctx context.Context, cfg *config.ArtifactDownload, a *models.JobArtifact) downloadResult {
+	name := artifactName(a)
+	res := downloadResult{Name: name}
+	rel, err := localPath(name)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.Path = filepath.Join(cfg.Dir, rel)
+	if err := os.MkdirAll(filepath.Dir(res.Path), 0o755); err != nil {
+		res.Err = err
+		return res
+	}
+	res.Size, res.SHA256, err = fetchFile(ctx, cfg, name, res.Path)
+	if err == nil && a.Size > 0 && res.Size != a.Size {
+		err = fmt.Errorf("got %d bytes, expected %d", res.Size, a.Size)
+	}
+	if err != nil {
+		os.Remove(res.Path)
+		res.Err = err
+	}
+	return res
+}
+
+// fetchFile downloads an artifact to a file, returning its size and
+// SHA-256 checksum.
+func fetchFile(ctx context.Context, cfg *config.ArtifactDownload, name, path string) (int64, string, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	cw := &countingWriter{w: io.MultiWriter(f, h)}
+	if err := fetchArtifact(ctx, cfg.Artifact, &cfg.ArtifactJob, name, cw, nil); err != nil {
+		return 0, "", err
+	}
+	if err := f.Close(); err != nil {
+		return 0, "", err
+	}
+	return cw.n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// localPath turns the name of an artifact into a path relative to the
+// download directory, rejecting names which would escape it.
+func localPath(name string) (string, error) {
+	p := filepath.Clean(filepath.FromSlash(name))
+	if p == "." || filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("unsafe artifact path %q", name)
+	}
+	return p, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
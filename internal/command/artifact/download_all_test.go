@@ -0,0 +1,28 @@
+package artifact
+
+import "testing"
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "reports/summary.html", want: "reports/summary.html"},
+		{name: "@stdout", want: "@stdout"},
+		{name: "a/../b.txt", want: "b.txt"},
+		{name: "../escape", wantErr: true},
+		{name: "/etc/passwd", wantErr: true},
+		{name: "..", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := localPath(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("localPath(%q) err = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("localPath(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
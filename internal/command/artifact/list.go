@@ -0,0 +1,75 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newList(artifact *config.Artifact) *cobra.Command {
+	cfg := &config.ArtifactList{Artifact: artifact}
+
+	cmd := &cobra.Command{
+		Use:   "list --job JOB [--attempt N]",
+		Short: "List job artifacts",
+		Long: `List the artifacts of a job attempt with their sizes. System artifacts,
+such as the job logs, are prefixed with @.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list(cmd.Context(), cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func list(ctx context.Context, cfg *config.ArtifactList, out, log io.Writer) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	artifacts, err := utils.GetJobArtifacts(ctx, cfg.API, cfg.Job, cfg.Attempt)
+	if err != nil {
+		return err
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		if len(artifacts) == 0 {
+			fmt.Fprintln(log, "No artifacts.")
+			return nil
+		}
+		return printArtifactTable(out, artifacts)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, artifacts)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, artifacts)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+type artifactRow struct {
+	Path string `sdtab:"PATH"`
+	Size string `sdtab:"SIZE"`
+}
+
+func printArtifactTable(out io.Writer, artifacts []*models.JobArtifact) error {
+	t := sdtab.New[artifactRow](out)
+	t.AddHeader()
+	for _, a := range artifacts {
+		t.AddRow(artifactRow{
+			Path: artifactName(a),
+			Size: utils.ByteCountSI(a.Size),
+		})
+	}
+	return t.Flush()
+}
@@ -2,6 +2,7 @@ package devbox
 
 import (
 	"github.com/signadot/cli/internal/config"
+	devboxpkg "github.com/signadot/cli/internal/devbox"
 	"github.com/spf13/cobra"
 )
 
@@ -11,13 +12,33 @@ func New(api *config.API) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "devbox",
 		Short: "Inspect and manipulate devboxes",
+		Long: `Inspect and manipulate devboxes.
+
+A devbox identifies this machine when connecting with 'signadot local
+connect'. Its ID is kept in ~/.signadot/.devbox-id. Several devboxes can be
+used on one machine (like containers or VMs sharing a home directory) with
+named identities, selected with --identity or $SIGNADOT_DEVBOX_IDENTITY, whose
+IDs are kept in ~/.signadot/.devbox-id.<identity>. The ID file can also be
+given explicitly with --id-file or $SIGNADOT_DEVBOX_ID_FILE.`,
 	}
+	cfg.AddFlags(cmd)
 
 	cmd.AddCommand(
 		newList(cfg),
 		newRegister(cfg),
 		newDelete(cfg),
+		newShow(cfg),
+		newPrune(cfg),
 	)
 
 	return cmd
 }
+
+// initDevbox initializes the API config and selects the devbox identity
+// given by the flags.
+func initDevbox(cfg *config.Devbox) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	return devboxpkg.SelectIdentity(cfg.IDFile, cfg.Identity)
+}
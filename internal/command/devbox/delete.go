@@ -40,7 +40,7 @@ func deleteDevbox(cfg *config.DevboxDelete, log io.Writer) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := cfg.InitAPIConfig(); err != nil {
+	if err := initDevbox(cfg.Devbox); err != nil {
 		return err
 	}
 
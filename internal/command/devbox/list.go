@@ -33,7 +33,7 @@ func newList(devbox *config.Devbox) *cobra.Command {
 }
 
 func list(cfg *config.DevboxList, out io.Writer, errOut io.Writer) error {
-	if err := cfg.InitAPIConfig(); err != nil {
+	if err := initDevbox(cfg.Devbox); err != nil {
 		return err
 	}
 
@@ -54,9 +54,10 @@ func list(cfg *config.DevboxList, out io.Writer, errOut io.Writer) error {
 	devboxes := resp.Payload
 
 	// Read the current devbox ID from file
+	idFile, _ := devboxpkg.IDFile()
 	currentDevboxID, err := devboxpkg.GetDefaultDevboxID()
 	if err != nil {
-		fmt.Fprintf(errOut, "Warning: Could not read devbox ID from ~/.signadot/.devbox-id: %v\n", err)
+		fmt.Fprintf(errOut, "Warning: Could not read devbox ID from %s: %v\n", idFile, err)
 		// Treat as absent (currentDevboxID is already empty)
 	}
 
@@ -70,7 +71,7 @@ func list(cfg *config.DevboxList, out io.Writer, errOut io.Writer) error {
 			}
 		}
 		if !currentDevboxInList {
-			ddb, err := get(ctx, cfg, currentDevboxID)
+			ddb, err := get(ctx, cfg.API, currentDevboxID)
 			if err != nil {
 				switch {
 				case devboxpkg.IsNotFound(err):
@@ -81,7 +82,7 @@ func list(cfg *config.DevboxList, out io.Writer, errOut io.Writer) error {
 						fmt.Fprintf(errOut, "debug: cached devbox %s is no longer registered\n", currentDevboxID)
 					}
 				default:
-					fmt.Fprintf(errOut, "Warning: Could not fetch devbox %s from ~/.signadot/.devbox-id: %v\n", currentDevboxID, err)
+					fmt.Fprintf(errOut, "Warning: Could not fetch devbox %s from %s: %v\n", currentDevboxID, idFile, err)
 				}
 				// set current to unknown
 				currentDevboxID = ""
@@ -103,7 +104,7 @@ func list(cfg *config.DevboxList, out io.Writer, errOut io.Writer) error {
 	}
 }
 
-func get(ctx context.Context, cfg *config.DevboxList, id string) (*models.Devbox, error) {
+func get(ctx context.Context, cfg *config.API, id string) (*models.Devbox, error) {
 
 	getParams := devboxes.NewGetDevboxParams().
 		WithContext(ctx).
@@ -1,9 +1,12 @@
 package devbox
 
 import (
+	"fmt"
 	"io"
+	"text/tabwriter"
 	"time"
 
+	devboxpkg "github.com/signadot/cli/internal/devbox"
 	"github.com/signadot/cli/internal/sdtab"
 	"github.com/signadot/go-sdk/models"
 	"github.com/xeonx/timeago"
@@ -39,21 +42,7 @@ func printDevboxTable(out io.Writer, devboxes []*models.Devbox, currentDevboxID
 	}
 
 	for _, db := range devboxes {
-		status := "inactive"
-		if db.Status != nil && db.Status.Session != nil && db.Status.Session.ValidUntil != "" {
-			validUntil, err := time.Parse(time.RFC3339, db.Status.Session.ValidUntil)
-			if err == nil {
-				now := time.Now()
-				if validUntil.After(now) {
-					status = "active"
-				} else {
-					// Session has expired, show how long ago
-					duration := now.Sub(validUntil)
-					timeAgo := timeago.NoMax(timeago.English).FormatRelativeDuration(duration)
-					status = "expired " + timeAgo
-				}
-			}
-		}
+		status := devboxStatus(db)
 
 		// Mark current devbox as "default" in status column
 		isDefault := currentDevboxID != "" && db.ID == currentDevboxID
@@ -82,3 +71,71 @@ func printDevboxTable(out io.Writer, devboxes []*models.Devbox, currentDevboxID
 
 	return t.Flush()
 }
+
+// devboxStatus returns whether the devbox has an active session, or how long
+// ago its last session expired.
+func devboxStatus(db *models.Devbox) string {
+	if db.Status == nil || db.Status.Session == nil || db.Status.Session.ValidUntil == "" {
+		return "inactive"
+	}
+	validUntil, err := time.Parse(time.RFC3339, db.Status.Session.ValidUntil)
+	if err != nil {
+		return "inactive"
+	}
+	now := time.Now()
+	if validUntil.After(now) {
+		return "active"
+	}
+	// Session has expired, show how long ago
+	duration := now.Sub(validUntil)
+	return "expired " + timeago.NoMax(timeago.English).FormatRelativeDuration(duration)
+}
+
+type sessionRow struct {
+	SessionID string `sdtab:"SESSION ID"`
+	Cluster   string `sdtab:"CLUSTER"`
+	Started   string `sdtab:"STARTED"`
+	Ended     string `sdtab:"ENDED"`
+}
+
+// printDevboxDetails prints a devbox along with its session history.
+func printDevboxDetails(out io.Writer, db *models.Devbox, history []*devboxpkg.SessionRecord) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", db.ID)
+	fmt.Fprintf(tw, "Name:\t%s\n", db.Metadata["name"])
+	fmt.Fprintf(tw, "Host:\t%s\n", db.Metadata["host"])
+	fmt.Fprintf(tw, "OS:\t%s/%s\n", db.Metadata["goos"], db.Metadata["goarch"])
+	fmt.Fprintf(tw, "Machine ID:\t%s\n", db.Metadata["machine-id"])
+	fmt.Fprintf(tw, "Status:\t%s\n", devboxStatus(db))
+	if db.Status != nil && db.Status.Session != nil {
+		fmt.Fprintf(tw, "Session ID:\t%s\n", db.Status.Session.ID)
+		fmt.Fprintf(tw, "Session Valid Until:\t%s\n", db.Status.Session.ValidUntil)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nSession History (this machine):\n")
+	if len(history) == 0 {
+		fmt.Fprintf(out, "No sessions recorded.\n")
+		return nil
+	}
+	t := sdtab.New[sessionRow](out)
+	t.AddHeader()
+	for _, rec := range history {
+		row := sessionRow{
+			SessionID: rec.SessionID,
+			Cluster:   rec.Cluster,
+			Started:   "?",
+			Ended:     "-",
+		}
+		if rec.StartedAt != nil {
+			row.Started = timeago.NoMax(timeago.English).Format(*rec.StartedAt)
+		}
+		if rec.EndedAt != nil {
+			row.Ended = timeago.NoMax(timeago.English).Format(*rec.EndedAt)
+		}
+		t.AddRow(row)
+	}
+	return t.Flush()
+}
@@ -0,0 +1,123 @@
+package devbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/signadot/cli/internal/config"
+	devboxpkg "github.com/signadot/cli/internal/devbox"
+	"github.com/signadot/cli/internal/utils/system"
+	"github.com/signadot/go-sdk/client/devboxes"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+	"github.com/xeonx/timeago"
+)
+
+func newPrune(devbox *config.Devbox) *cobra.Command {
+	cfg := &config.DevboxPrune{Devbox: devbox}
+
+	cmd := &cobra.Command{
+		Use:   "prune --idle DURATION [--dry-run]",
+		Short: "Delete your idle devboxes",
+		Long: `Delete your devboxes which have not been used for at least the given duration.
+
+A devbox was last used when its last session expired, or when its last
+session from this machine ended. Devboxes with an active session, and those
+whose last use is unknown, are kept. Note that the server also deletes the
+devboxes idle for 30 days.
+
+Example:
+  signadot devbox prune --idle 7d --dry-run
+  signadot devbox prune --idle 7d`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return prune(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+func prune(cfg *config.DevboxPrune, out, errOut io.Writer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := initDevbox(cfg.Devbox); err != nil {
+		return err
+	}
+
+	params := devboxes.NewGetDevboxesParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org)
+	resp, err := cfg.Client.Devboxes.GetDevboxes(params)
+	if err != nil {
+		return err
+	}
+	sdDir, err := system.GetSignadotDir()
+	if err != nil {
+		return err
+	}
+
+	var idle []*models.Devbox
+	for _, db := range resp.Payload {
+		if devboxStatus(db) == "active" {
+			continue
+		}
+		history, err := devboxpkg.LoadSessionHistory(sdDir, db.ID)
+		if err != nil {
+			return err
+		}
+		lastUsed := lastUsed(db, history)
+		if lastUsed.IsZero() || time.Since(lastUsed) < cfg.Idle {
+			continue
+		}
+		idle = append(idle, db)
+		fmt.Fprintf(out, "Devbox %s (%s) last used %s\n", db.ID, db.Metadata["name"],
+			timeago.NoMax(timeago.English).Format(lastUsed))
+	}
+	if len(idle) == 0 {
+		fmt.Fprintf(out, "No devboxes idle for %s.\n", cfg.Idle)
+		return nil
+	}
+	if cfg.DryRun {
+		fmt.Fprintf(out, "%d devbox(es) would be deleted.\n", len(idle))
+		return nil
+	}
+
+	var failed int
+	for _, db := range idle {
+		params := devboxes.NewDeleteDevboxParams().
+			WithContext(ctx).
+			WithOrgName(cfg.Org).
+			WithDevboxID(db.ID)
+		if _, err := cfg.Client.Devboxes.DeleteDevbox(params); err != nil {
+			fmt.Fprintf(errOut, "Warning: failed to delete devbox %s: %v\n", db.ID, err)
+			failed++
+			continue
+		}
+		if err := cleanupLocalDevboxID(db.ID, errOut); err != nil {
+			fmt.Fprintf(errOut, "Warning: failed to check local devbox ID file: %v\n", err)
+		}
+	}
+	fmt.Fprintf(out, "Deleted %d devbox(es).\n", len(idle)-failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d devbox(es)", failed)
+	}
+	return nil
+}
+
+// lastUsed returns when the devbox was last used, or the zero time if
+// unknown.
+func lastUsed(db *models.Devbox, history []*devboxpkg.SessionRecord) time.Time {
+	last := devboxpkg.LastActivity(history)
+	if db.Status != nil && db.Status.Session != nil {
+		validUntil, err := time.Parse(time.RFC3339, db.Status.Session.ValidUntil)
+		if err == nil && validUntil.After(last) {
+			last = validUntil
+		}
+	}
+	return last
+}
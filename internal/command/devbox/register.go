@@ -39,7 +39,7 @@ func register(cfg *config.DevboxRegister, out, log io.Writer) error {
 		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
-	if err := cfg.InitAPIConfig(); err != nil {
+	if err := initDevbox(cfg.Devbox); err != nil {
 		return err
 	}
 
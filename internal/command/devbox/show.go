@@ -0,0 +1,91 @@
+package devbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/signadot/cli/internal/config"
+	devboxpkg "github.com/signadot/cli/internal/devbox"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/utils/system"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newShow(devbox *config.Devbox) *cobra.Command {
+	cfg := &config.DevboxShow{Devbox: devbox}
+
+	cmd := &cobra.Command{
+		Use:   "show [ID]",
+		Short: "Show a devbox and its session history",
+		Long: `Show a devbox, by default the one of this machine (or of the selected
+identity), along with the history of its sessions started from this machine.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cfg.ID = args[0]
+			}
+			return show(cfg, cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+func show(cfg *config.DevboxShow, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := initDevbox(cfg.Devbox); err != nil {
+		return err
+	}
+
+	id := cfg.ID
+	if id == "" {
+		var err error
+		id, err = devboxpkg.GetDefaultDevboxID()
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			return errors.New("no devbox registered on this machine, see 'signadot devbox register'")
+		}
+	}
+	db, err := get(ctx, cfg.API, id)
+	if err != nil {
+		return err
+	}
+
+	sdDir, err := system.GetSignadotDir()
+	if err != nil {
+		return err
+	}
+	history, err := devboxpkg.LoadSessionHistory(sdDir, id)
+	if err != nil {
+		return err
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		return printDevboxDetails(out, db, history)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, devboxDetails(db, history))
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, devboxDetails(db, history))
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+func devboxDetails(db *models.Devbox, history []*devboxpkg.SessionRecord) any {
+	return struct {
+		*models.Devbox
+		SessionHistory []*devboxpkg.SessionRecord `json:"sessionHistory"`
+	}{
+		Devbox:         db,
+		SessionHistory: history,
+	}
+}
@@ -1,12 +1,16 @@
 package hostedtest
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/signadot/cli/internal/command/smarttest"
 	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/poll"
+	"github.com/signadot/cli/internal/report"
 	"github.com/signadot/go-sdk/client/test_executions"
 	"github.com/signadot/go-sdk/models"
 	libconncommon "github.com/signadot/libconnect/common"
@@ -22,17 +26,20 @@ func newRun(tConfig *config.HostedTest) *cobra.Command {
 		Short: "Run a hosted test",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return run(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args)
+			return run(cmd.Context(), cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args)
 		},
 	}
 	cfg.AddFlags(cmd)
 	return cmd
 }
 
-func run(cfg *config.HostedTestRun, wOut, wErr io.Writer, args []string) error {
+func run(ctx context.Context, cfg *config.HostedTestRun, wOut, wErr io.Writer, args []string) error {
 	if err := cfg.InitAPIConfig(); err != nil {
 		return err
 	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
 
 	// Handle single test execution
 	if cfg.Cluster == "" {
@@ -82,5 +89,44 @@ func run(cfg *config.HostedTestRun, wOut, wErr io.Writer, args []string) error {
 	if !result.IsSuccess() {
 		return errors.New(result.Error())
 	}
-	return smarttest.PrintTestExecution(cfg.OutputFormat, wOut, result.Payload)
+	tx := result.Payload
+	if cfg.Wait {
+		tx, err = waitTestExecution(ctx, cfg, tx.ID)
+		if err != nil {
+			return fmt.Errorf("error waiting for test execution %q: %w", result.Payload.ID, err)
+		}
+	}
+	if err := smarttest.PrintTestExecution(cfg.OutputFormat, wOut, tx); err != nil {
+		return err
+	}
+	return report.Write(cfg.Reports, smarttest.TestRunSuite("hosted test "+testName, []*models.TestExecution{tx}))
+}
+
+func waitTestExecution(ctx context.Context, cfg *config.HostedTestRun, execID string) (*models.TestExecution, error) {
+	params := test_executions.NewGetTestExecutionParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithExecutionID(execID)
+
+	var tx *models.TestExecution
+	err := poll.
+		NewPoll().
+		WithDelay(2*time.Second).
+		WithTimeout(cfg.Timeout).
+		UntilWithError(ctx, func(ctx context.Context) (bool, error) {
+			result, err := cfg.Client.TestExecutions.GetTestExecution(params, nil)
+			if err != nil {
+				return false, err
+			}
+			tx = result.Payload
+			switch tx.Status.Phase {
+			case models.TestexecutionsPhasePending, models.TestexecutionsPhaseInProgress:
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/signadot/cli/internal/config"
 	"github.com/signadot/cli/internal/sdtab"
+	cliutils "github.com/signadot/cli/internal/utils"
 	"github.com/signadot/go-sdk/models"
 	"github.com/signadot/go-sdk/utils"
 	"github.com/xeonx/timeago"
@@ -277,7 +278,7 @@ type jobArtifactRow struct {
 }
 
 func printArtifacts(cfg *config.JobGet, out io.Writer, job *models.Job) error {
-	artifactsList, err := getArtifacts(cfg, job)
+	artifactsList, err := cliutils.GetJobArtifacts(context.Background(), cfg.API, job.Name, job.Status.Attempts[0].ID)
 	if err != nil {
 		return err
 	}
@@ -292,21 +293,16 @@ func printArtifacts(cfg *config.JobGet, out io.Writer, job *models.Job) error {
 	t := sdtab.New[jobArtifactRow](out)
 	t.AddHeader()
 
-	excludeFiles := map[string]bool{"stderr.index": true, "stdout.index": true}
 	for _, artifact := range artifactsList {
 		path := artifact.Path
 
-		if _, ok := excludeFiles[path]; ok {
-			continue
-		}
-
 		if artifact.Space == "system" {
 			path = "@" + path
 		}
 
 		t.AddRow(jobArtifactRow{
 			Path: path,
-			Size: byteCountSI(artifact.Size),
+			Size: cliutils.ByteCountSI(artifact.Size),
 		})
 	}
 	return t.Flush()
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/signadot/cli/internal/command/logs"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/go-sdk/models"
+	"github.com/signadot/go-sdk/utils"
+)
+
+// jobSuite returns the test report of a finished job: a single test case
+// holding the logs of its attempt.
+func jobSuite(ctx context.Context, cfg *config.JobSubmit, job *models.Job) report.Suite {
+	c := report.Case{
+		Name:      job.Name,
+		Classname: "job",
+		Output:    jobLogs(ctx, cfg, job.Name),
+	}
+	if job.Spec != nil && job.Spec.RunnerGroup != "" {
+		c.Classname = job.Spec.RunnerGroup
+	}
+	suite := report.Suite{Name: "job " + job.Name}
+	if len(job.Status.Attempts) == 0 {
+		c.Status = report.StatusError
+		c.Message = "job has no attempts"
+		suite.Cases = []report.Case{c}
+		return suite
+	}
+
+	attempt := job.Status.Attempts[0]
+	c.Time = report.Between(attempt.CreatedAt, attempt.FinishedAt)
+	switch attempt.Phase {
+	case models.JobsPhaseSucceeded:
+		c.Status = report.StatusPassed
+	case models.JobsPhaseFailed:
+		c.Status = report.StatusFailed
+		c.Message = "job failed"
+		if st := attempt.State; st != nil && st.Failed != nil {
+			if st.Failed.Message != "" {
+				c.Message = st.Failed.Message
+			}
+			if st.Failed.ExitCode != nil {
+				c.Message += fmt.Sprintf(" (exit code %d)", *st.Failed.ExitCode)
+			}
+		}
+	case models.JobsPhaseCanceled:
+		c.Status = report.StatusError
+		c.Message = "job canceled"
+		if st := attempt.State; st != nil && st.Canceled != nil && st.Canceled.Message != "" {
+			c.Message = st.Canceled.Message
+		}
+	default:
+		c.Status = report.StatusError
+		c.Message = fmt.Sprintf("job did not finish (%s)", attempt.Phase)
+	}
+	suite.Timestamp = report.ParseTime(attempt.CreatedAt)
+	suite.Time = c.Time
+	suite.Cases = []report.Case{c}
+	return suite
+}
+
+// jobLogs returns the stdout and stderr logs of a finished job. Logs that
+// can't be fetched are noted instead, so the report is still written.
+func jobLogs(ctx context.Context, cfg *config.JobSubmit, jobName string) string {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var out bytes.Buffer
+	for _, stream := range []string{utils.LogTypeStdout, utils.LogTypeStderr} {
+		var buf bytes.Buffer
+		if _, err := logs.ShowLogs(ctx, cfg.API, &buf, jobName, stream, "", 0); err != nil {
+			fmt.Fprintf(&buf, "(fetching %s logs: %v)\n", stream, err)
+		}
+		if buf.Len() == 0 {
+			continue
+		}
+		fmt.Fprintf(&out, "--- %s ---\n", stream)
+		out.Write(buf.Bytes())
+	}
+	return out.String()
+}
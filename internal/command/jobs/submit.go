@@ -9,6 +9,7 @@ import (
 
 	"github.com/signadot/cli/internal/config"
 	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/report"
 	"github.com/signadot/go-sdk/client/jobs"
 	"github.com/signadot/go-sdk/models"
 	"github.com/spf13/cobra"
@@ -40,6 +41,9 @@ func submit(ctx context.Context, cfg *config.JobSubmit, outW, errW io.Writer) er
 	if cfg.Wait && cfg.Attach {
 		return errors.New("cannot specify both --attach and --wait")
 	}
+	if len(cfg.Reports) > 0 && !cfg.Wait {
+		return errors.New("--report requires --wait")
+	}
 	req, err := loadJob(cfg.Filename, cfg.TemplateVals, false /*forDelete */)
 	if err != nil {
 		return err
@@ -65,6 +69,11 @@ func submit(ctx context.Context, cfg *config.JobSubmit, outW, errW io.Writer) er
 	if err != nil {
 		return err
 	}
+	if len(cfg.Reports) > 0 {
+		if err := report.Write(cfg.Reports, jobSuite(ctx, cfg, resp)); err != nil {
+			return err
+		}
+	}
 	if cfg.Wait {
 		switch ph := resp.Status.Attempts[0].Phase; ph {
 		case "canceled", "failed":
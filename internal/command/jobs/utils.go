@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/signadot/cli/internal/config"
-	"github.com/signadot/go-sdk/client/artifacts"
 	"github.com/signadot/go-sdk/client/jobs"
 	"github.com/signadot/go-sdk/models"
 	"github.com/xeonx/timeago"
@@ -21,20 +20,6 @@ func getJob(cfg *config.Job, jobName string) (*models.Job, error) {
 	return resp.Payload, nil
 }
 
-func getArtifacts(cfg *config.JobGet, job *models.Job) ([]*models.JobArtifact, error) {
-	params := artifacts.NewListJobAttemptArtifactsParams().
-		WithOrgName(cfg.Org).
-		WithJobAttempt(job.Status.Attempts[0].ID).
-		WithJobName(job.Name)
-
-	resp, err := cfg.Client.Artifacts.ListJobAttemptArtifacts(params, nil)
-	if err != nil {
-		return []*models.JobArtifact{}, nil
-	}
-
-	return resp.Payload, nil
-}
-
 func isJobPhaseToPrintDefault(ph models.JobsPhase) bool {
 	if ph == models.JobsPhaseFailed {
 		return false
@@ -115,17 +100,3 @@ func getJobStatus(job *models.Job) string {
 	}
 	return "Unknown"
 }
-
-func byteCountSI(b int64) string {
-	const unit = 1000
-	if b < unit {
-		return fmt.Sprintf("%d B", b)
-	}
-	div, exp := int64(unit), 0
-	for n := b / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB",
-		float64(b)/float64(div), "kMGTPE"[exp])
-}
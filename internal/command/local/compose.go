@@ -0,0 +1,320 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/command/sandbox"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/local"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+func newCompose(localConfig *config.Local) *cobra.Command {
+	cfg := &config.LocalCompose{Local: localConfig}
+
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Run docker compose services in the context of a sandbox",
+	}
+
+	// Subcommands
+	cmd.AddCommand(
+		newComposeUp(cfg),
+	)
+
+	return cmd
+}
+
+func newComposeUp(composeConfig *config.LocalCompose) *cobra.Command {
+	cfg := &config.LocalComposeUp{LocalCompose: composeConfig}
+
+	cmd := &cobra.Command{
+		Use:   "up --sandbox SANDBOX [-f FILE] [--service LOCAL=SERVICE] [-- UP_ARGS...]",
+		Short: "Run docker compose up with the environment of a sandbox",
+		Long: `Run 'docker compose up' injecting into the compose services the environment
+of the sandbox local workloads they implement, as 'sandbox get-env' computes
+it, along with the cluster hosts managed by 'local connect'.
+
+Each local workload is mapped to the compose service with the same name,
+unless specified otherwise with --service. To route the sandbox traffic to
+the containers, use a compose address in the local workload mappings:
+
+  toLocal: compose://<service>:<port>
+
+Arguments after '--' are passed to 'docker compose up'.`,
+		Example: `  # Run the compose services implementing the local workloads of my-sandbox
+  signadot local compose up -f docker-compose.yml --sandbox my-sandbox
+
+  # Map the local workload route to the compose service route-svc, detached
+  signadot local compose up --sandbox my-sandbox --service route=route-svc -- -d`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exitCode, err := runComposeUp(cmd.Context(), cmd.ErrOrStderr(), cfg, args)
+			if err != nil {
+				return err
+			}
+			if exitCode != 0 {
+				os.Exit(exitCode)
+			}
+			return nil
+		},
+	}
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+type composeOverride struct {
+	Services map[string]*composeServiceOverride `json:"services"`
+}
+
+type composeServiceOverride struct {
+	Environment map[string]string `json:"environment,omitempty"`
+	ExtraHosts  []string          `json:"extra_hosts,omitempty"`
+}
+
+func runComposeUp(rootCtx context.Context, errOut io.Writer, cfg *config.LocalComposeUp, args []string) (int, error) {
+	ctx, cancel := signal.NotifyContext(rootCtx,
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if err := cfg.InitLocalConfig(); err != nil {
+		return 0, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return 0, err
+	}
+
+	sb, err := utils.GetSandbox(ctx, cfg.API, cfg.Sandbox)
+	if err != nil {
+		return 0, err
+	}
+	services, err := composeServices(ctx, cfg.Filenames)
+	if err != nil {
+		return 0, err
+	}
+	override := &composeOverride{
+		Services: make(map[string]*composeServiceOverride, len(services)),
+	}
+	for _, svc := range services {
+		override.Services[svc] = &composeServiceOverride{}
+	}
+
+	// inject the environment of the local workloads
+	for localName := range cfg.Services {
+		if !hasLocal(sb.Spec.Local, localName) {
+			return 0, fmt.Errorf("local %s not found in sandbox %s", localName, sb.Name)
+		}
+	}
+	kubeClient, err := local.GetLocalKubeClient()
+	if err != nil {
+		return 0, err
+	}
+	for _, sbLocal := range sb.Spec.Local {
+		svc, mapped := cfg.Services[sbLocal.Name]
+		if !mapped {
+			svc = sbLocal.Name
+		}
+		svcOverride := override.Services[svc]
+		if svcOverride == nil {
+			if mapped {
+				return 0, fmt.Errorf("no service %q in compose configuration", svc)
+			}
+			fmt.Fprintf(errOut, "WARNING: no compose service for local %s, skipping\n", sbLocal.Name)
+			continue
+		}
+		envCtx, envCancel := context.WithTimeout(ctx, 15*time.Second)
+		localEnv, err := sandbox.ResolveLocalEnv(envCtx, kubeClient, sb, sbLocal.Name, "")
+		envCancel()
+		if err != nil {
+			return 0, err
+		}
+		if err := sandbox.PrintLocalEnvWarnings(errOut, localEnv); err != nil {
+			return 0, err
+		}
+		svcOverride.Environment = make(map[string]string, len(localEnv.Env))
+		for _, item := range localEnv.Env {
+			// compose interpolates variables in the configuration files
+			svcOverride.Environment[item.Name] = strings.ReplaceAll(item.Value, "$", "$$")
+		}
+	}
+
+	// inject the cluster hosts
+	if !cfg.NoHosts {
+		extraHosts, err := clusterHosts(cfg.LocalConfig.VirtualIPNet)
+		if err != nil {
+			return 0, err
+		}
+		for _, svcOverride := range override.Services {
+			svcOverride.ExtraHosts = extraHosts
+		}
+	}
+
+	// write the override file
+	d, err := yaml.Marshal(override)
+	if err != nil {
+		return 0, err
+	}
+	overrideDir, err := os.MkdirTemp("", "signadot-compose-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(overrideDir)
+	overrideFile := filepath.Join(overrideDir, "signadot-override.yaml")
+	if err := os.WriteFile(overrideFile, d, 0600); err != nil {
+		return 0, err
+	}
+
+	// run docker compose up
+	filenames := cfg.Filenames
+	if len(filenames) == 0 {
+		filenames, err = defaultComposeFiles()
+		if err != nil {
+			return 0, err
+		}
+	}
+	composeArgs := append(composeFileArgs(filenames), "-f", overrideFile, "up")
+	composeArgs = append(composeArgs, args...)
+	proc := exec.Command("docker", append([]string{"compose"}, composeArgs...)...)
+	proc.Stdin = os.Stdin
+	proc.Stdout = os.Stdout
+	proc.Stderr = os.Stderr
+	if err := proc.Start(); err != nil {
+		return 0, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- proc.Wait()
+	}()
+	select {
+	case err := <-done:
+		return exitCode(err)
+	case <-ctx.Done():
+		return exitCode(stopProcess(proc, done))
+	}
+}
+
+// composeServices returns the services defined in the compose configuration.
+func composeServices(ctx context.Context, filenames []string) ([]string, error) {
+	args := append([]string{"compose"}, composeFileArgs(filenames)...)
+	args = append(args, "config", "--services")
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read compose configuration: %w: %s", err,
+			strings.TrimSpace(stderr.String()))
+	}
+	return strings.Fields(string(out)), nil
+}
+
+func composeFileArgs(filenames []string) []string {
+	res := make([]string, 0, 2*len(filenames))
+	for _, f := range filenames {
+		res = append(res, "-f", f)
+	}
+	return res
+}
+
+// defaultComposeFiles returns the files docker compose would pick, as
+// passing any -f disables its own discovery: the ones listed in COMPOSE_FILE
+// if set, or else the compose file of the current directory along with its
+// override file, if any.
+func defaultComposeFiles() ([]string, error) {
+	if v := os.Getenv("COMPOSE_FILE"); v != "" {
+		sep := os.Getenv("COMPOSE_PATH_SEPARATOR")
+		if sep == "" {
+			sep = string(os.PathListSeparator)
+		}
+		var res []string
+		for _, f := range strings.Split(v, sep) {
+			if f != "" {
+				res = append(res, f)
+			}
+		}
+		if len(res) > 0 {
+			return res, nil
+		}
+	}
+	for _, f := range []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"} {
+		if _, err := os.Stat(f); err != nil {
+			continue
+		}
+		res := []string{f}
+		ext := filepath.Ext(f)
+		override := strings.TrimSuffix(f, ext) + ".override" + ext
+		if _, err := os.Stat(override); err == nil {
+			res = append(res, override)
+		}
+		return res, nil
+	}
+	return nil, errors.New("no compose configuration file found, specify one with --file or COMPOSE_FILE")
+}
+
+func hasLocal(locals []*models.Local, name string) bool {
+	for _, l := range locals {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// clusterHosts returns the host:ip entries of the hosts file that resolve to
+// the local virtual IP network, that is the cluster hosts setup by 'local
+// connect'.
+func clusterHosts(virtualIPNet string) ([]string, error) {
+	_, ipNet, err := net.ParseCIDR(virtualIPNet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid virtual IP network %q: %w", virtualIPNet, err)
+	}
+	hostsFile := "/etc/hosts"
+	if runtime.GOOS == "windows" {
+		hostsFile = `C:\Windows\System32\Drivers\etc\hosts`
+	}
+	f, err := os.Open(hostsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var res []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		ip := net.ParseIP(fields[0])
+		if ip == nil || !ipNet.Contains(ip) {
+			continue
+		}
+		for _, host := range fields[1:] {
+			res = append(res, host+":"+fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(res)
+	return res, nil
+}
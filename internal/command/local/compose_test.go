@@ -0,0 +1,51 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultComposeFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("COMPOSE_FILE", "")
+	t.Setenv("COMPOSE_PATH_SEPARATOR", "")
+
+	if _, err := defaultComposeFiles(); err == nil {
+		t.Errorf("no compose file: got no error")
+	}
+
+	for _, f := range []string{"docker-compose.yml", "docker-compose.override.yml"} {
+		if err := os.WriteFile(filepath.Join(dir, f), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := defaultComposeFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"docker-compose.yml", "docker-compose.override.yml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("discovered files: got %v, want %v", got, want)
+	}
+
+	t.Setenv("COMPOSE_FILE", "a.yaml"+string(os.PathListSeparator)+"b.yaml")
+	got, err = defaultComposeFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a.yaml", "b.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("COMPOSE_FILE: got %v, want %v", got, want)
+	}
+
+	t.Setenv("COMPOSE_FILE", "a.yaml,b.yaml")
+	t.Setenv("COMPOSE_PATH_SEPARATOR", ",")
+	got, err = defaultComposeFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a.yaml", "b.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("COMPOSE_PATH_SEPARATOR: got %v, want %v", got, want)
+	}
+}
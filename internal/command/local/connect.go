@@ -3,6 +3,7 @@ package local
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -91,6 +92,12 @@ func runConnect(cmd *cobra.Command, out io.Writer, cfg *config.LocalConnect, arg
 		devboxID string
 		claimed  bool
 	)
+	if cfg.Devbox != "" && cfg.DevboxIdentity != "" {
+		return errors.New("only one of --devbox or --devbox-identity can be specified")
+	}
+	if err := devbox.SelectIdentity("", cfg.DevboxIdentity); err != nil {
+		return err
+	}
 	if cfg.Devbox != "" {
 		// If devbox ID is provided, validate it exists
 		if err := devbox.ValidateDevboxID(ctx, cfg.API, cfg.Devbox); err != nil {
@@ -20,6 +20,8 @@ func New(api *config.API) *cobra.Command {
 		newStatus(cfg),
 		newDisconnect(cfg),
 		newProxy(cfg),
+		newRun(cfg),
+		newCompose(cfg),
 		override.New(cfg),
 	)
 
@@ -11,7 +11,7 @@ func New(local *config.Local) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "override --sandbox=<sandbox> [--workload=<workload>] --workload-port=<port> --with=<target> [--except-status=...] [--detach]",
+		Use:   "override [-f <file>] --sandbox=<sandbox> [--workload=<workload>] --workload-port=<port> --with=<target> [--except-status=...] [--record-dir=<dir>] [--inspect] [--detach]",
 		Short: "Override sandbox HTTP traffic using a local service",
 		Long: `The 'override' command lets you intercept both HTTP and gRPC traffic coming into your sandbox and process it with a local service you specify (such as on your laptop).
 
@@ -28,6 +28,19 @@ Special case: Using '--except-status'
 - With this flag, all requests are overridden and served by your local service, EXCEPT when your local service returns one of the specified HTTP status codes.
 - In those cases (when your local service replies with a status listed in '--except-status'), the request is forwarded to the original sandbox workload, and its response is returned to the client instead.
 
+The override and its policy can also be declared in a YAML file with '-f', flags taking precedence over the file:
+
+  sandbox: my-sandbox
+  workload: my-workload
+  workloadPort: 8080
+  with: localhost:9999
+  policy:
+    exceptStatus: [404, 503]
+
+With '--record-dir', the requests sent to your local service and its responses are recorded, in the format of 'traffic record', along with the decision taken for each request (overridden or fallen through to the sandbox). '--inspect' opens the recorded traffic in the traffic watch TUI instead of printing the traffic logs, recording by default to '$HOME/.signadot/traffic/override/<sandbox>'. Recorded traffic can be browsed later with 'signadot traffic inspect --dir DIR'.
+
+A sandbox can have several overrides, as long as they intercept different workloads or ports. Overrides are recorded in '$HOME/.signadot/overrides.json' so that 'override restore' can re-create and re-attach to them, for instance after a reboot.
+
 This setup allows flexible and powerful local testing of changes for both HTTP and gRPC services while letting you make exceptions for specific HTTP status codes as needed.`,
 		Example: `  # Override sandbox traffic from workload my-workload, port 8080 to localhost:9999
   signadot local override --sandbox=my-sandbox --workload=my-workload --workload-port=8080 --with=localhost:9999
@@ -35,6 +48,12 @@ This setup allows flexible and powerful local testing of changes for both HTTP a
   # Bypass override when the response returns 404 and 503
   signadot local override --sandbox=my-sandbox --workload=my-workload --workload-port=8080 --with=localhost:9999 --except-status=404,503
 
+  # Declare the override in a file
+  signadot local override -f override.yaml
+
+  # Inspect the overridden traffic in the traffic watch TUI
+  signadot local override --sandbox=my-sandbox --workload=my-workload --workload-port=8080 --with=localhost:9999 --inspect
+
   # Keep the override active after the CLI session ends
   signadot local override --sandbox=my-sandbox --workload=my-workload --workload-port=8080 --with=localhost:9999 --detach
 
@@ -42,8 +61,14 @@ This setup allows flexible and powerful local testing of changes for both HTTP a
   signadot local override list
 
   # Delete a specific override
-  signadot local override delete <name> --sandbox=<sandbox>`,
+  signadot local override delete <name> --sandbox=<sandbox>
+
+  # Restore the overrides created from this machine (e.g. after a reboot)
+  signadot local override restore`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cfg.LoadFile(cmd); err != nil {
+				return err
+			}
 			return runOverride(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), cfg)
 		},
 	}
@@ -55,6 +80,7 @@ This setup allows flexible and powerful local testing of changes for both HTTP a
 	cmd.AddCommand(
 		newDelete(cfg.LocalOverride),
 		newList(cfg.LocalOverride),
+		newRestore(cfg.LocalOverride),
 	)
 
 	return cmd
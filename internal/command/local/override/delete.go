@@ -69,6 +69,9 @@ func runDelete(out io.Writer, cfg *config.LocalOverrideDelete, name string) erro
 	if err := deleteOverrideFromSandbox(ctx, cfg.API, sb, name); err != nil {
 		return err
 	}
+	if err := forgetOverride(cfg.Sandbox, name); err != nil {
+		return err
+	}
 
 	printOverrideStatus(out, fmt.Sprintf("Override %s deleted successfully from sandbox %s", name, cfg.Sandbox), true)
 
@@ -2,13 +2,14 @@ package override
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
 
 	"github.com/signadot/cli/internal/builder"
 	"github.com/signadot/cli/internal/config"
-	"github.com/signadot/cli/internal/devbox"
+	sbmgr "github.com/signadot/cli/internal/locald/sandboxmanager"
 	"github.com/signadot/cli/internal/utils"
 	"github.com/signadot/go-sdk/client/sandboxes"
 	"github.com/signadot/go-sdk/models"
@@ -16,15 +17,25 @@ import (
 
 type undoFunc func(ctx context.Context, w io.Writer) error
 
-func hasSandboxOverride(sb *models.Sandbox) bool {
-	return len(builder.GetAvailableOverrideMiddlewares(sb)) > 0
+// sessionDevboxID returns the ID of the devbox of the local session, making
+// sure the sandbox manager is running against the cluster of the sandbox
+// (signadot local connect has been executed).
+func sessionDevboxID(sandbox *models.Sandbox) (string, error) {
+	stResp, err := sbmgr.ValidateSandboxManager(sandbox.Spec.Cluster)
+	if err != nil {
+		return "", err
+	}
+	if stResp.DevboxSession == nil {
+		return "", errors.New("not connected with a devbox session (CLI locald version mismatch) please disconnect and reconnect")
+	}
+	return stResp.DevboxSession.DevboxId, nil
 }
 
 func applyOverrideToSandbox(ctx context.Context, cfg *config.LocalOverrideCreate,
 	baseSandbox *models.Sandbox, workloadName, devboxID string, logPort int,
 ) (*models.Sandbox, string, undoFunc, error) {
 	// generate the override mw policy arg
-	policyArg, err := builder.NewOverrideArgPolicy(cfg.ExcludedStatusCodes)
+	policyArg, err := builder.NewOverrideArgPolicy(&cfg.Policy)
 	if err != nil {
 		return nil, "", noOpUndo, err
 	}
@@ -74,7 +85,7 @@ func applyOverrideToSandbox(ctx context.Context, cfg *config.LocalOverrideCreate
 func deleteOverrideFromSandbox(ctx context.Context, cfg *config.API,
 	sandbox *models.Sandbox, overrideName string) error {
 	// Use the sandbox builder to delete the override
-	devboxID, err := devbox.GetID(ctx, cfg, false, "")
+	devboxID, err := sessionDevboxID(sandbox)
 	if err != nil {
 		return err
 	}
@@ -99,6 +110,37 @@ func deleteOverrideFromSandbox(ctx context.Context, cfg *config.API,
 	return err
 }
 
+// setOverrideLog attaches the override to a log server listening on logPort,
+// or detaches it from any log server if logPort is 0
+func setOverrideLog(ctx context.Context, cfg *config.API, sandbox *models.Sandbox,
+	devboxID, overrideName string, logPort int) error {
+	sbBuilder := builder.
+		BuildSandbox(sandbox.Name, builder.WithData(*sandbox)).
+		SetDevboxID(devboxID)
+	if logPort > 0 {
+		sbBuilder.AttachOverrideLog(overrideName, logPort)
+	} else {
+		sbBuilder.DetachOverrideLog(overrideName)
+	}
+
+	sb, err := sbBuilder.Build()
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(&sb, sandbox) {
+		return nil
+	}
+
+	params := sandboxes.NewApplySandboxParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithSandboxName(sandbox.Name).
+		WithData(&sb)
+
+	_, err = cfg.Client.Sandboxes.ApplySandbox(params, nil)
+	return err
+}
+
 func mkUndo(cfg *config.LocalOverrideCreate, overrideName string) undoFunc {
 	return func(ctx context.Context, out io.Writer) error {
 		sb, err := utils.GetSandbox(ctx, cfg.API, cfg.Sandbox)
@@ -106,7 +148,10 @@ func mkUndo(cfg *config.LocalOverrideCreate, overrideName string) undoFunc {
 			return err
 		}
 		printOverrideProgress(out, fmt.Sprintf("Removing override from %s", cfg.Sandbox))
-		return deleteOverrideFromSandbox(ctx, cfg.API, sb, overrideName)
+		if err := deleteOverrideFromSandbox(ctx, cfg.API, sb, overrideName); err != nil {
+			return err
+		}
+		return forgetOverride(cfg.Sandbox, overrideName)
 	}
 }
 
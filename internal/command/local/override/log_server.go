@@ -11,9 +11,10 @@ import (
 	"github.com/signadot/libconnect/common/override"
 )
 
-// createLogServer creates an HTTP server and listener for log consumption
+// createLogServer creates an HTTP server and listener for log consumption,
+// calling onEntry for each received log entry
 // Returns the server, listener, and the actual port that was assigned
-func createLogServer(sandboxName, localAddress string) (*http.Server, net.Listener, int) {
+func createLogServer(onEntry func(*override.LogEntry)) (*http.Server, net.Listener, int) {
 	mux := http.NewServeMux()
 
 	ln, err := net.Listen("tcp", ":0")
@@ -44,7 +45,7 @@ func createLogServer(sandboxName, localAddress string) (*http.Server, net.Listen
 			return
 		}
 
-		printFormattedLogEntry(&logEntry, sandboxName, localAddress)
+		onEntry(&logEntry)
 
 		w.WriteHeader(http.StatusOK)
 	})
@@ -14,9 +14,10 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/signadot/cli/internal/builder"
 	"github.com/signadot/cli/internal/config"
-	sbmgr "github.com/signadot/cli/internal/locald/sandboxmanager"
 	"github.com/signadot/cli/internal/poll"
+	"github.com/signadot/cli/internal/tui"
 	"github.com/signadot/cli/internal/utils"
 	"github.com/signadot/go-sdk/models"
 	"github.com/signadot/libconnect/common/override"
@@ -49,18 +50,46 @@ func runOverride(rootCtx context.Context, out, errOut io.Writer,
 	if err := validateWorkload(sb, cfg.Workload); err != nil {
 		return err
 	}
-	if hasSandboxOverride(sb) {
-		return errors.New("the sandbox already has an override, delete it before proceeding.")
+	if o := builder.GetConflictingOverride(sb, cfg.Port, []string{cfg.Workload}); o != nil {
+		return fmt.Errorf("port %d of workload %s is already overridden by %s, delete it before proceeding",
+			cfg.Port, cfg.Workload, o.Forward.Name)
 	}
 
-	// Make sure sandbox manager is running against the sandbox cluster
-	// (signadot local connect has been executed)
-	stResp, err := sbmgr.ValidateSandboxManager(sb.Spec.Cluster)
+	devboxID, err := sessionDevboxID(sb)
 	if err != nil {
 		return err
 	}
-	if stResp.DevboxSession == nil {
-		return errors.New("not connected with a devbox session (CLI locald version mismatch) please disconnect and reconnect")
+
+	// Create the traffic recorder (if needed)
+	var (
+		recorder  *trafficRecorder
+		recordDir string
+	)
+	applyCfg := cfg
+	if cfg.RecordDir != "" || cfg.Inspect {
+		recordDir = cfg.RecordDir
+		if recordDir == "" {
+			recordDir, err = overrideRecordDir(cfg.Sandbox)
+			if err != nil {
+				return err
+			}
+		}
+		recorder, err = newTrafficRecorder(recordDir, cfg.To, sb.RoutingKey, cfg.Workload, cfg.Policy)
+		if err != nil {
+			return err
+		}
+		recorder.Start(ctx)
+		defer func() {
+			if err := recorder.Err(); err != nil {
+				fmt.Fprintf(errOut, "WARNING: %v\n", err)
+			}
+		}()
+		fmt.Fprintf(out, "Traffic will be written to %s.\n", recordDir)
+
+		// route the override traffic through the recorder
+		recorderCfg := *cfg
+		recorderCfg.To = recorder.Addr()
+		applyCfg = &recorderCfg
 	}
 
 	// Create the log server (if needed)
@@ -70,17 +99,37 @@ func runOverride(rootCtx context.Context, out, errOut io.Writer,
 		logPort     int
 	)
 	if !cfg.Detach {
-		logServer, logListener, logPort = createLogServer(cfg.Sandbox, cfg.To)
+		logServer, logListener, logPort = createLogServer(func(e *override.LogEntry) {
+			if !cfg.Inspect {
+				printFormattedLogEntry(e, cfg.Sandbox, cfg.To)
+			}
+		})
 	}
 
 	// Apply the override to the sandbox
 	printOverrideProgress(out, fmt.Sprintf("Applying override to %s", cfg.Sandbox))
-	_, overrideName, undo, err := applyOverrideToSandbox(ctx, cfg, sb, cfg.Workload,
-		stResp.DevboxSession.DevboxId, logPort)
+	_, overrideName, undo, err := applyOverrideToSandbox(ctx, applyCfg, sb, cfg.Workload,
+		devboxID, logPort)
 	if err != nil {
 		return err
 	}
 
+	// Record the override, so that it can be restored
+	err = recordOverride(overrideRecord{
+		Name:      overrideName,
+		Sandbox:   cfg.Sandbox,
+		Workload:  cfg.Workload,
+		Port:      cfg.Port,
+		To:        cfg.To,
+		Policy:    cfg.Policy,
+		Detached:  cfg.Detach,
+		CreatedAt: time.Now(),
+		RecordDir: recordDir,
+	})
+	if err != nil {
+		fmt.Fprintf(errOut, "WARNING: unable to record override %s: %v\n", overrideName, err)
+	}
+
 	// NOTE we should keep the single 'retErr' from here down
 	var retErr error
 	if !cfg.Detach {
@@ -115,20 +164,16 @@ func runOverride(rootCtx context.Context, out, errOut io.Writer,
 	fmt.Fprintf(out, "%s Local destination %s will override sandbox responses as follows:\n\nAll HTTP/gRPC requests intended for sandbox %s, workload %s, port %d will be sent to your local service at %s.\n\n",
 		green("✓"), cfg.To, bold(cfg.Sandbox), bold(cfg.Workload), cfg.Port, bold(cfg.To))
 
-	if len(cfg.ExcludedStatusCodes) > 0 {
-		codes := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(cfg.ExcludedStatusCodes)), ","), "[]")
-		fmt.Fprintf(out, "* If your local service (%s) responds with status code(s) %s:\n", bold(cfg.To), codes)
-		fmt.Fprintf(out, "    -> Request is forwarded to the sandbox (%s).\n", bold(cfg.Sandbox))
-		fmt.Fprintf(out, "* Otherwise:\n")
-		fmt.Fprintf(out, "    -> Response from your local service (%s) is returned to the client.\n", bold(cfg.To))
-	} else {
-		fmt.Fprintf(out, "* If your local service (%s) responds with header `sd-override: true`:\n", bold(cfg.To))
-		fmt.Fprintf(out, "    -> Response from your local service (%s) is returned to the client.\n", bold(cfg.To))
-		fmt.Fprintf(out, "* Otherwise:\n")
-		fmt.Fprintf(out, "    -> Request is forwarded to the sandbox (%s).\n", bold(cfg.Sandbox))
-	}
+	printOverridePolicy(out, cfg)
 	fmt.Fprintf(out, "\n")
 
+	if cfg.Inspect {
+		// Start the log server
+		startLogServer(ctx, logServer, logListener)
+		retErr = inspectOverride(ctx, cfg, sb, overrideName, recordDir)
+		return retErr
+	}
+
 	// Inform the user that traffic logs will be printed
 	fmt.Fprintln(out, "Printing traffic logs below (press Ctrl+C to stop):")
 	fmt.Fprintf(out, "\n")
@@ -144,6 +189,43 @@ func runOverride(rootCtx context.Context, out, errOut io.Writer,
 	return retErr
 }
 
+// inspectOverride runs the traffic watch TUI against the recorded override
+// traffic, until the user quits it. Readiness problems are reported in the
+// TUI logs view.
+func inspectOverride(ctx context.Context, cfg *config.LocalOverrideCreate,
+	sb *models.Sandbox, overrideName, recordDir string) error {
+	f, err := os.CreateTemp("", "signadot-override-*.log")
+	if err != nil {
+		return fmt.Errorf("error creating temp file: %w", err)
+	}
+	defer f.Close()
+
+	readiness := poll.NewPoll().Readiness(ctx, 5*time.Second, ckMatch(ctx, cfg, sb, overrideName))
+	defer readiness.Stop()
+	go readyLoop(ctx, readiness, f)
+
+	trafficWatch := tui.NewTrafficWatch(recordDir, config.OutputFormatJSON, f.Name())
+	return trafficWatch.Run()
+}
+
+func printOverridePolicy(out io.Writer, cfg *config.LocalOverrideCreate) {
+	bold := color.New(color.Bold).SprintFunc()
+	policy := &cfg.Policy
+
+	if len(policy.ExcludedStatusCodes) > 0 {
+		codes := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(policy.ExcludedStatusCodes)), ","), "[]")
+		fmt.Fprintf(out, "* If your local service (%s) responds with status code(s) %s:\n", bold(cfg.To), codes)
+		fmt.Fprintf(out, "    -> Request is forwarded to the sandbox (%s).\n", bold(cfg.Sandbox))
+		fmt.Fprintf(out, "* Otherwise:\n")
+		fmt.Fprintf(out, "    -> Response from your local service (%s) is returned to the client.\n", bold(cfg.To))
+	} else {
+		fmt.Fprintf(out, "* If your local service (%s) responds with header `sd-override: true`:\n", bold(cfg.To))
+		fmt.Fprintf(out, "    -> Response from your local service (%s) is returned to the client.\n", bold(cfg.To))
+		fmt.Fprintf(out, "* Otherwise:\n")
+		fmt.Fprintf(out, "    -> Request is forwarded to the sandbox (%s).\n", bold(cfg.Sandbox))
+	}
+}
+
 func computeServedByWidth(sandboxName, localAddress string) int {
 	width := len("SERVED BY")
 	if len(sandboxName) > width {
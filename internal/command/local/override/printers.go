@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/signadot/cli/internal/builder"
 	"github.com/signadot/cli/internal/sdtab"
@@ -15,15 +18,18 @@ type sandboxWithForward struct {
 }
 
 type overrideRow struct {
-	Name    string `sdtab:"NAME"`
-	Target  string `sdtab:"TARGET"`
-	ToLocal string `sdtab:"TO"`
-	Status  string `sdtab:"STATUS"`
+	Name      string `sdtab:"NAME"`
+	Target    string `sdtab:"TARGET"`
+	Workload  string `sdtab:"WORKLOAD"`
+	Port      string `sdtab:"PORT"`
+	ToLocal   string `sdtab:"TO"`
+	LogServer string `sdtab:"LOG SERVER"`
+	Status    string `sdtab:"STATUS"`
 }
 
 func isOverrideAttachedRunning(forward *builder.DetailedOverrideMiddleware) bool {
 	// Ping the log forward to see if it is running
-	conn, err := net.Dial("tcp", forward.LogForward.ToLocal)
+	conn, err := net.DialTimeout("tcp", forward.LogForward.ToLocal, time.Second)
 	if err != nil {
 		return false
 	}
@@ -39,24 +45,30 @@ func printOverrideTable(out io.Writer, sandboxes []*sandboxWithForward) error {
 	for _, override := range sandboxes {
 		for _, forward := range override.Forwards {
 
-			var status string
+			var status, logServer string
 
 			switch {
 			case forward.LogForward != nil:
 				if isOverrideAttachedRunning(forward) {
 					status = "attached"
+					logServer = fmt.Sprintf("%s (connected)", forward.LogForward.ToLocal)
 				} else {
 					status = "stopped"
+					logServer = fmt.Sprintf("%s (unreachable)", forward.LogForward.ToLocal)
 				}
 			default:
 				status = "detached"
+				logServer = "-"
 			}
 
 			t.AddRow(overrideRow{
-				Name:    forward.Forward.Name,
-				Target:  fmt.Sprintf("sandbox=%s", override.Sandbox),
-				ToLocal: forward.Forward.ToLocal,
-				Status:  status,
+				Name:      forward.Forward.Name,
+				Target:    fmt.Sprintf("sandbox=%s", override.Sandbox),
+				Workload:  strings.Join(forward.Workloads, ","),
+				Port:      strconv.FormatInt(forward.Forward.Port, 10),
+				ToLocal:   forward.Forward.ToLocal,
+				LogServer: logServer,
+				Status:    status,
 			})
 		}
 	}
@@ -0,0 +1,347 @@
+package override
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/trafficwatch/filemanager"
+	"github.com/signadot/cli/internal/utils/system"
+	"github.com/signadot/libconnect/common/trafficwatch/api"
+)
+
+// trafficRecorder sits between the sandbox and the local service of an
+// override. It records the requests sent to the local service, along with
+// its responses, in the on-disk format of 'traffic record', so that they can
+// be browsed with 'traffic inspect'.
+//
+// Each record is completed with the decision of the override middleware
+// (overridden or fallen through), which is computed from the local response
+// with the override policy, the same way the middleware does. Requests which
+// fall through are recorded with the local response that caused it, the
+// response of the sandbox never reaching the recorder.
+type trafficRecorder struct {
+	dir        string
+	routingKey string
+	workload   string
+	policy     builder.OverridePolicy
+
+	ln     net.Listener
+	server *http.Server
+	proxy  *httputil.ReverseProxy
+
+	mu       sync.Mutex
+	metaF    *os.File
+	metaEnc  *json.Encoder
+	errCount int
+	err      error
+}
+
+// overrideRecordDir returns the default directory where the traffic of the
+// overrides of a sandbox is recorded.
+func overrideRecordDir(sandbox string) (string, error) {
+	sdDir, err := system.GetSignadotDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(sdDir, "traffic", "override", sandbox), nil
+}
+
+func newTrafficRecorder(dir, to, routingKey, workload string, policy builder.OverridePolicy) (*trafficRecorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	metaF, err := os.OpenFile(filemanager.GetMetaStreamPath(dir, config.OutputFormatJSON),
+		os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		metaF.Close()
+		return nil, fmt.Errorf("error listening on available port: %w", err)
+	}
+
+	// gRPC requests need to reach the local service over (cleartext) HTTP/2
+	h2 := &http.Transport{Protocols: &http.Protocols{}}
+	h2.Protocols.SetUnencryptedHTTP2(true)
+	h1 := http.DefaultTransport.(*http.Transport).Clone()
+
+	r := &trafficRecorder{
+		dir:        dir,
+		routingKey: routingKey,
+		workload:   workload,
+		policy:     policy,
+		ln:         ln,
+		metaF:      metaF,
+		metaEnc:    json.NewEncoder(metaF),
+	}
+	r.proxy = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(&url.URL{Scheme: "http", Host: to})
+			pr.Out.Host = pr.In.Host
+		},
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.ProtoMajor == 2 {
+				return h2.RoundTrip(req)
+			}
+			return h1.RoundTrip(req)
+		}),
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			// abort the connection, so that the override middleware sees
+			// the local service as unreachable and applies its policy
+			panic(http.ErrAbortHandler)
+		},
+		FlushInterval: -1,
+	}
+	protocols := &http.Protocols{}
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	r.server = &http.Server{
+		Handler:   r,
+		Protocols: protocols,
+	}
+	return r, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Addr returns the address the sandbox should forward the override traffic
+// to.
+func (r *trafficRecorder) Addr() string {
+	return r.ln.Addr().String()
+}
+
+// Start serves the override traffic until ctx is done.
+func (r *trafficRecorder) Start(ctx context.Context) {
+	go r.server.Serve(r.ln)
+	go func() {
+		<-ctx.Done()
+		r.server.Shutdown(context.Background())
+		r.close()
+	}()
+}
+
+func (r *trafficRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	meta, err := r.startRequest(req)
+	if err != nil {
+		// don't break the override because of the recording
+		r.fail(err)
+		r.proxy.ServeHTTP(w, req)
+		return
+	}
+
+	reqBody := &bytes.Buffer{}
+	if req.Body != nil {
+		req.Body = &teeReadCloser{Reader: io.TeeReader(req.Body, reqBody), Closer: req.Body}
+	}
+	rw := &recordingWriter{ResponseWriter: w}
+	defer func() {
+		// this also runs when the proxy aborts the request, as the local
+		// service is unreachable
+		id := meta.MiddlewareRequestID
+		r.fail(writeRecordFile(filemanager.GetSourceRequestPath(r.dir, id),
+			wireRequest(req, reqBody.Bytes())))
+		meta.Override = &filemanager.OverrideDecision{StatusCode: rw.code}
+		if rw.code != 0 {
+			r.fail(writeRecordFile(filemanager.GetSourceResponsePath(r.dir, id),
+				wireResponse(req.ProtoMajor, req.ProtoMinor, rw.code, rw.Header(), rw.body.Bytes())))
+			meta.Override.Overridden = isOverridden(&r.policy, rw.code, rw.Header())
+		}
+		r.fail(r.doneRequest(meta))
+	}()
+	r.proxy.ServeHTTP(rw, req)
+}
+
+// isOverridden tells whether the override middleware returns a local
+// response to the client, as defined by the policy: when the status code is
+// not excluded, or without excluded codes, when the local service sets the
+// sd-override header.
+func isOverridden(policy *builder.OverridePolicy, code int, header http.Header) bool {
+	if len(policy.ExcludedStatusCodes) > 0 {
+		return !slices.Contains(policy.ExcludedStatusCodes, code)
+	}
+	return strings.EqualFold(header.Get("sd-override"), "true")
+}
+
+// startRequest records the start of a request.
+func (r *trafficRecorder) startRequest(req *http.Request) (*filemanager.RequestMetadata, error) {
+	id, err := newRequestID()
+	if err != nil {
+		return nil, err
+	}
+	protocol := filemanager.ProtocolHTTP
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc") {
+		protocol = filemanager.ProtocolGRPC
+	}
+	meta := &filemanager.RequestMetadata{
+		RequestMetadata: api.RequestMetadata{
+			MiddlewareRequestID: id,
+			When:                time.Now().Format(time.RFC3339Nano),
+			RoutingKey:          r.routingKey,
+			DestWorkload:        r.workload,
+			RequestURI:          requestURI(req),
+			Method:              req.Method,
+			UserAgent:           req.UserAgent(),
+		},
+		Protocol: protocol,
+	}
+	if err := os.MkdirAll(filepath.Join(r.dir, id), 0755); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.metaEnc.Encode(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (r *trafficRecorder) doneRequest(meta *filemanager.RequestMetadata) error {
+	meta.DoneAt = time.Now()
+	d, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	p := filemanager.GetSourceMetaPath(r.dir, meta.MiddlewareRequestID, config.OutputFormatJSON)
+	if err := writeRecordFile(p, d); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.metaEnc.Encode(meta)
+}
+
+func writeRecordFile(p string, d []byte) error {
+	return os.WriteFile(p, d, 0644)
+}
+
+// fail keeps track of the errors recording the traffic.
+func (r *trafficRecorder) fail(err error) {
+	if err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errCount++
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns an error if some of the traffic couldn't be recorded.
+func (r *trafficRecorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		return nil
+	}
+	return fmt.Errorf("%d error(s) recording the override traffic, first one: %w", r.errCount, r.err)
+}
+
+// close closes the meta file.
+func (r *trafficRecorder) close() {
+	r.mu.Lock()
+	err := r.metaF.Close()
+	r.mu.Unlock()
+	r.fail(err)
+}
+
+func newRequestID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func requestURI(req *http.Request) string {
+	return "http://" + req.Host + req.URL.RequestURI()
+}
+
+// wireRequest returns the request in wire format, with the body fully read.
+func wireRequest(req *http.Request, body []byte) []byte {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "%s %s %s\r\n", req.Method, req.URL.RequestURI(), req.Proto)
+	fmt.Fprintf(b, "Host: %s\r\n", req.Host)
+	writeWireBody(b, req.Header, body)
+	return b.Bytes()
+}
+
+// wireResponse returns the response in wire format, with the body fully
+// read.
+func wireResponse(protoMajor, protoMinor, status int, header http.Header, body []byte) []byte {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "HTTP/%d.%d %03d %s\r\n", protoMajor, protoMinor, status, http.StatusText(status))
+	writeWireBody(b, header, body)
+	return b.Bytes()
+}
+
+func writeWireBody(b *bytes.Buffer, header http.Header, body []byte) {
+	h := http.Header{}
+	for k, v := range header {
+		if strings.HasPrefix(k, http.TrailerPrefix) {
+			continue
+		}
+		h[k] = v
+	}
+	h.Del("Transfer-Encoding")
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	h.Write(b)
+	b.WriteString("\r\n")
+	b.Write(body)
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recordingWriter captures the response written to the sandbox.
+type recordingWriter struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(d []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	w.body.Write(d)
+	return w.ResponseWriter.Write(d)
+}
+
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,140 @@
+package override
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/trafficwatch/filemanager"
+)
+
+func TestIsOverridden(t *testing.T) {
+	excluded := &builder.OverridePolicy{ExcludedStatusCodes: []int{404, 503}}
+	header := func(v string) http.Header {
+		h := http.Header{}
+		if v != "" {
+			h.Set("sd-override", v)
+		}
+		return h
+	}
+	cases := []struct {
+		policy *builder.OverridePolicy
+		code   int
+		header http.Header
+		want   bool
+	}{
+		{policy: excluded, code: 200, header: header(""), want: true},
+		{policy: excluded, code: 404, header: header("true"), want: false},
+		{policy: excluded, code: 503, header: header(""), want: false},
+		{policy: &builder.OverridePolicy{}, code: 200, header: header(""), want: false},
+		{policy: &builder.OverridePolicy{}, code: 500, header: header("true"), want: true},
+		{policy: &builder.OverridePolicy{}, code: 200, header: header("TRUE"), want: true},
+		{policy: &builder.OverridePolicy{}, code: 200, header: header("false"), want: false},
+	}
+	for i, c := range cases {
+		if got := isOverridden(c.policy, c.code, c.header); got != c.want {
+			t.Errorf("case %d: got %v, want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestTrafficRecorder(t *testing.T) {
+	// the local service replies with the path of the request, and 404 for
+	// the paths under /missing
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		io.WriteString(w, req.URL.Path)
+	}))
+	defer local.Close()
+
+	dir := t.TempDir()
+	policy := builder.OverridePolicy{ExcludedStatusCodes: []int{404}}
+	r, err := newTrafficRecorder(dir, local.Listener.Addr().String(), "rk", "frontend", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Start(ctx)
+
+	// concurrent requests with the same method and path must not be mixed up
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		for _, prefix := range []string{"/found", "/missing"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				url := fmt.Sprintf("http://%s%s/%d", r.Addr(), prefix, i)
+				resp, err := http.Get(url)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}()
+		}
+	}
+	wg.Wait()
+	// wait for the requests to be completed
+	r.server.Shutdown(context.Background())
+	cancel()
+
+	d, err := os.ReadFile(filemanager.GetMetaStreamPath(dir, config.OutputFormatJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := 0
+	dec := json.NewDecoder(strings.NewReader(string(d)))
+	for dec.More() {
+		meta := &filemanager.RequestMetadata{}
+		if err := dec.Decode(meta); err != nil {
+			t.Fatal(err)
+		}
+		if meta.Override == nil {
+			// start of the request
+			continue
+		}
+		done++
+		id := meta.MiddlewareRequestID
+		req, err := filemanager.LoadHttpRequest(filemanager.GetSourceRequestPath(dir, id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := filemanager.LoadHttpResponse(filemanager.GetSourceResponsePath(dir, id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != req.URL.Path {
+			t.Errorf("request %s: recorded response %q for path %q", id, body, req.URL.Path)
+		}
+		missing := strings.HasPrefix(req.URL.Path, "/missing")
+		if meta.Override.Overridden == missing {
+			t.Errorf("request %s to %s: got overridden=%v", id, req.URL.Path, meta.Override.Overridden)
+		}
+		if _, err := os.Stat(filemanager.GetSourceMetaPath(dir, id, config.OutputFormatJSON)); err != nil {
+			t.Errorf("request %s: %v", id, err)
+		}
+	}
+	if done != 2*n {
+		t.Errorf("got %d completed requests, want %d", done, 2*n)
+	}
+	if err := r.Err(); err != nil {
+		t.Error(err)
+	}
+}
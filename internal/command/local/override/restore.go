@@ -0,0 +1,240 @@
+package override
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/devbox"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/signadot/go-sdk/client/sandboxes"
+	"github.com/signadot/go-sdk/models"
+	"github.com/signadot/libconnect/common/override"
+	"github.com/spf13/cobra"
+)
+
+func newRestore(cfg *config.LocalOverride) *cobra.Command {
+	restoreCfg := &config.LocalOverrideRestore{LocalOverride: cfg}
+
+	cmd := &cobra.Command{
+		Use:   "restore [--sandbox=<sandbox>] [--detach]",
+		Short: "Restore the traffic overrides created from this machine",
+		Long: `Restore the traffic overrides created from this machine, for instance after a reboot.
+
+Overrides are recorded locally when they are created. Recorded overrides that
+are no longer present in their sandbox are created again. Unless --detach is
+specified, the CLI then attaches to all of them and prints their traffic logs
+until interrupted, after which the overrides are left running detached.
+
+Example:
+  signadot local override restore
+  signadot local override restore --sandbox=my-sandbox --detach`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestore(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), restoreCfg)
+		},
+	}
+	restoreCfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func runRestore(rootCtx context.Context, out, errOut io.Writer, cfg *config.LocalOverrideRestore) error {
+	ctx, cancel := signal.NotifyContext(rootCtx,
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if err := cfg.InitLocalConfig(); err != nil {
+		return err
+	}
+
+	// Initialize API client
+	if err := cfg.API.InitAPIConfig(); err != nil {
+		return err
+	}
+
+	state, err := loadOverridesState()
+	if err != nil {
+		return err
+	}
+	var records []overrideRecord
+	for _, rec := range state.Overrides {
+		if cfg.Sandbox != "" && rec.Sandbox != cfg.Sandbox {
+			continue
+		}
+		records = append(records, rec)
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(out, "No overrides to restore.")
+		return nil
+	}
+
+	var attached []*overrideRecord
+	defer func() {
+		// leave the attached overrides running detached
+		for _, rec := range attached {
+			if err := detachRestored(rootCtx, cfg.API, rec); err != nil {
+				fmt.Fprintf(errOut, "WARNING: unable to detach override %s from sandbox %s: %v\n",
+					rec.Name, rec.Sandbox, err)
+				continue
+			}
+			printOverrideStatus(out, fmt.Sprintf("Override %s left running detached in sandbox %s", rec.Name, rec.Sandbox), true)
+		}
+	}()
+
+	for i := range records {
+		rec := &records[i]
+		restored, err := restoreOverride(ctx, out, cfg, rec)
+		if err != nil {
+			return err
+		}
+		if restored && !cfg.Detach {
+			attached = append(attached, rec)
+		}
+	}
+
+	if len(attached) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out, "\nPrinting traffic logs below (press Ctrl+C to stop):")
+	fmt.Fprintf(out, "\n")
+	printLogHeader(attached[0].Sandbox, attached[0].To)
+	<-ctx.Done()
+	return nil
+}
+
+// restoreOverride makes sure the recorded override is present in its sandbox,
+// creating it again if needed, and attaches it to a new log server unless
+// running detached. It returns false if the override could not be restored
+// because its sandbox no longer exists.
+func restoreOverride(ctx context.Context, out io.Writer, cfg *config.LocalOverrideRestore, rec *overrideRecord) (bool, error) {
+	sb, err := utils.GetSandbox(ctx, cfg.API, rec.Sandbox)
+	if err != nil {
+		if devbox.IsNotFound(err) {
+			printOverrideStatus(out, fmt.Sprintf("Sandbox %s no longer exists, forgetting override %s", rec.Sandbox, rec.Name), false)
+			return false, forgetOverride(rec.Sandbox, rec.Name)
+		}
+		return false, err
+	}
+
+	devboxID, err := sessionDevboxID(sb)
+	if err != nil {
+		return false, err
+	}
+
+	logPort := 0
+	if !cfg.Detach {
+		logServer, logListener, port := createLogServer(func(e *override.LogEntry) {
+			printFormattedLogEntry(e, rec.Sandbox, rec.To)
+		})
+		startLogServer(ctx, logServer, logListener)
+		logPort = port
+	}
+
+	printOverrideProgress(out, fmt.Sprintf("Restoring override %s in sandbox %s", rec.Name, rec.Sandbox))
+	if builder.HasOverrideMiddleware(sb, rec.Name) {
+		if err := reapplyOverride(ctx, cfg.API, sb, devboxID, rec, logPort); err != nil {
+			return false, err
+		}
+	} else {
+		createCfg := &config.LocalOverrideCreate{
+			LocalOverride: cfg.LocalOverride,
+			Sandbox:       rec.Sandbox,
+			Port:          rec.Port,
+			To:            rec.To,
+			Workload:      rec.Workload,
+			Detach:        cfg.Detach,
+			Policy:        rec.Policy,
+		}
+		_, name, _, err := applyOverrideToSandbox(ctx, createCfg, sb, rec.Workload, devboxID, logPort)
+		if err != nil {
+			return false, err
+		}
+		if name != rec.Name {
+			if err := forgetOverride(rec.Sandbox, rec.Name); err != nil {
+				return false, err
+			}
+			rec.Name = name
+		}
+	}
+	if rec.RecordDir != "" {
+		printOverrideStatus(out, fmt.Sprintf("Override %s no longer records its traffic to %s", rec.Name, rec.RecordDir), false)
+		rec.RecordDir = ""
+	}
+	rec.Detached = cfg.Detach
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = time.Now()
+	}
+	if err := recordOverride(*rec); err != nil {
+		return false, err
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	printOverrideStatus(out, fmt.Sprintf("Override %s restored: sandbox %s, workload %s, port %d -> %s",
+		bold(rec.Name), bold(rec.Sandbox), bold(rec.Workload), rec.Port, bold(rec.To)), true)
+	return true, nil
+}
+
+// reapplyOverride points the recorded override, which is still present in
+// the sandbox, to its local service and attaches it to the log server on
+// logPort (or detaches it if 0).
+func reapplyOverride(ctx context.Context, cfg *config.API, sandbox *models.Sandbox,
+	devboxID string, rec *overrideRecord, logPort int) error {
+	sb, err := restoredSandbox(sandbox, devboxID, rec, logPort)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(&sb, sandbox) {
+		return nil
+	}
+
+	params := sandboxes.NewApplySandboxParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithSandboxName(sandbox.Name).
+		WithData(&sb)
+
+	_, err = cfg.Client.Sandboxes.ApplySandbox(params, nil)
+	return err
+}
+
+// restoredSandbox returns the sandbox with the recorded override forwarding
+// to its local service, as an override whose traffic was recorded forwards
+// to the recorder of its CLI session, which is gone.
+func restoredSandbox(sandbox *models.Sandbox, devboxID string, rec *overrideRecord, logPort int) (models.Sandbox, error) {
+	sbBuilder := builder.
+		BuildSandbox(sandbox.Name, builder.WithData(*sandbox)).
+		SetDevboxID(devboxID).
+		SetOverrideTarget(rec.Name, rec.To)
+	if logPort > 0 {
+		sbBuilder.AttachOverrideLog(rec.Name, logPort)
+	} else {
+		sbBuilder.DetachOverrideLog(rec.Name)
+	}
+	return sbBuilder.Build()
+}
+
+func detachRestored(ctx context.Context, cfg *config.API, rec *overrideRecord) error {
+	sb, err := utils.GetSandbox(ctx, cfg, rec.Sandbox)
+	if err != nil {
+		return err
+	}
+	devboxID, err := sessionDevboxID(sb)
+	if err != nil {
+		return err
+	}
+	if err := setOverrideLog(ctx, cfg, sb, devboxID, rec.Name, 0); err != nil {
+		return err
+	}
+	rec.Detached = true
+	return recordOverride(*rec)
+}
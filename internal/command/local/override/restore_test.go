@@ -0,0 +1,62 @@
+package override
+
+import (
+	"testing"
+
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/go-sdk/models"
+)
+
+func TestRestoredSandboxRecordedOverride(t *testing.T) {
+	// an override created with --record-dir forwards to the recorder of its
+	// CLI session
+	b := builder.BuildSandbox("test", builder.WithData(models.Sandbox{
+		Name: "test",
+		Spec: &models.SandboxSpec{},
+	})).AddOverrideMiddleware(8080, "127.0.0.1:41234", []string{"frontend"})
+	name := *b.GetLastAddedOverrideName()
+	sb, err := b.AttachOverrideLog(name, 7000).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &overrideRecord{
+		Name:      name,
+		Sandbox:   "test",
+		Workload:  "frontend",
+		Port:      8080,
+		To:        "localhost:9999",
+		RecordDir: "/tmp/traffic",
+	}
+
+	res, err := restoredSandbox(&sb, "devbox-1", rec, 7001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forwards := map[string]string{}
+	for _, fw := range res.Spec.Routing.Forwards {
+		forwards[fw.Name] = fw.ToLocal
+	}
+	if got := forwards[name]; got != "localhost:9999" {
+		t.Errorf("override forwards to %q, want localhost:9999", got)
+	}
+	if len(forwards) != 2 {
+		t.Errorf("got forwards %v, want the override and its log", forwards)
+	}
+	if builder.GetAvailableOverrideMiddlewares(&res)[0].LogForward.ToLocal != "localhost:7001" {
+		t.Errorf("log not re-attached to the new log server")
+	}
+	// the sandbox given is left untouched
+	for _, fw := range sb.Spec.Routing.Forwards {
+		if fw.Name == name && fw.ToLocal != "127.0.0.1:41234" {
+			t.Errorf("input sandbox modified")
+		}
+	}
+
+	res, err = restoredSandbox(&sb, "devbox-1", rec, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(res.Spec.Routing.Forwards); n != 1 {
+		t.Errorf("got %d forwards when detached, want 1", n)
+	}
+}
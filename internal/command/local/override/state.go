@@ -0,0 +1,169 @@
+package override
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/utils/system"
+)
+
+const overridesStateFile = "overrides.json"
+
+// overrideRecord is the local record of an override created from this
+// machine, used to restore it (e.g. after a reboot)
+type overrideRecord struct {
+	Name      string                 `json:"name"`
+	Sandbox   string                 `json:"sandbox"`
+	Workload  string                 `json:"workload"`
+	Port      int64                  `json:"port"`
+	To        string                 `json:"to"`
+	Policy    builder.OverridePolicy `json:"policy"`
+	Detached  bool                   `json:"detached"`
+	CreatedAt time.Time              `json:"createdAt"`
+	// RecordDir is set when the override traffic was recorded, in which
+	// case the override was forwarded to the recorder of the CLI session
+	// instead of To
+	RecordDir string `json:"recordDir,omitempty"`
+}
+
+type overridesState struct {
+	Overrides []overrideRecord `json:"overrides"`
+}
+
+func overridesStatePath() (string, error) {
+	sdDir, err := system.GetSignadotDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(sdDir, overridesStateFile), nil
+}
+
+func loadOverridesState() (*overridesState, error) {
+	p, err := overridesStatePath()
+	if err != nil {
+		return nil, err
+	}
+	d, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return &overridesState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &overridesState{}
+	if err := json.Unmarshal(d, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *overridesState) save() error {
+	p, err := overridesStatePath()
+	if err != nil {
+		return err
+	}
+	if err := system.CreateDirIfNotExist(filepath.Dir(p)); err != nil {
+		return err
+	}
+	d, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write atomically, so that readers never see a partial state
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, d, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+func (s *overridesState) find(sandbox, name string) *overrideRecord {
+	for i := range s.Overrides {
+		rec := &s.Overrides[i]
+		if rec.Sandbox == sandbox && rec.Name == name {
+			return rec
+		}
+	}
+	return nil
+}
+
+func (s *overridesState) remove(sandbox, name string) {
+	res := s.Overrides[:0]
+	for _, rec := range s.Overrides {
+		if rec.Sandbox == sandbox && rec.Name == name {
+			continue
+		}
+		res = append(res, rec)
+	}
+	s.Overrides = res
+}
+
+// lockOverridesState takes an exclusive lock on the local state, as several
+// CLI sessions can update it concurrently. The returned function releases it.
+func lockOverridesState() (func(), error) {
+	p, err := overridesStatePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := system.CreateDirIfNotExist(filepath.Dir(p)); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(p+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error locking %s: %w", f.Name(), err)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// updateOverridesState applies fn to the local state and saves it, holding
+// the state lock. The state is not saved if fn returns false or an error.
+func updateOverridesState(fn func(*overridesState) (bool, error)) error {
+	unlock, err := lockOverridesState()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := loadOverridesState()
+	if err != nil {
+		return err
+	}
+	changed, err := fn(state)
+	if err != nil || !changed {
+		return err
+	}
+	return state.save()
+}
+
+// recordOverride adds (or replaces) the record of an override in the local
+// state
+func recordOverride(rec overrideRecord) error {
+	return updateOverridesState(func(state *overridesState) (bool, error) {
+		state.remove(rec.Sandbox, rec.Name)
+		state.Overrides = append(state.Overrides, rec)
+		return true, nil
+	})
+}
+
+// forgetOverride removes the record of an override from the local state
+func forgetOverride(sandbox, name string) error {
+	return updateOverridesState(func(state *overridesState) (bool, error) {
+		if state.find(sandbox, name) == nil {
+			return false, nil
+		}
+		state.remove(sandbox, name)
+		return true, nil
+	})
+}
@@ -0,0 +1,79 @@
+package override
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestOverridesStateConcurrentUpdates(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = recordOverride(overrideRecord{
+				Name:    fmt.Sprintf("override-%d", i),
+				Sandbox: "test",
+			})
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err := loadOverridesState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Overrides) != n {
+		t.Fatalf("got %d records, want %d: concurrent updates were lost", len(state.Overrides), n)
+	}
+}
+
+func TestRecordAndForgetOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := recordOverride(overrideRecord{Name: "override-0", Sandbox: "a", To: "localhost:1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordOverride(overrideRecord{Name: "override-0", Sandbox: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	// recording again replaces the record
+	if err := recordOverride(overrideRecord{Name: "override-0", Sandbox: "a", To: "localhost:2"}); err != nil {
+		t.Fatal(err)
+	}
+	state, err := loadOverridesState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Overrides) != 2 {
+		t.Fatalf("got %d records, want 2", len(state.Overrides))
+	}
+	if rec := state.find("a", "override-0"); rec == nil || rec.To != "localhost:2" {
+		t.Fatalf("got record %+v, want one to localhost:2", rec)
+	}
+
+	if err := forgetOverride("a", "override-0"); err != nil {
+		t.Fatal(err)
+	}
+	// forgetting an unknown override is a no-op
+	if err := forgetOverride("a", "override-0"); err != nil {
+		t.Fatal(err)
+	}
+	state, err = loadOverridesState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Overrides) != 1 || state.find("b", "override-0") == nil {
+		t.Fatalf("got records %+v, want only b/override-0", state.Overrides)
+	}
+}
@@ -38,6 +38,7 @@ func printRawStatus(cfg *config.LocalStatus, out io.Writer, printer func(out io.
 		SandboxesWatcher  any `json:"sandboxesWatcher,omitempty"`
 		Sandboxes         any `json:"sandboxes,omitempty"`
 		DevboxSession     any `json:"devboxSession,omitempty"`
+		LocalProxies      any `json:"localProxies,omitempty"`
 	}
 
 	rawSt := rawStatus{
@@ -50,6 +51,7 @@ func printRawStatus(cfg *config.LocalStatus, out io.Writer, printer func(out io.
 		SandboxesWatcher:  getRawWatcher(cfg, status.Watcher, statusMap),
 		Sandboxes:         statusMap["sandboxes"],
 		DevboxSession:     getRawDevboxSession(cfg, status.DevboxSession, statusMap),
+		LocalProxies:      getRawLocalProxies(cfg, status.LocalProxies, statusMap),
 	}
 
 	return printer(out, rawSt)
@@ -359,6 +361,46 @@ func getRawDevboxSession(cfg *config.LocalStatus, devboxSession *commonapi.Devbo
 	return result
 }
 
+func getRawLocalProxies(cfg *config.LocalStatus, localProxies []*sbmapi.LocalProxyStatus,
+	statusMap map[string]any) any {
+	if len(localProxies) == 0 {
+		return nil
+	}
+
+	if cfg.Details {
+		// Details view
+		return statusMap["localProxies"]
+	}
+
+	// Standard view
+	type PrintableLocalProxy struct {
+		Name            string `json:"name"`
+		Context         string `json:"context"`
+		Target          string `json:"target"`
+		BindAddress     string `json:"bindAddress"`
+		Healthy         bool   `json:"healthy"`
+		LastErrorReason string `json:"lastErrorReason,omitempty"`
+	}
+
+	result := make([]*PrintableLocalProxy, 0, len(localProxies))
+	for _, lp := range localProxies {
+		plp := &PrintableLocalProxy{
+			Name:        lp.Spec.GetName(),
+			Context:     localProxyContext(lp.Spec),
+			Target:      lp.Spec.GetTargetUrl(),
+			BindAddress: lp.Spec.GetBindAddr(),
+		}
+		if lp.Health != nil {
+			plp.Healthy = lp.Health.Healthy
+			if !lp.Health.Healthy {
+				plp.LastErrorReason = lp.Health.LastErrorReason
+			}
+		}
+		result = append(result, plp)
+	}
+	return result
+}
+
 func printLocalStatus(cfg *config.LocalStatus, out io.Writer, status *sbmapi.StatusResponse) error {
 	ciConfig, err := sbmapi.ToCIConfig(status.CiConfig)
 	if err != nil {
@@ -441,6 +483,7 @@ func (p *statusPrinter) printSuccess() {
 	}
 	p.printSandboxesWatcherStatus()
 	p.printSandboxStatus()
+	p.printLocalProxiesStatus()
 }
 
 func (p *statusPrinter) printOperatorInfo() {
@@ -552,6 +595,26 @@ func (p *statusPrinter) printSandboxStatus() {
 	}
 }
 
+func (p *statusPrinter) printLocalProxiesStatus() {
+	if len(p.status.LocalProxies) == 0 {
+		return
+	}
+	p.printLine(p.out, 0, "Local Proxies:", "*")
+	for _, lp := range p.status.LocalProxies {
+		p.printLine(p.out, 1, fmt.Sprintf("%s: %s -> %s (%s)", p.white(lp.Spec.GetName()),
+			lp.Spec.GetBindAddr(), lp.Spec.GetTargetUrl(), localProxyContext(lp.Spec)), "-")
+		if lp.Health != nil && lp.Health.Healthy {
+			p.printLine(p.out, 2, "proxy ready", p.green("✓"))
+		} else {
+			msg := "proxy not ready"
+			if lp.Health != nil && lp.Health.LastErrorReason != "" {
+				msg += fmt.Sprintf(" (%q)", lp.Health.LastErrorReason)
+			}
+			p.printLine(p.out, 2, msg, p.red("✗"))
+		}
+	}
+}
+
 func (p *statusPrinter) printLine(out io.Writer, idents int, line, prefix string) {
 	for i := 0; i < idents; i++ {
 		fmt.Fprintf(out, "    ")
@@ -0,0 +1,113 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/signadot/cli/internal/config"
+	sbmapi "github.com/signadot/cli/internal/locald/api/sandboxmanager"
+	sbmgr "github.com/signadot/cli/internal/locald/sandboxmanager"
+	"github.com/spf13/cobra"
+)
+
+func newProxyStop(proxyConfig *config.LocalProxy) *cobra.Command {
+	cfg := &config.LocalProxyStop{LocalProxy: proxyConfig}
+
+	cmd := &cobra.Command{
+		Use:   "stop NAME",
+		Short: "Stop a proxy running detached in the sandbox manager",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProxyStop(cmd.Context(), cmd.OutOrStdout(), cfg, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runProxyStop(ctx context.Context, out io.Writer, cfg *config.LocalProxyStop, name string) error {
+	if err := cfg.InitLocalConfig(); err != nil {
+		return err
+	}
+	err := sbmgr.RemoveProxy(ctx, name)
+	if errors.Is(err, sbmgr.ErrLocalProxyNotFound) {
+		return fmt.Errorf("no detached proxy named %q, see 'signadot local status'", name)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Stopped proxy %s\n", name)
+	return nil
+}
+
+// runProxyDetach registers the proxies with the sandbox manager, which keeps
+// running them until they are stopped with 'local proxy stop' or the machine
+// disconnects.
+func runProxyDetach(ctx context.Context, out io.Writer, cfg *config.LocalProxy) error {
+	var specs []*sbmapi.LocalProxySpec
+	if cfg.Filename != "" {
+		proxies, err := loadProxiesFile(cfg.Filename)
+		if err != nil {
+			return err
+		}
+		for _, ps := range proxies {
+			cluster, routingKey, err := resolveProxyContext(cfg.API, ps.Sandbox, ps.RouteGroup, ps.Cluster)
+			if err != nil {
+				return fmt.Errorf("proxy %q: %w", ps.Name, err)
+			}
+			specs = append(specs, &sbmapi.LocalProxySpec{
+				Name:       ps.Name,
+				Sandbox:    ps.Sandbox,
+				Routegroup: ps.RouteGroup,
+				Cluster:    cluster,
+				RoutingKey: routingKey,
+				TargetUrl:  ps.mapping.GetTarget(),
+				BindAddr:   ps.mapping.BindAddr,
+				Headers:    ps.Headers,
+			})
+		}
+	} else {
+		cluster, routingKey, err := resolveProxyContext(cfg.API, cfg.Sandbox, cfg.RouteGroup, cfg.Cluster)
+		if err != nil {
+			return err
+		}
+		for i := range cfg.ProxyMappings {
+			pm := &cfg.ProxyMappings[i]
+			specs = append(specs, &sbmapi.LocalProxySpec{
+				Name:       pm.BindAddr,
+				Sandbox:    cfg.Sandbox,
+				Routegroup: cfg.RouteGroup,
+				Cluster:    cluster,
+				RoutingKey: routingKey,
+				TargetUrl:  pm.GetTarget(),
+				BindAddr:   pm.BindAddr,
+			})
+		}
+	}
+	if len(specs) == 0 {
+		return errors.New("no proxies to run, specify at least one '--map'")
+	}
+
+	if err := sbmgr.RegisterProxies(ctx, specs); err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		fmt.Fprintf(out, "Proxy %s running detached: %s -> %s (%s)\n",
+			spec.Name, spec.BindAddr, spec.TargetUrl, localProxyContext(spec))
+	}
+	fmt.Fprintln(out, "\nUse 'signadot local status' to check them, and 'signadot local proxy stop NAME' to stop them.")
+	return nil
+}
+
+// localProxyContext returns the context of a proxy registered with the
+// sandbox manager, in the format of 'local proxy status'.
+func localProxyContext(spec *sbmapi.LocalProxySpec) string {
+	ps := &proxyStatus{
+		Sandbox:    spec.GetSandbox(),
+		RouteGroup: spec.GetRoutegroup(),
+		Cluster:    spec.GetCluster(),
+	}
+	return ps.context()
+}
@@ -0,0 +1,287 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/auth"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/libconnect/common/controlplaneproxy"
+	"sigs.k8s.io/yaml"
+)
+
+// proxyFileCheckInterval is how often the proxies file is checked for
+// changes
+const proxyFileCheckInterval = 2 * time.Second
+
+type proxiesFile struct {
+	Proxies []*proxySpec `json:"proxies"`
+}
+
+// proxySpec is a proxy declared in a 'local proxy -f' file
+type proxySpec struct {
+	Name       string            `json:"name,omitempty"`
+	Sandbox    string            `json:"sandbox,omitempty"`
+	RouteGroup string            `json:"routegroup,omitempty"`
+	Cluster    string            `json:"cluster,omitempty"`
+	Map        string            `json:"map"`
+	Headers    map[string]string `json:"headers,omitempty"`
+
+	mapping *config.ProxyMapping
+}
+
+func (ps *proxySpec) validate() error {
+	// a cluster can be combined with a (multi-cluster) routegroup
+	switch {
+	case ps.Sandbox == "" && ps.RouteGroup == "" && ps.Cluster == "":
+		return errors.New("one of sandbox, routegroup or cluster must be specified")
+	case ps.Sandbox != "" && (ps.RouteGroup != "" || ps.Cluster != ""):
+		return errors.New("only one of sandbox, routegroup or cluster can be specified")
+	}
+	pm, err := config.ParseProxyMapping(ps.Map)
+	if err != nil {
+		return err
+	}
+	if err := pm.Validate(); err != nil {
+		return err
+	}
+	if len(ps.Headers) > 0 && pm.TargetProto == "tcp" {
+		return errors.New("headers cannot be injected in tcp proxies")
+	}
+	ps.mapping = pm
+	if ps.Name == "" {
+		ps.Name = pm.BindAddr
+	}
+	return nil
+}
+
+// loadProxiesFile reads and validates the proxies declared in the given file.
+func loadProxiesFile(filename string) ([]*proxySpec, error) {
+	d, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	pf := &proxiesFile{}
+	if err := yaml.UnmarshalStrict(d, pf); err != nil {
+		return nil, fmt.Errorf("error unmarshalling proxies file %q: %w", filename, err)
+	}
+	names, binds := map[string]bool{}, map[string]bool{}
+	for i, ps := range pf.Proxies {
+		if err := ps.validate(); err != nil {
+			return nil, fmt.Errorf("proxy %d in %q: %w", i, filename, err)
+		}
+		if names[ps.Name] {
+			return nil, fmt.Errorf("duplicate proxy name %q in %q", ps.Name, filename)
+		}
+		names[ps.Name] = true
+		if binds[ps.mapping.BindAddr] {
+			return nil, fmt.Errorf("duplicate bind address %q in %q", ps.mapping.BindAddr, filename)
+		}
+		binds[ps.mapping.BindAddr] = true
+	}
+	return pf.Proxies, nil
+}
+
+// runningProxy is a proxy started from a proxies file
+type runningProxy struct {
+	spec   *proxySpec
+	status proxyStatus
+	proxy  *controlplaneproxy.Proxy
+	done   chan struct{}
+}
+
+// proxySet runs the proxies declared in a proxies file, reconciling them with
+// the file contents on reload.
+type proxySet struct {
+	log     *slog.Logger
+	cfg     *config.LocalProxy
+	state   *proxyProcessState
+	running map[string]*runningProxy
+}
+
+func runProxyFile(rootCtx context.Context, log *slog.Logger, cfg *config.LocalProxy) error {
+	ctx, cancel := signal.NotifyContext(rootCtx,
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	specs, err := loadProxiesFile(cfg.Filename)
+	if err != nil {
+		return err
+	}
+	modTime, err := fileModTime(cfg.Filename)
+	if err != nil {
+		return err
+	}
+
+	set := &proxySet{
+		log:     log,
+		cfg:     cfg,
+		state:   newProxyProcessState(cfg.Filename),
+		running: map[string]*runningProxy{},
+	}
+	defer set.stopAll()
+	set.apply(ctx, specs)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		set.state.keep(ctx, log)
+	}()
+	defer wg.Wait()
+
+	ticker := time.NewTicker(proxyFileCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Shutting down proxies")
+			return nil
+		case <-ticker.C:
+		}
+		mt, err := fileModTime(cfg.Filename)
+		if err != nil || mt.Equal(modTime) {
+			continue
+		}
+		modTime = mt
+		specs, err := loadProxiesFile(cfg.Filename)
+		if err != nil {
+			log.Error("unable to reload proxies file, keeping the current proxies", "error", err)
+			continue
+		}
+		log.Info("Reloading proxies", "file", cfg.Filename)
+		set.apply(ctx, specs)
+	}
+}
+
+func fileModTime(filename string) (time.Time, error) {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+// apply stops the running proxies which are no longer declared (or changed),
+// and starts the new ones.
+func (s *proxySet) apply(ctx context.Context, specs []*proxySpec) {
+	desired := make(map[string]*proxySpec, len(specs))
+	for _, ps := range specs {
+		desired[ps.Name] = ps
+	}
+	// stop first, as a changed proxy can keep its bind address
+	for name, rp := range s.running {
+		ps, ok := desired[name]
+		if ok && reflect.DeepEqual(ps, rp.spec) && rp.proxy != nil {
+			continue
+		}
+		s.stop(ctx, rp)
+		delete(s.running, name)
+	}
+	for _, ps := range specs {
+		if _, ok := s.running[ps.Name]; ok {
+			continue
+		}
+		s.running[ps.Name] = s.start(ctx, ps)
+	}
+	s.saveState()
+}
+
+func (s *proxySet) start(ctx context.Context, ps *proxySpec) *runningProxy {
+	rp := &runningProxy{
+		spec: ps,
+		status: proxyStatus{
+			Name:       ps.Name,
+			Sandbox:    ps.Sandbox,
+			RouteGroup: ps.RouteGroup,
+			Cluster:    ps.Cluster,
+			Target:     ps.mapping.GetTarget(),
+			BindAddr:   ps.mapping.BindAddr,
+			Headers:    ps.Headers,
+		},
+	}
+	fail := func(err error) *runningProxy {
+		s.log.Error("unable to start proxy", "name", ps.Name, "error", err)
+		rp.status.Status = proxyStatusError
+		rp.status.Error = err.Error()
+		return rp
+	}
+
+	cluster, routingKey, err := resolveProxyContext(s.cfg.API, ps.Sandbox, ps.RouteGroup, ps.Cluster)
+	if err != nil {
+		return fail(err)
+	}
+	rp.status.Cluster = cluster
+
+	headers := ps.Headers
+	ctlPlaneProxy, err := controlplaneproxy.NewProxy(&controlplaneproxy.Config{
+		Log:        s.log.With("proxy", ps.Name),
+		ProxyURL:   s.cfg.ProxyURL,
+		TargetURL:  ps.mapping.GetTarget(),
+		Cluster:    cluster,
+		RoutingKey: routingKey,
+		BindAddr:   ps.mapping.BindAddr,
+		GetInjectHeaders: func() (http.Header, error) {
+			h, err := auth.GetHeaders()
+			if err != nil {
+				return nil, err
+			}
+			for _, k := range slices.Sorted(maps.Keys(headers)) {
+				h.Set(k, headers[k])
+			}
+			return h, nil
+		},
+	})
+	if err != nil {
+		return fail(err)
+	}
+	rp.proxy = ctlPlaneProxy
+	rp.done = make(chan struct{})
+	rp.status.Status = proxyStatusRunning
+	go func() {
+		defer close(rp.done)
+		ctlPlaneProxy.Run(ctx)
+	}()
+	s.log.Info("Started proxy", "name", ps.Name, "target", rp.status.Target,
+		"bind", rp.status.BindAddr, "context", rp.status.context())
+	return rp
+}
+
+func (s *proxySet) stop(ctx context.Context, rp *runningProxy) {
+	if rp.proxy == nil {
+		return
+	}
+	rp.proxy.Close(ctx)
+	<-rp.done
+	s.log.Info("Stopped proxy", "name", rp.spec.Name)
+}
+
+func (s *proxySet) stopAll() {
+	ctx := context.Background()
+	for name, rp := range s.running {
+		s.stop(ctx, rp)
+		delete(s.running, name)
+	}
+}
+
+func (s *proxySet) saveState() {
+	names := slices.Sorted(maps.Keys(s.running))
+	proxies := make([]proxyStatus, 0, len(names))
+	for _, name := range names {
+		proxies = append(proxies, s.running[name].status)
+	}
+	if err := s.state.setProxies(proxies); err != nil {
+		s.log.Warn("unable to save proxy state", "error", err)
+	}
+}
@@ -0,0 +1,557 @@
+package local
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/auth"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/libconnect/common/controlplaneproxy"
+)
+
+const (
+	socks5Version = 0x05
+
+	// socks5 reply codes
+	socks5Succeeded           = 0x00
+	socks5HostUnreachable     = 0x04
+	socks5ConnectionRefused   = 0x05
+	socks5CommandNotSupported = 0x07
+	socks5AddressNotSupported = 0x08
+
+	// how long to wait for the client to speak first on a tunnelled
+	// connection, in order to detect its protocol
+	forwardSniffTimeout = 200 * time.Millisecond
+	// how long to wait for a control plane proxy to start listening
+	forwardProxyStartTimeout = 5 * time.Second
+)
+
+var httpMethodRegex = regexp.MustCompile(`^[A-Z]+ `)
+
+// forwardProxy is a local forward proxy, speaking both HTTP (including
+// CONNECT) and SOCKS5 on the same address. Connections to cluster hosts go
+// through a control plane proxy per target, so that the routing key gets
+// injected into HTTP and gRPC traffic. Other hosts are dialed directly.
+type forwardProxy struct {
+	log        *slog.Logger
+	cfg        *config.LocalProxy
+	cluster    string
+	routingKey string
+
+	// the context of the control plane proxies
+	ctx context.Context
+
+	mu      sync.Mutex
+	targets map[string]*forwardTarget
+
+	httpConns *connListener
+	transport *http.Transport
+}
+
+// forwardTarget is the control plane proxy to a cluster target
+type forwardTarget struct {
+	bindAddr string
+	proxy    *controlplaneproxy.Proxy
+	done     chan struct{}
+
+	// ready is closed once the proxy listens on bindAddr, or failed to
+	// start, in which case err is set
+	ready chan struct{}
+	err   error
+}
+
+func runForwardProxy(rootCtx context.Context, log *slog.Logger, cfg *config.LocalProxy,
+	cluster, routingKey string, state *proxyProcessState) error {
+	ctx, cancel := signal.NotifyContext(rootCtx,
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	ln, err := net.Listen("tcp", cfg.ForwardAddr)
+	if err != nil {
+		return err
+	}
+
+	fp := &forwardProxy{
+		log:        log,
+		cfg:        cfg,
+		cluster:    cluster,
+		routingKey: routingKey,
+		ctx:        ctx,
+		targets:    map[string]*forwardTarget{},
+		httpConns:  newConnListener(ln.Addr()),
+	}
+	fp.transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return fp.dial(ctx, "http", addr)
+		},
+		MaxIdleConnsPerHost: 8,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	defer fp.close()
+
+	server := &http.Server{Handler: fp}
+	go server.Serve(fp.httpConns)
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+		server.Close()
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		state.keep(ctx, log)
+	}()
+	defer wg.Wait()
+
+	log.Info("Forward proxy listening (HTTP and SOCKS5)", "address", ln.Addr().String(),
+		"cluster", cluster)
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go fp.handleConn(conn)
+	}
+}
+
+// isClusterHost returns true if host is a cluster service name, that is it
+// has a 'svc' label (e.g. my-svc.my-ns.svc or my-svc.my-ns.svc.cluster.local).
+func isClusterHost(host string) bool {
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "svc" {
+			return true
+		}
+	}
+	return false
+}
+
+func (fp *forwardProxy) handleConn(conn net.Conn) {
+	br := bufio.NewReader(conn)
+	b, err := br.Peek(1)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	if b[0] == socks5Version {
+		defer conn.Close()
+		if err := fp.handleSOCKS5(conn, br); err != nil {
+			fp.log.Debug("socks5 connection error", "error", err)
+		}
+		return
+	}
+	// let the HTTP server handle it
+	fp.httpConns.push(&bufferedConn{Conn: conn, r: br})
+}
+
+// ServeHTTP handles the HTTP proxy requests.
+func (fp *forwardProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodConnect {
+		fp.handleConnect(w, req)
+		return
+	}
+	if req.URL.Host == "" {
+		http.Error(w, "this is a proxy, requests must use an absolute URI", http.StatusBadRequest)
+		return
+	}
+	if req.URL.Scheme != "http" {
+		http.Error(w, fmt.Sprintf("unsupported scheme %q, use CONNECT", req.URL.Scheme), http.StatusBadRequest)
+		return
+	}
+
+	outReq := req.Clone(req.Context())
+	outReq.RequestURI = ""
+	for _, h := range []string{"Proxy-Connection", "Proxy-Authorization", "Connection", "Keep-Alive", "Upgrade", "Te", "Trailer", "Transfer-Encoding"} {
+		outReq.Header.Del(h)
+	}
+	resp, err := fp.transport.RoundTrip(outReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+func (fp *forwardProxy) handleConnect(w http.ResponseWriter, req *http.Request) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if _, err := io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		return
+	}
+	if err := fp.tunnel(&bufferedConn{Conn: conn, r: brw.Reader}, req.Host); err != nil {
+		fp.log.Debug("tunnel error", "address", req.Host, "error", err)
+	}
+}
+
+// handleSOCKS5 serves a SOCKS5 connection, supporting only the CONNECT command
+// without authentication.
+func (fp *forwardProxy) handleSOCKS5(conn net.Conn, br *bufio.Reader) error {
+	// greeting
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(br, hdr); err != nil {
+		return err
+	}
+	methods := make([]byte, hdr[1])
+	if _, err := io.ReadFull(br, methods); err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte{socks5Version, 0x00}); err != nil {
+		return err
+	}
+
+	// request
+	addr, reply, err := readSOCKS5Request(br)
+	if err != nil {
+		if reply != socks5Succeeded {
+			writeSOCKS5Reply(conn, reply)
+		}
+		return err
+	}
+	client := &bufferedConn{Conn: conn, r: br}
+
+	host, _, _ := net.SplitHostPort(addr)
+	if isClusterHost(host) {
+		// the protocol spoken to a cluster host, which tells how to inject
+		// the routing key, is only known once the client speaks, that is
+		// after the reply
+		if err := writeSOCKS5Reply(conn, socks5Succeeded); err != nil {
+			return err
+		}
+		return fp.tunnel(client, addr)
+	}
+	upstream, err := (&net.Dialer{}).DialContext(fp.ctx, "tcp", addr)
+	if err != nil {
+		writeSOCKS5Reply(conn, socks5DialReply(err))
+		return err
+	}
+	if err := writeSOCKS5Reply(conn, socks5Succeeded); err != nil {
+		upstream.Close()
+		return err
+	}
+	return pipe(client, upstream)
+}
+
+// readSOCKS5Request reads a SOCKS5 request, returning the address to connect
+// to, or the reply code to send back on an unsupported request.
+func readSOCKS5Request(br *bufio.Reader) (string, byte, error) {
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(br, req); err != nil {
+		return "", socks5Succeeded, err
+	}
+	if req[0] != socks5Version {
+		return "", socks5Succeeded, fmt.Errorf("unsupported socks version %d", req[0])
+	}
+	if req[1] != 0x01 {
+		return "", socks5CommandNotSupported, fmt.Errorf("unsupported socks5 command %d", req[1])
+	}
+	var host string
+	switch req[3] {
+	case 0x01, 0x04:
+		ip := make(net.IP, 4)
+		if req[3] == 0x04 {
+			ip = make(net.IP, 16)
+		}
+		if _, err := io.ReadFull(br, ip); err != nil {
+			return "", socks5Succeeded, err
+		}
+		host = ip.String()
+	case 0x03:
+		l, err := br.ReadByte()
+		if err != nil {
+			return "", socks5Succeeded, err
+		}
+		name := make([]byte, l)
+		if _, err := io.ReadFull(br, name); err != nil {
+			return "", socks5Succeeded, err
+		}
+		host = string(name)
+	default:
+		return "", socks5AddressNotSupported, fmt.Errorf("unsupported socks5 address type %d", req[3])
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(br, port); err != nil {
+		return "", socks5Succeeded, err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), socks5Succeeded, nil
+}
+
+func writeSOCKS5Reply(conn net.Conn, reply byte) error {
+	_, err := conn.Write([]byte{socks5Version, reply, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+// socks5DialReply returns the reply code for an error dialing the target.
+func socks5DialReply(err error) byte {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return socks5ConnectionRefused
+	}
+	return socks5HostUnreachable
+}
+
+// tunnel connects the client to addr, detecting the protocol spoken by the
+// client to get the routing key injected when possible.
+func (fp *forwardProxy) tunnel(client *bufferedConn, addr string) error {
+	proto := sniffProtocol(client)
+	upstream, err := fp.dial(fp.ctx, proto, addr)
+	if err != nil {
+		return err
+	}
+	return pipe(client, upstream)
+}
+
+// pipe copies data both ways between the client and upstream, until either
+// side is done, and closes upstream.
+func pipe(client *bufferedConn, upstream net.Conn) error {
+	defer upstream.Close()
+
+	errC := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(upstream, client)
+		if cw, ok := upstream.(interface{ CloseWrite() error }); ok {
+			cw.CloseWrite()
+		}
+		errC <- err
+	}()
+	go func() {
+		_, err := io.Copy(client, upstream)
+		errC <- err
+	}()
+	return <-errC
+}
+
+// sniffProtocol returns the protocol to use for a tunnelled connection: grpc
+// for HTTP/2, http for HTTP/1 and tcp for anything else (e.g. TLS, or
+// protocols where the server speaks first).
+func sniffProtocol(c *bufferedConn) string {
+	c.SetReadDeadline(time.Now().Add(forwardSniffTimeout))
+	defer c.SetReadDeadline(time.Time{})
+	if _, err := c.r.Peek(1); err != nil {
+		return "tcp"
+	}
+	b, _ := c.r.Peek(c.r.Buffered())
+	switch {
+	case strings.HasPrefix(string(b), "PRI * HTTP/2"):
+		return "grpc"
+	case httpMethodRegex.Match(b):
+		return "http"
+	default:
+		return "tcp"
+	}
+}
+
+// dial connects to addr, through a control plane proxy for cluster hosts.
+func (fp *forwardProxy) dial(ctx context.Context, proto, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	d := &net.Dialer{}
+	if !isClusterHost(host) {
+		return d.DialContext(ctx, "tcp", addr)
+	}
+	bindAddr, err := fp.target(proto + "://" + addr)
+	if err != nil {
+		return nil, err
+	}
+	return d.DialContext(ctx, "tcp", bindAddr)
+}
+
+// target returns the bind address of the control plane proxy to the given
+// target, starting it if needed.
+func (fp *forwardProxy) target(targetURL string) (string, error) {
+	fp.mu.Lock()
+	t, ok := fp.targets[targetURL]
+	if !ok {
+		var err error
+		t, err = fp.startTarget(targetURL)
+		if err != nil {
+			fp.mu.Unlock()
+			return "", err
+		}
+		fp.targets[targetURL] = t
+	}
+	fp.mu.Unlock()
+
+	// wait for the proxy without holding the lock, so that a slow target
+	// doesn't hold up the connections to the other ones
+	<-t.ready
+	if t.err != nil {
+		return "", t.err
+	}
+	return t.bindAddr, nil
+}
+
+// startTarget starts the control plane proxy to the given target. Its ready
+// channel is closed once it listens, or failed to start, in which case it is
+// removed from the targets. It must be called with fp.mu held.
+func (fp *forwardProxy) startTarget(targetURL string) (*forwardTarget, error) {
+	bindAddr, err := freeLocalAddr()
+	if err != nil {
+		return nil, err
+	}
+	ctlPlaneProxy, err := controlplaneproxy.NewProxy(&controlplaneproxy.Config{
+		Log:              fp.log.With("target", targetURL),
+		ProxyURL:         fp.cfg.ProxyURL,
+		TargetURL:        targetURL,
+		Cluster:          fp.cluster,
+		RoutingKey:       fp.routingKey,
+		BindAddr:         bindAddr,
+		GetInjectHeaders: auth.GetHeaders,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t := &forwardTarget{
+		bindAddr: bindAddr,
+		proxy:    ctlPlaneProxy,
+		done:     make(chan struct{}),
+		ready:    make(chan struct{}),
+	}
+	go func() {
+		defer close(t.done)
+		ctlPlaneProxy.Run(fp.ctx)
+	}()
+	go func() {
+		defer close(t.ready)
+		if err := waitListening(bindAddr, t.done); err != nil {
+			t.err = fmt.Errorf("proxy to %s: %w", targetURL, err)
+			fp.mu.Lock()
+			if fp.targets[targetURL] == t {
+				delete(fp.targets, targetURL)
+			}
+			fp.mu.Unlock()
+			ctlPlaneProxy.Close(context.Background())
+			return
+		}
+		fp.log.Debug("Started control plane proxy", "target", targetURL, "bind", bindAddr)
+	}()
+	return t, nil
+}
+
+func (fp *forwardProxy) close() {
+	fp.transport.CloseIdleConnections()
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	for _, t := range fp.targets {
+		t.proxy.Close(context.Background())
+		<-t.done
+	}
+	fp.targets = map[string]*forwardTarget{}
+}
+
+// freeLocalAddr returns a loopback address with a port which is currently
+// available.
+func freeLocalAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
+
+func waitListening(addr string, done <-chan struct{}) error {
+	deadline := time.Now().Add(forwardProxyStartTimeout)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		select {
+		case <-done:
+			return errors.New("proxy exited")
+		default:
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not listening after %v", forwardProxyStartTimeout)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// bufferedConn is a connection whose first bytes were read in r.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// connListener is a net.Listener accepting the connections pushed to it.
+type connListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newConnListener(addr net.Addr) *connListener {
+	return &connListener{
+		addr:   addr,
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (l *connListener) push(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.closed:
+		conn.Close()
+	}
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *connListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.addr
+}
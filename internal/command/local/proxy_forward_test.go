@@ -0,0 +1,195 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestIsClusterHost(t *testing.T) {
+	cases := map[string]bool{
+		"my-svc.my-ns.svc":                true,
+		"my-svc.my-ns.svc.cluster.local":  true,
+		"my-svc.my-ns.svc.cluster.local.": true,
+		"my-svc":                          false,
+		"my-svc.my-ns":                    false,
+		"service.example.com":             false,
+		"127.0.0.1":                       false,
+		"":                                false,
+	}
+	for host, want := range cases {
+		if got := isClusterHost(host); got != want {
+			t.Errorf("isClusterHost(%q): got %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestReadSOCKS5Request(t *testing.T) {
+	cases := []struct {
+		name      string
+		req       []byte
+		wantAddr  string
+		wantReply byte
+		wantErr   bool
+	}{
+		{
+			name:     "ipv4",
+			req:      []byte{0x05, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x1f, 0x90},
+			wantAddr: "10.0.0.1:8080",
+		},
+		{
+			name: "ipv6",
+			req: append([]byte{0x05, 0x01, 0x00, 0x04},
+				append(net.ParseIP("::1").To16(), 0x00, 0x50)...),
+			wantAddr: "[::1]:80",
+		},
+		{
+			name: "domain",
+			req: append(append([]byte{0x05, 0x01, 0x00, 0x03, 16}, "my-svc.my-ns.svc"...),
+				0x01, 0xbb),
+			wantAddr: "my-svc.my-ns.svc:443",
+		},
+		{
+			name:      "bind command",
+			req:       []byte{0x05, 0x02, 0x00, 0x01, 10, 0, 0, 1, 0x1f, 0x90},
+			wantReply: socks5CommandNotSupported,
+			wantErr:   true,
+		},
+		{
+			name:      "unknown address type",
+			req:       []byte{0x05, 0x01, 0x00, 0x02, 10, 0, 0, 1, 0x1f, 0x90},
+			wantReply: socks5AddressNotSupported,
+			wantErr:   true,
+		},
+		{
+			name:    "socks4",
+			req:     []byte{0x04, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x1f, 0x90},
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			req:     []byte{0x05, 0x01, 0x00, 0x01, 10, 0},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			addr, reply, err := readSOCKS5Request(bufio.NewReader(bytes.NewReader(c.req)))
+			if (err != nil) != c.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, c.wantErr)
+			}
+			if addr != c.wantAddr {
+				t.Errorf("got address %q, want %q", addr, c.wantAddr)
+			}
+			if reply != c.wantReply {
+				t.Errorf("got reply %#x, want %#x", reply, c.wantReply)
+			}
+		})
+	}
+}
+
+func TestSniffProtocol(t *testing.T) {
+	cases := map[string]string{
+		"PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n": "grpc",
+		"GET / HTTP/1.1\r\n":               "http",
+		"POST /api HTTP/1.1\r\n":           "http",
+		"\x16\x03\x01\x02\x00":             "tcp",
+		"":                                 "tcp",
+	}
+	for data, want := range cases {
+		client, server := net.Pipe()
+		if data != "" {
+			go client.Write([]byte(data))
+		}
+		got := sniffProtocol(&bufferedConn{Conn: server, r: bufio.NewReader(server)})
+		client.Close()
+		server.Close()
+		if got != want {
+			t.Errorf("sniffProtocol(%q): got %s, want %s", data, got, want)
+		}
+	}
+}
+
+func TestHandleSOCKS5(t *testing.T) {
+	// an echo server
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	// a port nobody listens on
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	fp := &forwardProxy{
+		log: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ctx: context.Background(),
+	}
+	connect := func(port int) (net.Conn, []byte) {
+		client, server := net.Pipe()
+		go func() {
+			defer server.Close()
+			fp.handleSOCKS5(server, bufio.NewReader(server))
+		}()
+		client.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, err := client.Write([]byte{0x05, 0x01, 0x00}); err != nil {
+			t.Fatal(err)
+		}
+		greeting := make([]byte, 2)
+		if _, err := io.ReadFull(client, greeting); err != nil {
+			t.Fatal(err)
+		}
+		req := []byte{0x05, 0x01, 0x00, 0x01, 127, 0, 0, 1, byte(port >> 8), byte(port)}
+		if _, err := client.Write(req); err != nil {
+			t.Fatal(err)
+		}
+		reply := make([]byte, 10)
+		if _, err := io.ReadFull(client, reply); err != nil {
+			t.Fatal(err)
+		}
+		return client, reply
+	}
+
+	client, reply := connect(ln.Addr().(*net.TCPAddr).Port)
+	if reply[1] != socks5Succeeded {
+		t.Fatalf("got reply %#x, want success", reply[1])
+	}
+	if _, err := client.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	pong := make([]byte, 4)
+	if _, err := io.ReadFull(client, pong); err != nil {
+		t.Fatal(err)
+	}
+	if string(pong) != "ping" {
+		t.Errorf("got %q through the tunnel, want ping", pong)
+	}
+	client.Close()
+
+	client, reply = connect(closedPort)
+	defer client.Close()
+	if reply[1] != socks5ConnectionRefused {
+		t.Errorf("got reply %#x for a refused connection, want %#x", reply[1], socks5ConnectionRefused)
+	}
+}
@@ -0,0 +1,289 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/signadot/cli/internal/config"
+	sbmgr "github.com/signadot/cli/internal/locald/sandboxmanager"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/signadot/cli/internal/utils/system"
+	"github.com/spf13/cobra"
+)
+
+const (
+	proxyStatusRunning = "running"
+	proxyStatusError   = "error"
+
+	// proxy processes refresh their state file every proxyStateRefresh, a
+	// state file not refreshed for proxyStateExpiry belongs to a dead process
+	proxyStateRefresh = 5 * time.Second
+	proxyStateExpiry  = 3 * proxyStateRefresh
+)
+
+func newProxyStatus(proxyConfig *config.LocalProxy) *cobra.Command {
+	cfg := &config.LocalProxyStatus{LocalProxy: proxyConfig}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the running local proxies, including the detached ones",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProxyStatus(cmd.OutOrStdout(), cfg)
+		},
+	}
+
+	return cmd
+}
+
+// proxyStatus is the status of a proxy run by a 'local proxy' process
+type proxyStatus struct {
+	Name       string            `json:"name"`
+	Sandbox    string            `json:"sandbox,omitempty"`
+	RouteGroup string            `json:"routegroup,omitempty"`
+	Cluster    string            `json:"cluster"`
+	Target     string            `json:"target"`
+	BindAddr   string            `json:"bindAddr"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// proxyProcessState is the state of a 'local proxy' process, stored in the
+// proxy state dir while it runs
+type proxyProcessState struct {
+	PID       int           `json:"pid,omitempty"`
+	Detached  bool          `json:"detached,omitempty"`
+	Filename  string        `json:"filename,omitempty"`
+	StartedAt time.Time     `json:"startedAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Proxies   []proxyStatus `json:"proxies"`
+
+	mu sync.Mutex
+}
+
+func newProxyProcessState(filename string) *proxyProcessState {
+	return &proxyProcessState{
+		PID:       os.Getpid(),
+		Filename:  filename,
+		StartedAt: time.Now(),
+	}
+}
+
+func proxyStateDir() (string, error) {
+	sdDir, err := system.GetSignadotDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(sdDir, "proxy"), nil
+}
+
+// setProxies replaces the proxies of the state and saves it.
+func (s *proxyProcessState) setProxies(proxies []proxyStatus) error {
+	s.mu.Lock()
+	s.Proxies = proxies
+	s.mu.Unlock()
+	return s.save()
+}
+
+func (s *proxyProcessState) save() error {
+	s.mu.Lock()
+	s.UpdatedAt = time.Now()
+	d, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	dir, err := proxyStateDir()
+	if err != nil {
+		return err
+	}
+	if err := system.CreateDirIfNotExist(dir); err != nil {
+		return err
+	}
+	p := filepath.Join(dir, strconv.Itoa(s.PID)+".json")
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, d, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+func (s *proxyProcessState) remove() error {
+	dir, err := proxyStateDir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, strconv.Itoa(s.PID)+".json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// keep refreshes the state file until ctx is done, and then removes it.
+func (s *proxyProcessState) keep(ctx context.Context, log *slog.Logger) {
+	defer s.remove()
+	ticker := time.NewTicker(proxyStateRefresh)
+	defer ticker.Stop()
+	for {
+		if err := s.save(); err != nil {
+			log.Warn("unable to save proxy state", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadProxyProcessStates returns the state of the running 'local proxy'
+// processes, cleaning up the state left by dead ones.
+func loadProxyProcessStates() ([]*proxyProcessState, error) {
+	dir, err := proxyStateDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res []*proxyProcessState
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		p := filepath.Join(dir, entry.Name())
+		d, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		state := &proxyProcessState{}
+		if err := json.Unmarshal(d, state); err != nil {
+			continue
+		}
+		if time.Since(state.UpdatedAt) > proxyStateExpiry {
+			os.Remove(p)
+			continue
+		}
+		res = append(res, state)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].StartedAt.Before(res[j].StartedAt)
+	})
+	return res, nil
+}
+
+// loadDetachedProxiesState returns the proxies running detached in the
+// sandbox manager, if any.
+func loadDetachedProxiesState() *proxyProcessState {
+	status, err := sbmgr.GetStatus()
+	if err != nil || len(status.LocalProxies) == 0 {
+		return nil
+	}
+	state := &proxyProcessState{Detached: true}
+	for _, lp := range status.LocalProxies {
+		ps := proxyStatus{
+			Name:       lp.Spec.GetName(),
+			Sandbox:    lp.Spec.GetSandbox(),
+			RouteGroup: lp.Spec.GetRoutegroup(),
+			Cluster:    lp.Spec.GetCluster(),
+			Target:     lp.Spec.GetTargetUrl(),
+			BindAddr:   lp.Spec.GetBindAddr(),
+			Headers:    lp.Spec.GetHeaders(),
+			Status:     proxyStatusRunning,
+		}
+		if lp.Health != nil && !lp.Health.Healthy && lp.Health.LastErrorReason != "" {
+			ps.Status = proxyStatusError
+			ps.Error = lp.Health.LastErrorReason
+		}
+		state.Proxies = append(state.Proxies, ps)
+	}
+	return state
+}
+
+func runProxyStatus(out io.Writer, cfg *config.LocalProxyStatus) error {
+	states, err := loadProxyProcessStates()
+	if err != nil {
+		return err
+	}
+	if state := loadDetachedProxiesState(); state != nil {
+		states = append(states, state)
+	}
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		return printProxyStatusTable(out, states)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, states)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, states)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+type proxyStatusRow struct {
+	Name     string `sdtab:"NAME"`
+	Context  string `sdtab:"CONTEXT"`
+	Target   string `sdtab:"TARGET"`
+	BindAddr string `sdtab:"BIND"`
+	PID      string `sdtab:"PID"`
+	Status   string `sdtab:"STATUS"`
+}
+
+func printProxyStatusTable(out io.Writer, states []*proxyProcessState) error {
+	if len(states) == 0 {
+		fmt.Fprintln(out, "No local proxies running.")
+		return nil
+	}
+	t := sdtab.New[proxyStatusRow](out)
+	t.AddHeader()
+	for _, state := range states {
+		pid := strconv.Itoa(state.PID)
+		if state.Detached {
+			pid = "detached"
+		}
+		for i := range state.Proxies {
+			ps := &state.Proxies[i]
+			status := ps.Status
+			if ps.Error != "" {
+				status = fmt.Sprintf("%s: %s", ps.Status, ps.Error)
+			}
+			t.AddRow(proxyStatusRow{
+				Name:     ps.Name,
+				Context:  ps.context(),
+				Target:   ps.Target,
+				BindAddr: ps.BindAddr,
+				PID:      pid,
+				Status:   status,
+			})
+		}
+	}
+	return t.Flush()
+}
+
+func (ps *proxyStatus) context() string {
+	switch {
+	case ps.Sandbox != "":
+		return "sandbox=" + ps.Sandbox
+	case ps.RouteGroup != "":
+		return "routegroup=" + ps.RouteGroup
+	default:
+		return "cluster=" + ps.Cluster
+	}
+}
@@ -0,0 +1,235 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/command/sandbox"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/local"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// grace period given to the process to exit before it gets killed
+	runStopTimeout = 10 * time.Second
+
+	// env var pointing the process to the materialized sandbox files
+	runFilesDirEnv = "SIGNADOT_SANDBOX_FILES_DIR"
+)
+
+func newRun(localConfig *config.Local) *cobra.Command {
+	cfg := &config.LocalRun{Local: localConfig}
+
+	cmd := &cobra.Command{
+		Use:   "run --sandbox SANDBOX [--local LOCAL] -- COMMAND [ARG...]",
+		Short: "Run a process with the environment and files of a sandbox local workload",
+		Long: `Run a process with the environment and files of a local workload of a sandbox.
+
+The environment is resolved as in 'sandbox get-env' and the files are
+materialized as in 'sandbox get-files', in a temporary directory unless
+--files-dir is given. Environment variables referring to a materialized file
+are rewritten to point into that directory.
+
+Unless --no-watch is given, the sandbox spec is watched and the process is
+restarted with the updated environment whenever it changes.`,
+		Example: `  # Run a local workload of my-sandbox
+  signadot local run --sandbox my-sandbox --local route -- go run ./cmd/route`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exitCode, err := runLocal(cmd.Context(), cmd.ErrOrStderr(), cfg, args)
+			if err != nil {
+				return err
+			}
+			if exitCode != 0 {
+				os.Exit(exitCode)
+			}
+			return nil
+		},
+	}
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func runLocal(rootCtx context.Context, errOut io.Writer, cfg *config.LocalRun, args []string) (int, error) {
+	ctx, cancel := signal.NotifyContext(rootCtx,
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if err := cfg.InitAPIConfig(); err != nil {
+		return 0, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return 0, err
+	}
+
+	sb, err := utils.GetSandbox(ctx, cfg.API, cfg.Sandbox)
+	if err != nil {
+		return 0, err
+	}
+	kubeClient, err := local.GetLocalKubeClient()
+	if err != nil {
+		return 0, err
+	}
+
+	filesDir := cfg.FilesDir
+	cleanFilesDir := filesDir == ""
+	if cleanFilesDir {
+		filesDir, err = os.MkdirTemp("", "signadot-run-"+cfg.Sandbox+"-")
+		if err != nil {
+			return 0, err
+		}
+		defer os.RemoveAll(filesDir)
+	}
+
+	var specChanges <-chan *models.Sandbox
+	if !cfg.NoWatch {
+		specChanges = watchSandboxSpec(ctx, errOut, cfg, sb)
+	}
+
+	for {
+		env, err := prepareRunEnv(ctx, errOut, cfg, kubeClient, sb, filesDir, cleanFilesDir)
+		if err != nil {
+			return 0, err
+		}
+		proc := exec.Command(args[0], args[1:]...)
+		proc.Env = append(os.Environ(), env...)
+		proc.Stdin = os.Stdin
+		proc.Stdout = os.Stdout
+		proc.Stderr = os.Stderr
+		if err := proc.Start(); err != nil {
+			return 0, err
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- proc.Wait()
+		}()
+
+		select {
+		case err := <-done:
+			return exitCode(err)
+		case <-ctx.Done():
+			return exitCode(stopProcess(proc, done))
+		case sb = <-specChanges:
+			fmt.Fprintf(errOut, "Sandbox %s spec changed, restarting %s\n", cfg.Sandbox, args[0])
+			stopProcess(proc, done)
+		}
+	}
+}
+
+// prepareRunEnv resolves the sandbox local environment, exports its files
+// into filesDir and returns the environment in the form expected by exec.Cmd.
+func prepareRunEnv(ctx context.Context, errOut io.Writer, cfg *config.LocalRun,
+	kubeClient client.Client, sb *models.Sandbox, filesDir string, clean bool) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	localEnv, err := sandbox.ResolveLocalEnv(ctx, kubeClient, sb, cfg.LocalWorkload, cfg.Container)
+	if err != nil {
+		return nil, err
+	}
+	if err := sandbox.PrintLocalEnvWarnings(errOut, localEnv); err != nil {
+		return nil, err
+	}
+
+	if clean {
+		if err := os.RemoveAll(filesDir); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(filesDir, 0755); err != nil {
+		return nil, err
+	}
+	if localEnv.Files != nil {
+		if err := localEnv.Files.ExportTo(filesDir); err != nil {
+			return nil, err
+		}
+	}
+
+	items := sandbox.RewriteFilePaths(localEnv.Env, localEnv.Files, filesDir)
+	res := make([]string, 0, len(items)+1)
+	for _, item := range items {
+		res = append(res, item.Name+"="+item.Value)
+	}
+	res = append(res, runFilesDirEnv+"="+filesDir)
+	return res, nil
+}
+
+// watchSandboxSpec polls the sandbox and sends it on the returned channel
+// every time its spec changes.
+func watchSandboxSpec(ctx context.Context, errOut io.Writer, cfg *config.LocalRun, sb *models.Sandbox) <-chan *models.Sandbox {
+	res := make(chan *models.Sandbox)
+	go func() {
+		last, _ := json.Marshal(sb.Spec)
+		ticker := time.NewTicker(cfg.WatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			cur, err := utils.GetSandbox(ctx, cfg.API, cfg.Sandbox)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Fprintf(errOut, "WARNING: unable to get sandbox %s: %v\n", cfg.Sandbox, err)
+				}
+				continue
+			}
+			spec, err := json.Marshal(cur.Spec)
+			if err != nil || bytes.Equal(spec, last) {
+				continue
+			}
+			last = spec
+			select {
+			case res <- cur:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res
+}
+
+// stopProcess asks the process to terminate, killing it if it does not exit
+// within runStopTimeout, and returns the result of waiting for it.
+func stopProcess(proc *exec.Cmd, done <-chan error) error {
+	if err := proc.Process.Signal(syscall.SIGTERM); err != nil {
+		proc.Process.Kill()
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(runStopTimeout):
+		proc.Process.Kill()
+		return <-done
+	}
+}
+
+func exitCode(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code >= 0 {
+			return code, nil
+		}
+		// terminated by a signal
+		return 1, nil
+	}
+	return 0, err
+}
@@ -20,6 +20,7 @@ func New(api *config.API) *cobra.Command {
 	cmd.AddCommand(
 		newCompile(cfg),
 		newCreate(cfg),
+		newLint(cfg),
 		newGet(cfg),
 		newDelete(cfg),
 		newRecompile(cfg),
@@ -28,6 +29,7 @@ func New(api *config.API) *cobra.Command {
 		planexec.New(cfg),
 		planaction.New(cfg),
 		newRun(cfg),
+		newSchedule(cfg),
 	)
 
 	return cmd
@@ -72,21 +72,34 @@ func create(cfg *config.PlanCreate, out, log io.Writer) error {
 }
 
 func loadPlanSpec(file string, tplVals config.TemplateVals) (*models.PlanSpec, error) {
-	template, err := utils.LoadUnstructuredTemplate(file, tplVals, false)
+	template, _, err := loadPlanSpecTemplate(file, tplVals)
 	if err != nil {
 		return nil, err
 	}
+	return parsePlanSpec(template)
+}
+
+// loadPlanSpecTemplate loads the unstructured plan spec of a file, reporting
+// whether it is nested under a top-level spec field.
+func loadPlanSpecTemplate(file string, tplVals config.TemplateVals) (any, bool, error) {
+	template, err := utils.LoadUnstructuredTemplate(file, tplVals, false)
+	if err != nil {
+		return nil, false, err
+	}
 
 	// Extract the spec field if present, otherwise treat the whole thing as spec.
 	m, ok := template.(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("plan file must be a YAML/JSON object")
+		return nil, false, fmt.Errorf("plan file must be a YAML/JSON object")
 	}
 	specVal, hasSpec := m["spec"]
 	if hasSpec {
 		template = specVal
 	}
+	return template, hasSpec, nil
+}
 
+func parsePlanSpec(template any) (*models.PlanSpec, error) {
 	d, err := json.Marshal(template)
 	if err != nil {
 		return nil, err
@@ -1,9 +1,11 @@
 package plan
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
+	"github.com/signadot/cli/internal/command/planshared"
 	"github.com/signadot/cli/internal/config"
 	"github.com/signadot/cli/internal/print"
 	sdkplans "github.com/signadot/go-sdk/client/plans"
@@ -16,12 +18,20 @@ func newGet(plan *config.Plan) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get PLAN_ID",
 		Short: "Get plan details",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get the details of a plan.
+
+With --graph, show the dependency graph of its steps instead (a step depends
+on the steps whose outputs it refers to), as an ASCII tree or, to paste in
+documents, in the Graphviz DOT or Mermaid languages.`,
+		Example: `  signadot plan get PLAN_ID --graph
+  signadot plan get PLAN_ID --graph=mermaid`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return getPlan(cfg, cmd.OutOrStdout(), args[0])
 		},
 	}
 
+	cfg.AddFlags(cmd)
 	return cmd
 }
 
@@ -37,6 +47,13 @@ func getPlan(cfg *config.PlanGet, out io.Writer, planID string) error {
 		return err
 	}
 
+	if cfg.Graph != config.GraphFormatNone {
+		if cfg.OutputFormat != config.OutputFormatDefault {
+			return errors.New("--graph can't be combined with --output")
+		}
+		return planshared.PrintStepGraph(out, planshared.NewStepGraph(resp.Payload.Spec), string(cfg.Graph))
+	}
+
 	switch cfg.OutputFormat {
 	case config.OutputFormatDefault:
 		return printPlanDetails(out, resp.Payload)
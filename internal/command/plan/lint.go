@@ -0,0 +1,300 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/signadot/cli/internal/command/planshared"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newLint(plan *config.Plan) *cobra.Command {
+	cfg := &config.PlanLint{Plan: plan}
+
+	cmd := &cobra.Command{
+		Use:   "lint -f SPEC_FILE",
+		Short: "Check a plan spec file without creating the plan",
+		Long: `Check a hand-authored plan spec file before 'signadot plan create':
+
+  - validate it against the plan schema,
+  - check the wiring of step inputs (refs to undeclared params or unknown
+    steps),
+  - detect steps which can never run (depending on such steps, on each other,
+    or with a condition which is always false),
+  - report step outputs nothing uses.
+
+Every schema violation is reported, along with the line of the offending
+value. The plan schema of the configured API is fetched once and cached in
+~/.signadot/plan-schema.json, so linting works offline afterwards; use
+--refresh-schema to fetch it again. Exits with an error if any error is
+reported.`,
+		Example: `  signadot plan lint -f plan.yaml`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lint(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+type lintIssue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+
+	// path of the offending value in the spec
+	path []any
+}
+
+func lint(cfg *config.PlanLint, out, log io.Writer) error {
+	template, nested, err := loadPlanSpecTemplate(cfg.Filename, cfg.TemplateVals)
+	if err != nil {
+		return err
+	}
+	// normalize the spec to JSON values
+	d, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	var specVal any
+	if err := json.Unmarshal(d, &specVal); err != nil {
+		return err
+	}
+
+	var issues []lintIssue
+	if schema := loadPlanSchema(cfg, log); schema != nil {
+		if sv, err := newSchemaValidator(schema); err != nil {
+			fmt.Fprintf(log, "Warning: skipping schema validation, invalid plan schema: %v\n", err)
+		} else {
+			for _, v := range sv.validate(specVal) {
+				msg := "schema: " + v.message
+				if len(v.path) > 0 {
+					msg = fmt.Sprintf("schema: %s: %s", pointerString(v.path), v.message)
+				}
+				issues = append(issues, lintIssue{Severity: lintError, Message: msg, path: v.path})
+			}
+		}
+	}
+	spec, err := parsePlanSpec(template)
+	if err != nil {
+		issues = append(issues, lintIssue{Severity: lintError, Message: err.Error()})
+	} else {
+		issues = append(issues, lintPlanSpec(spec)...)
+	}
+
+	// map the paths to lines of the file, when it can be read again
+	var lf *lineFinder
+	if data, err := os.ReadFile(cfg.Filename); err == nil {
+		lf = newLineFinder(data)
+	} else {
+		lf = &lineFinder{}
+	}
+	for i := range issues {
+		path := issues[i].path
+		if nested {
+			path = append([]any{"spec"}, path...)
+		}
+		issues[i].File = cfg.Filename
+		issues[i].Line = lf.line(path)
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+
+	nErrors := 0
+	for _, is := range issues {
+		if is.Severity == lintError {
+			nErrors++
+		}
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		printLintIssues(out, log, issues, nErrors)
+	case config.OutputFormatJSON:
+		if err := print.RawJSON(out, issues); err != nil {
+			return err
+		}
+	case config.OutputFormatYAML:
+		if err := print.RawYAML(out, issues); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+	if nErrors > 0 {
+		return fmt.Errorf("%s has %d error(s)", cfg.Filename, nErrors)
+	}
+	return nil
+}
+
+func printLintIssues(out, log io.Writer, issues []lintIssue, nErrors int) {
+	if len(issues) == 0 {
+		fmt.Fprintln(log, "No issues found.")
+		return
+	}
+	for _, is := range issues {
+		if is.Line > 0 {
+			fmt.Fprintf(out, "%s:%d: %s: %s\n", is.File, is.Line, is.Severity, is.Message)
+		} else {
+			fmt.Fprintf(out, "%s: %s: %s\n", is.File, is.Severity, is.Message)
+		}
+	}
+	fmt.Fprintf(log, "\n%d error(s), %d warning(s)\n", nErrors, len(issues)-nErrors)
+}
+
+// lintPlanSpec checks the wiring of the steps of a plan: refs to undeclared
+// params or unknown steps, steps which can never run and step outputs which
+// are never used.
+func lintPlanSpec(spec *models.PlanSpec) []lintIssue {
+	var issues []lintIssue
+	add := func(severity string, path []any, format string, args ...any) {
+		issues = append(issues, lintIssue{Severity: severity, Message: fmt.Sprintf(format, args...), path: path})
+	}
+
+	params := map[string]bool{}
+	for _, p := range spec.Params {
+		if p != nil {
+			params[p.Name] = true
+		}
+	}
+	stepIndex := map[string]int{}
+	for i, s := range spec.Steps {
+		if s == nil {
+			continue
+		}
+		if _, ok := stepIndex[s.ID]; ok {
+			add(lintError, []any{"steps", i, "id"}, "duplicate step %q", s.ID)
+			continue
+		}
+		stepIndex[s.ID] = i
+	}
+
+	// deps[i] holds the steps on whose outputs step i depends
+	deps := make([]map[string]bool, len(spec.Steps))
+	// used[step][output] records the step outputs which are referred to
+	used := map[string]map[string]bool{}
+	checkRef := func(path []any, what, ref string) (planshared.PlanRef, bool) {
+		r, ok := planshared.ParsePlanRef(ref)
+		switch {
+		case !ok:
+			add(lintWarning, path, "%s: cannot check ref %q", what, ref)
+			return r, false
+		case r.Param != "":
+			if !params[r.Param] {
+				add(lintError, path, "%s refers to undeclared param %q", what, r.Param)
+			}
+			return r, false
+		}
+		if _, ok := stepIndex[r.Step]; !ok {
+			add(lintError, path, "%s refers to unknown step %q", what, r.Step)
+			return r, false
+		}
+		if used[r.Step] == nil {
+			used[r.Step] = map[string]bool{}
+		}
+		used[r.Step][r.Output] = true
+		return r, true
+	}
+
+	for i, s := range spec.Steps {
+		if s == nil || s.Args == nil {
+			continue
+		}
+		deps[i] = map[string]bool{}
+		for _, name := range sortedKeys(s.Args.Refs) {
+			path := []any{"steps", i, "args", "refs", name}
+			what := fmt.Sprintf("input %q of step %q", name, s.ID)
+			if r, ok := checkRef(path, what, s.Args.Refs[name]); ok {
+				deps[i][r.Step] = true
+			}
+		}
+	}
+	for _, name := range sortedKeys(spec.Output) {
+		checkRef([]any{"output", name}, fmt.Sprintf("plan output %q", name), spec.Output[name])
+	}
+	if c := spec.Cluster; c != nil {
+		for _, p := range []string{c.FromCluster, c.FromSandbox, c.FromRouteGroup} {
+			if p != "" && !params[p] {
+				add(lintError, []any{"cluster"}, "cluster refers to undeclared param %q", p)
+			}
+		}
+	}
+
+	// A step can run if its condition may hold and all the steps it depends
+	// on can run; compute that as a fixpoint, which leaves out cycles.
+	canRun := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for i, s := range spec.Steps {
+			if s == nil || canRun[s.ID] || isFalseCondition(s.Condition) {
+				continue
+			}
+			ok := true
+			for d := range deps[i] {
+				ok = ok && canRun[d]
+			}
+			if ok {
+				canRun[s.ID] = true
+				changed = true
+			}
+		}
+	}
+	for i, s := range spec.Steps {
+		if s == nil || canRun[s.ID] {
+			continue
+		}
+		if isFalseCondition(s.Condition) {
+			add(lintWarning, []any{"steps", i}, "step %q never runs: its condition is always false", s.ID)
+			continue
+		}
+		var blocked []string
+		for _, d := range sortedKeys(deps[i]) {
+			if !canRun[d] {
+				blocked = append(blocked, fmt.Sprintf("%q", d))
+			}
+		}
+		if len(blocked) > 0 {
+			add(lintError, []any{"steps", i}, "step %q can never run: it depends on step(s) %s, which can never run",
+				s.ID, strings.Join(blocked, ", "))
+		}
+	}
+
+	for i, s := range spec.Steps {
+		if s == nil {
+			continue
+		}
+		for j, o := range s.ExtraOutputs {
+			if o != nil && !used[s.ID][o.Name] {
+				add(lintWarning, []any{"steps", i, "extra_outputs", j}, "output %q of step %q is never used", o.Name, s.ID)
+			}
+		}
+	}
+	return issues
+}
+
+func isFalseCondition(cond string) bool {
+	return strings.TrimSpace(cond) == "false"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,483 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/utils/system"
+	sdkmeta "github.com/signadot/go-sdk/client/meta"
+)
+
+// planSchemaCacheFile is the file, under the signadot directory, caching the
+// plan-authoring JSON Schema of each API URL so that plans can be linted
+// offline.
+const planSchemaCacheFile = "plan-schema.json"
+
+// loadPlanSchema returns the cached plan schema of the configured API,
+// fetching it when it is not cached yet or when refresh is set. It returns
+// nil, after warning on log, when no schema is available.
+func loadPlanSchema(cfg *config.PlanLint, log io.Writer) map[string]any {
+	// only the API URL is needed to look up the cache
+	if err := cfg.InitUnauthAPIConfig(); err != nil {
+		fmt.Fprintf(log, "Warning: skipping schema validation: %v\n", err)
+		return nil
+	}
+	apiURL := cfg.APIURL
+	signadotDir, err := system.GetSignadotDir()
+	if err != nil {
+		fmt.Fprintf(log, "Warning: skipping schema validation: %v\n", err)
+		return nil
+	}
+	cacheFile := filepath.Join(signadotDir, planSchemaCacheFile)
+
+	schemas, cacheErr := readPlanSchemas(cacheFile)
+	if cacheErr != nil && !errors.Is(cacheErr, fs.ErrNotExist) {
+		fmt.Fprintf(log, "Warning: ignoring the plan schema cache: %v\n", cacheErr)
+	}
+	cached := schemas[apiURL]
+	if cached != nil && !cfg.RefreshSchema {
+		return cached
+	}
+	schema, err := fetchPlanSchema(cfg)
+	if err != nil {
+		if cached != nil {
+			fmt.Fprintf(log, "Warning: couldn't refresh the plan schema, using the cached copy: %v\n", err)
+			return cached
+		}
+		fmt.Fprintf(log, "Warning: skipping schema validation, the plan schema of %s isn't cached and couldn't be fetched: %v\n", apiURL, err)
+		return nil
+	}
+	if schemas == nil {
+		schemas = map[string]map[string]any{}
+	}
+	schemas[apiURL] = schema
+	d, err := json.Marshal(schemas)
+	if err == nil {
+		err = system.CreateDirIfNotExist(signadotDir)
+	}
+	if err == nil {
+		err = os.WriteFile(cacheFile, d, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(log, "Warning: couldn't cache the plan schema: %v\n", err)
+	}
+	return schema
+}
+
+// readPlanSchemas reads the cached plan schemas, by API URL.
+func readPlanSchemas(file string) (map[string]map[string]any, error) {
+	d, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var schemas map[string]map[string]any
+	if err := json.Unmarshal(d, &schemas); err != nil {
+		return nil, fmt.Errorf("invalid plan schema cache %s: %w", file, err)
+	}
+	return schemas, nil
+}
+
+func fetchPlanSchema(cfg *config.PlanLint) (map[string]any, error) {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return nil, err
+	}
+	resp, err := cfg.Client.Meta.MetaPlans(sdkmeta.NewMetaPlansParams())
+	if err != nil {
+		return nil, err
+	}
+	// round trip through JSON, whatever the payload type
+	d, err := json.Marshal(resp.Payload)
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(d, &schema); err != nil {
+		return nil, err
+	}
+	if len(schema) == 0 {
+		return nil, errors.New("empty plan schema")
+	}
+	return schema, nil
+}
+
+// schemaValidator checks a spec against the plan schema. The validator of
+// jsonschema-go stops at the first violation and doesn't tell where it is, so
+// the spec is walked along with the schema, each object and array being
+// checked on its own before its members are: every violation is reported,
+// with the path of the offending value.
+type schemaValidator struct {
+	root map[string]any
+	// defsKey is the keyword under which the root schema has its
+	// definitions
+	defsKey string
+	// resolved caches the schemas checked against, by schema pointer and
+	// whether they are shallow
+	resolved map[schemaKey]*jsonschema.Resolved
+}
+
+type schemaKey struct {
+	ptr     string
+	shallow bool
+}
+
+type schemaViolation struct {
+	path    []any
+	message string
+}
+
+// lintTargetDef is the definition, added to the root schema, holding the
+// subschema to check a value against.
+const lintTargetDef = "signadot-lint-target"
+
+// keywords of subschemas which apply to a value along with its members: the
+// values with such a subschema are checked as a whole
+var wholeValueKeywords = []string{
+	"$ref", "$dynamicRef", "allOf", "anyOf", "oneOf", "not", "if",
+	"dependentSchemas", "patternProperties", "prefixItems",
+	"unevaluatedProperties", "unevaluatedItems",
+}
+
+// keywords of subschemas which don't constrain the value
+var annotationKeywords = map[string]bool{
+	"title": true, "description": true, "$comment": true, "default": true,
+	"examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+func newSchemaValidator(schema map[string]any) (*schemaValidator, error) {
+	sv := &schemaValidator{
+		root:     schema,
+		defsKey:  "$defs",
+		resolved: map[schemaKey]*jsonschema.Resolved{},
+	}
+	if _, ok := schema["$defs"]; !ok {
+		if _, ok := schema["definitions"]; ok {
+			sv.defsKey = "definitions"
+		}
+	}
+	// check the schema as a whole up front
+	if _, err := sv.resolve(schemaKey{}); err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+// validate returns the violations of the schema by the given value, which
+// must be a JSON value.
+func (sv *schemaValidator) validate(v any) []schemaViolation {
+	var res []schemaViolation
+	sv.walk("", v, nil, &res)
+	return res
+}
+
+func (sv *schemaValidator) walk(ptr string, v any, path []any, res *[]schemaViolation) {
+	ptr = sv.deref(ptr)
+	s, _ := sv.lookup(ptr).(map[string]any)
+	descend := s != nil
+	for _, k := range wholeValueKeywords {
+		if _, ok := s[k]; ok {
+			descend = false
+		}
+	}
+	if descend {
+		switch val := v.(type) {
+		case map[string]any:
+			props, _ := s["properties"].(map[string]any)
+			addl, _ := s["additionalProperties"].(map[string]any)
+			if props == nil && addl == nil {
+				break
+			}
+			sv.check(schemaKey{ptr: ptr, shallow: true}, v, path, res)
+			for _, k := range sortedKeys(val) {
+				child := append(slices.Clip(path), k)
+				if _, ok := props[k]; ok {
+					sv.walk(ptr+"/properties/"+escapePointer(k), val[k], child, res)
+				} else if addl != nil {
+					sv.walk(ptr+"/additionalProperties", val[k], child, res)
+				}
+			}
+			return
+		case []any:
+			if _, ok := s["items"].(map[string]any); !ok {
+				break
+			}
+			sv.check(schemaKey{ptr: ptr, shallow: true}, v, path, res)
+			for i, item := range val {
+				sv.walk(ptr+"/items", item, append(slices.Clip(path), i), res)
+			}
+			return
+		}
+	}
+	sv.check(schemaKey{ptr: ptr}, v, path, res)
+}
+
+// check checks a value against the subschema at the given pointer, recording
+// the violation if any.
+func (sv *schemaValidator) check(key schemaKey, v any, path []any, res *[]schemaViolation) {
+	rs, err := sv.resolve(key)
+	if err == nil {
+		err = rs.Validate(v)
+	}
+	if err != nil {
+		*res = append(*res, schemaViolation{path: path, message: violationMessage(err)})
+	}
+}
+
+// resolve prepares the subschema at the pointer of key for validation. A
+// shallow subschema only constrains an object or an array, not its members.
+func (sv *schemaValidator) resolve(key schemaKey) (*jsonschema.Resolved, error) {
+	if rs, ok := sv.resolved[key]; ok {
+		return rs, nil
+	}
+	target := sv.lookup(key.ptr)
+	if m, ok := target.(map[string]any); ok {
+		target = shallowSchema(m, key.shallow)
+	}
+	// the subschema is checked as a definition of the root schema, for its
+	// refs to resolve
+	doc := map[string]any{}
+	if v, ok := sv.root["$schema"]; ok {
+		doc["$schema"] = v
+	}
+	defs := map[string]any{}
+	if m, ok := sv.root[sv.defsKey].(map[string]any); ok {
+		maps.Copy(defs, m)
+	}
+	defs[lintTargetDef] = target
+	doc[sv.defsKey] = defs
+	doc["$ref"] = "#/" + sv.defsKey + "/" + lintTargetDef
+
+	d, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var s jsonschema.Schema
+	if err := json.Unmarshal(d, &s); err != nil {
+		return nil, err
+	}
+	rs, err := s.Resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	sv.resolved[key] = rs
+	return rs, nil
+}
+
+// shallowSchema returns a copy of a subschema fit to be checked on its own:
+// without the keywords identifying a root schema and, when shallow, with the
+// subschemas of its members allowing anything.
+func shallowSchema(s map[string]any, shallow bool) map[string]any {
+	res := maps.Clone(s)
+	for _, k := range []string{"$schema", "$id", "$defs", "definitions"} {
+		delete(res, k)
+	}
+	if !shallow {
+		return res
+	}
+	if props, ok := res["properties"].(map[string]any); ok {
+		anyProps := make(map[string]any, len(props))
+		for k := range props {
+			anyProps[k] = true
+		}
+		res["properties"] = anyProps
+	}
+	if _, ok := res["additionalProperties"].(map[string]any); ok {
+		res["additionalProperties"] = true
+	}
+	if _, ok := res["items"].(map[string]any); ok {
+		res["items"] = true
+	}
+	return res
+}
+
+// deref follows the local refs of the subschema at ptr which only hold a ref.
+func (sv *schemaValidator) deref(ptr string) string {
+	for range 32 {
+		s, ok := sv.lookup(ptr).(map[string]any)
+		if !ok {
+			return ptr
+		}
+		ref, ok := s["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return ptr
+		}
+		for k := range s {
+			if k != "$ref" && !annotationKeywords[k] {
+				return ptr
+			}
+		}
+		ptr = strings.TrimPrefix(ref, "#")
+	}
+	return ptr
+}
+
+// lookup returns the subschema of the root schema at the given JSON pointer,
+// or nil.
+func (sv *schemaValidator) lookup(ptr string) any {
+	var node any = sv.root
+	if ptr == "" {
+		return node
+	}
+	for _, tok := range strings.Split(strings.TrimPrefix(ptr, "/"), "/") {
+		tok = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+		switch n := node.(type) {
+		case map[string]any:
+			node = n[tok]
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil
+			}
+			node = n[i]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+func escapePointer(tok string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(tok)
+}
+
+// violationMessage drops the schema locations jsonschema-go prefixes its
+// errors with, which refer to the schema rather than to the spec.
+func violationMessage(err error) string {
+	msg := err.Error()
+	for strings.HasPrefix(msg, "validating ") {
+		_, rest, ok := strings.Cut(msg, ": ")
+		if !ok {
+			break
+		}
+		msg = rest
+	}
+	return msg
+}
+
+// pointerString formats a path in a spec as a JSON pointer.
+func pointerString(path []any) string {
+	var sb strings.Builder
+	for _, elem := range path {
+		sb.WriteString("/")
+		sb.WriteString(escapePointer(fmt.Sprint(elem)))
+	}
+	return sb.String()
+}
+
+// lineFinder maps paths in a YAML or JSON document to line numbers.
+type lineFinder struct {
+	root ast.Node
+}
+
+func newLineFinder(data []byte) *lineFinder {
+	f, err := parser.ParseBytes(data, 0)
+	if err != nil || len(f.Docs) == 0 {
+		return &lineFinder{}
+	}
+	return &lineFinder{root: f.Docs[0].Body}
+}
+
+// line returns the line of the value at path or, when there is no such
+// value, of its closest ancestor. It returns 0 if the document couldn't be
+// parsed.
+func (lf *lineFinder) line(path []any) int {
+	node := lf.root
+	if node == nil {
+		return 0
+	}
+	line := nodeLine(node)
+	for _, elem := range path {
+		node = childNode(node, elem)
+		if node == nil {
+			break
+		}
+		if l := nodeLine(node); l > 0 {
+			line = l
+		}
+	}
+	return line
+}
+
+func childNode(node ast.Node, elem any) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+			continue
+		case *ast.TagNode:
+			node = n.Value
+			continue
+		}
+		break
+	}
+	switch key := elem.(type) {
+	case string:
+		var values []*ast.MappingValueNode
+		switch n := node.(type) {
+		case *ast.MappingNode:
+			values = n.Values
+		case *ast.MappingValueNode:
+			values = []*ast.MappingValueNode{n}
+		}
+		for _, mv := range values {
+			if mv.Key != nil && mappingKey(mv.Key) == key {
+				// the line of a field is the line of its key, block values
+				// starting on the next line
+				return &keyedNode{Node: mv.Value, key: mv.Key}
+			}
+		}
+	case int:
+		if kn, ok := node.(*keyedNode); ok {
+			node = kn.Node
+		}
+		if n, ok := node.(*ast.SequenceNode); ok && key < len(n.Values) {
+			return n.Values[key]
+		}
+	}
+	if kn, ok := node.(*keyedNode); ok {
+		return childNode(kn.Node, elem)
+	}
+	return nil
+}
+
+// keyedNode is a mapping value remembering its key, for line reporting.
+type keyedNode struct {
+	ast.Node
+	key ast.MapKeyNode
+}
+
+func mappingKey(k ast.MapKeyNode) string {
+	tk := k.GetToken()
+	if tk == nil {
+		return ""
+	}
+	return tk.Value
+}
+
+func nodeLine(node ast.Node) int {
+	if kn, ok := node.(*keyedNode); ok {
+		if tk := kn.key.GetToken(); tk != nil && tk.Position != nil {
+			return tk.Position.Line
+		}
+		node = kn.Node
+	}
+	if node == nil {
+		return 0
+	}
+	if tk := node.GetToken(); tk != nil && tk.Position != nil {
+		return tk.Position.Line
+	}
+	return 0
+}
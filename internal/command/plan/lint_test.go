@@ -0,0 +1,132 @@
+package plan
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSchemaValidator(t *testing.T) {
+	t.Parallel()
+	schema := map[string]any{}
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["steps"],
+		"additionalProperties": false,
+		"properties": {
+			"runner": {"type": "string"},
+			"steps": {"type": "array", "items": {"$ref": "#/$defs/step"}}
+		},
+		"$defs": {
+			"step": {
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string"},
+					"retries": {"type": "integer"},
+					"mode": {"enum": ["fast", "slow"]}
+				}
+			}
+		}
+	}`), &schema); err != nil {
+		t.Fatal(err)
+	}
+	sv, err := newSchemaValidator(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type violation struct {
+		ptr, msg string
+	}
+	cases := []struct {
+		spec string
+		want []violation
+	}{
+		{spec: `{"runner": "x", "steps": [{"id": "a", "retries": 1, "mode": "fast"}]}`},
+		{spec: `{"runnr": "x", "steps": []}`, want: []violation{{"", "runnr"}}},
+		{spec: `{"steps": [{"retries": 1}]}`, want: []violation{{"/steps/0", "id"}}},
+		{
+			spec: `{"runner": 1, "steps": [{"id": "a", "mode": "medium"}, {"id": "b", "retries": 1.5}]}`,
+			want: []violation{{"/runner", "string"}, {"/steps/0/mode", "medium"}, {"/steps/1/retries", "integer"}},
+		},
+	}
+	for _, c := range cases {
+		var spec any
+		if err := json.Unmarshal([]byte(c.spec), &spec); err != nil {
+			t.Fatal(err)
+		}
+		got := sv.validate(spec)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got violations %v, want %v", c.spec, got, c.want)
+			continue
+		}
+		for i, v := range got {
+			if ptr := pointerString(v.path); ptr != c.want[i].ptr || !strings.Contains(v.message, c.want[i].msg) {
+				t.Errorf("%s: got violation %q at %q, want one mentioning %q at %q",
+					c.spec, v.message, ptr, c.want[i].msg, c.want[i].ptr)
+			}
+			if strings.Contains(v.message, "validating") {
+				t.Errorf("%s: schema locations left in %q", c.spec, v.message)
+			}
+		}
+	}
+}
+
+func TestSchemaValidatorDraft7(t *testing.T) {
+	t.Parallel()
+	schema := map[string]any{}
+	if err := json.Unmarshal([]byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"properties": {
+			"params": {"type": "array", "items": {"$ref": "#/definitions/param"}}
+		},
+		"definitions": {
+			"param": {"type": "object", "required": ["name"]}
+		}
+	}`), &schema); err != nil {
+		t.Fatal(err)
+	}
+	sv, err := newSchemaValidator(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec any
+	if err := json.Unmarshal([]byte(`{"params": [{"name": "a"}, {}, {}]}`), &spec); err != nil {
+		t.Fatal(err)
+	}
+	got := sv.validate(spec)
+	if len(got) != 2 || pointerString(got[0].path) != "/params/1" || pointerString(got[1].path) != "/params/2" {
+		t.Errorf("got violations %v, want ones at /params/1 and /params/2", got)
+	}
+}
+
+func TestLineFinder(t *testing.T) {
+	t.Parallel()
+	lf := newLineFinder([]byte(`spec:
+  params:
+    - name: env
+  steps:
+    - id: a
+      args:
+        refs:
+          x: params.env
+    - id: b
+`))
+	cases := []struct {
+		path []any
+		want int
+	}{
+		{[]any{"spec", "params", 0}, 3},
+		{[]any{"spec", "steps", 0, "args", "refs", "x"}, 8},
+		{[]any{"spec", "steps", 1}, 9},
+		// missing values map to their closest ancestor
+		{[]any{"spec", "steps", 1, "args"}, 9},
+		{[]any{"spec", "output"}, 1},
+	}
+	for _, c := range cases {
+		if got := lf.line(c.path); got != c.want {
+			t.Errorf("line(%v) = %d, want %d", c.path, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/signadot/cli/internal/clio"
+	"github.com/signadot/cli/internal/command/planexec"
+	"github.com/signadot/cli/internal/config"
+	sdkprint "github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/signadot/go-sdk/models"
+)
+
+// matrixCell is a combination of values of the matrix params, named like
+// "env=prod,region=eu".
+type matrixCell struct {
+	Name   string
+	Params map[string]any
+}
+
+// matrixResult is the outcome of the execution of a matrix cell.
+type matrixResult struct {
+	Cell        string         `json:"cell"`
+	Params      map[string]any `json:"params"`
+	ExecutionID string         `json:"executionID,omitempty"`
+	Phase       string         `json:"phase,omitempty"`
+	Error       string         `json:"error,omitempty"`
+
+	suite *report.Suite
+}
+
+// loadMatrix returns the matrix params, sorted, and their values, from
+// --matrix and --matrix-param.
+func loadMatrix(cfg *config.PlanRun) ([]string, map[string][]any, error) {
+	values := map[string][]any{}
+	if cfg.Matrix != "" {
+		m, err := clio.LoadYAML[map[string]any](cfg.Matrix)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range *m {
+			switch x := v.(type) {
+			case []any:
+				values[k] = x
+			default:
+				values[k] = []any{x}
+			}
+		}
+	}
+	for _, tv := range cfg.MatrixParams {
+		if _, ok := values[tv.Var]; ok {
+			return nil, nil, fmt.Errorf("matrix param %q is specified more than once", tv.Var)
+		}
+		for _, v := range strings.Split(tv.Val, ",") {
+			values[tv.Var] = append(values[tv.Var], paramValue(v))
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for k, vs := range values {
+		if len(vs) == 0 {
+			return nil, nil, fmt.Errorf("matrix param %q has no values", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, values, nil
+}
+
+// matrixCells returns the cartesian product of the values of the matrix
+// params, the first param varying slowest.
+func matrixCells(keys []string, values map[string][]any) []matrixCell {
+	cells := []matrixCell{{Params: map[string]any{}}}
+	for _, k := range keys {
+		var next []matrixCell
+		for _, c := range cells {
+			for _, v := range values[k] {
+				params := make(map[string]any, len(c.Params)+1)
+				for pk, pv := range c.Params {
+					params[pk] = pv
+				}
+				params[k] = v
+				name := k + "=" + formatCellValue(v)
+				if c.Name != "" {
+					name = c.Name + "," + name
+				}
+				next = append(next, matrixCell{Name: name, Params: params})
+			}
+		}
+		cells = next
+	}
+	return cells
+}
+
+func formatCellValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case json.RawMessage:
+		return string(x)
+	}
+	d, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(d)
+}
+
+// cellDirName turns the name of a cell into a directory name.
+func cellDirName(cell string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '.', r == '-', r == '=':
+			return r
+		}
+		return '_'
+	}, cell)
+}
+
+// runMatrix runs an execution of the plan per matrix cell, at most
+// --max-parallel at once, then prints a summary and exits with the
+// aggregated code: 1 if any execution failed, else 2 if any was cancelled.
+func runMatrix(ctx context.Context, cfg *config.PlanRun, out, log io.Writer,
+	plan *models.RunnablePlan, params map[string]any, secrets map[string]string) error {
+	if cfg.MaxParallel < 1 {
+		return fmt.Errorf("--max-parallel must be at least 1")
+	}
+	keys, values, err := loadMatrix(cfg)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if _, ok := params[k]; ok {
+			return fmt.Errorf("param %q appears in both --param and the matrix; specify only one", k)
+		}
+		if _, ok := secrets[k]; ok {
+			return fmt.Errorf("param %q appears in both --param-secret and the matrix; specify only one", k)
+		}
+	}
+	cells := matrixCells(keys, values)
+	if cfg.OutputFormat == config.OutputFormatDefault && !cfg.Attach {
+		fmt.Fprintf(log, "Running %d executions of plan %s (at most %d at once)\n", len(cells), plan.ID, cfg.MaxParallel)
+	}
+
+	aw := sdkprint.NewAttachWriter(out, cfg.OutputFormat == config.OutputFormatJSON)
+	results := make([]matrixResult, len(cells))
+	sem := make(chan struct{}, cfg.MaxParallel)
+	var wg sync.WaitGroup
+	for i, cell := range cells {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = matrixResult{Cell: cell.Name, Params: cell.Params,
+					Phase: string(models.PlansExecutionPhaseCancelled), Error: "not started: interrupted"}
+				return
+			}
+			defer func() { <-sem }()
+			results[i] = runMatrixCell(ctx, cfg, log, aw.WithCell(cell.Name), plan, cell, params, secrets)
+		}()
+	}
+	wg.Wait()
+
+	if err := writeMatrixResults(cfg, out, log, results); err != nil {
+		return err
+	}
+	if len(cfg.Reports) > 0 {
+		if err := report.Write(cfg.Reports, matrixSuites(plan.ID, results)...); err != nil {
+			fmt.Fprintf(log, "Warning: %v\n", err)
+		}
+	}
+	if code := matrixExitCode(results); code != 0 {
+		os.Exit(code)
+	}
+	return nil
+}
+
+func runMatrixCell(ctx context.Context, cfg *config.PlanRun, log io.Writer, aw *sdkprint.AttachWriter,
+	plan *models.RunnablePlan, cell matrixCell, base map[string]any, secrets map[string]string) matrixResult {
+	res := matrixResult{Cell: cell.Name, Params: cell.Params}
+	textLog := cfg.OutputFormat == config.OutputFormatDefault && !cfg.Attach
+
+	params := make(map[string]any, len(base)+len(cell.Params))
+	for k, v := range base {
+		params[k] = v
+	}
+	for k, v := range cell.Params {
+		params[k] = v
+	}
+	created, err := createExecution(ctx, cfg, plan.ID, params, secrets)
+	if err != nil {
+		res.Error = err.Error()
+		if textLog {
+			fmt.Fprintf(log, "[%s] %s\n", cell.Name, res.Error)
+		}
+		return res
+	}
+	res.ExecutionID = created.ID
+	if textLog {
+		fmt.Fprintf(log, "[%s] Created execution %s\n", cell.Name, created.ID)
+	}
+	if !cfg.Wait {
+		if created.Status != nil {
+			res.Phase = string(created.Status.Phase)
+		}
+		return res
+	}
+
+	exec, err := planexec.WaitExecution(ctx, cfg.API, &cfg.PlanExecWait, aw, created)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			planexec.CancelExecution(cfg.API, created.ID)
+			res.Phase = string(models.PlansExecutionPhaseCancelled)
+		}
+		res.Error = err.Error()
+		return res
+	}
+	res.Phase = string(exec.Status.Phase)
+	res.Error = exec.Status.Error
+	if textLog {
+		fmt.Fprintf(log, "[%s] Execution %s %s\n", cell.Name, exec.ID, exec.Status.Phase)
+	}
+	if len(cfg.Reports) > 0 {
+		suite := planexec.ExecutionSuite(cfg.API, exec, plan.Spec, matrixSuiteName(plan.ID, cell.Name))
+		res.suite = &suite
+	}
+	if cfg.OutputDir != "" {
+		dir := filepath.Join(cfg.OutputDir, cellDirName(cell.Name))
+		if err := planexec.ExportOutputs(cfg.API, dir, log, exec); err != nil {
+			fmt.Fprintf(log, "Warning: [%s] output export failed: %v\n", cell.Name, err)
+		}
+	}
+	return res
+}
+
+func matrixSuiteName(planID, cell string) string {
+	return planID + " [" + cell + "]"
+}
+
+// matrixSuites returns the test reports of the cells, with a single
+// erroring test case for cells whose execution didn't finish.
+func matrixSuites(planID string, results []matrixResult) []report.Suite {
+	suites := make([]report.Suite, 0, len(results))
+	for _, r := range results {
+		if r.suite != nil {
+			suites = append(suites, *r.suite)
+			continue
+		}
+		name := matrixSuiteName(planID, r.Cell)
+		suites = append(suites, report.Suite{
+			Name: name,
+			Cases: []report.Case{{
+				Name:      "execution",
+				Classname: planID,
+				Status:    report.StatusError,
+				Message:   r.Error,
+			}},
+		})
+	}
+	return suites
+}
+
+type matrixResultRow struct {
+	Cell      string `sdtab:"CELL"`
+	Execution string `sdtab:"EXECUTION"`
+	Phase     string `sdtab:"PHASE"`
+	Error     string `sdtab:"ERROR,trunc"`
+}
+
+// writeMatrixResults prints the summary of a matrix run. With --attach,
+// stdout holds the event stream, so the summary table goes to stderr.
+func writeMatrixResults(cfg *config.PlanRun, out, log io.Writer, results []matrixResult) error {
+	if cfg.Attach {
+		fmt.Fprintln(log)
+		return printMatrixTable(log, results)
+	}
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		fmt.Fprintln(log)
+		return printMatrixTable(out, results)
+	case config.OutputFormatJSON:
+		return sdkprint.RawJSON(out, results)
+	case config.OutputFormatYAML:
+		return sdkprint.RawYAML(out, results)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+func printMatrixTable(out io.Writer, results []matrixResult) error {
+	t := sdtab.New[matrixResultRow](out)
+	t.AddHeader()
+	for _, r := range results {
+		t.AddRow(matrixResultRow{
+			Cell:      r.Cell,
+			Execution: r.ExecutionID,
+			Phase:     r.Phase,
+			Error:     r.Error,
+		})
+	}
+	return t.Flush()
+}
+
+// matrixExitCode aggregates the phases of the executions like the exit
+// code of a single run: 1 if any failed (or couldn't be created), else 2 if
+// any was cancelled, else 0.
+func matrixExitCode(results []matrixResult) int {
+	code := 0
+	for _, r := range results {
+		switch {
+		case r.Phase == string(models.PlansExecutionPhaseCancelled):
+			if code == 0 {
+				code = 2
+			}
+		case r.Phase == string(models.PlansExecutionPhaseFailed), r.Error != "":
+			return 1
+		}
+	}
+	return code
+}
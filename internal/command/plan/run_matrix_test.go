@@ -0,0 +1,35 @@
+package plan
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMatrixCells(t *testing.T) {
+	t.Parallel()
+	values := map[string][]any{
+		"env":      {"staging", "prod"},
+		"replicas": {json.RawMessage("1"), json.RawMessage("3")},
+	}
+	cells := matrixCells([]string{"env", "replicas"}, values)
+	var names []string
+	for _, c := range cells {
+		names = append(names, c.Name)
+	}
+	want := []string{
+		"env=staging,replicas=1",
+		"env=staging,replicas=3",
+		"env=prod,replicas=1",
+		"env=prod,replicas=3",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got cells %v, want %v", names, want)
+	}
+	if got := cells[2].Params["env"]; got != "prod" {
+		t.Errorf("got env %v in %s, want prod", got, cells[2].Name)
+	}
+	if got := cellDirName(cells[3].Name); got != "env=prod_replicas=3" {
+		t.Errorf("got dir %q", got)
+	}
+}
@@ -0,0 +1,294 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/spf13/cobra"
+)
+
+var scheduleNameRx = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+func newSchedule(plan *config.Plan) *cobra.Command {
+	cfg := &config.PlanSchedule{Plan: plan}
+
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage recurring plan runs",
+		Long: `Manage recurring runs of plans, on cron schedules.
+
+Schedules are stored locally, in ~/.signadot/plan-schedules.yaml, and fire
+while 'signadot plan schedule run-local' is running, which records each run
+in a history directory.`,
+	}
+
+	cmd.AddCommand(
+		newScheduleCreate(cfg),
+		newScheduleList(cfg),
+		newSchedulePause(cfg),
+		newScheduleResume(cfg),
+		newScheduleDelete(cfg),
+		newScheduleRunLocal(cfg),
+	)
+
+	return cmd
+}
+
+func newScheduleCreate(schedule *config.PlanSchedule) *cobra.Command {
+	cfg := &config.PlanScheduleCreate{PlanSchedule: schedule}
+
+	cmd := &cobra.Command{
+		Use:   "create {--tag TAG_NAME | --plan PLAN_ID} --cron EXPR [--param key=value ...]",
+		Short: "Schedule recurring runs of a plan",
+		Long: `Schedule recurring runs of a plan, by tag (resolved at each run) or by ID,
+with the same params and targets as 'signadot plan run'.
+
+The cron expression has five fields, in local time: minute, hour, day of
+month, month and day of week. Fields take values, lists ("1,15"), ranges
+("9-17") and steps ("*/10"); @hourly, @daily, @weekly, @monthly and @yearly
+are shorthands.`,
+		Example: `  # Run the nightly-e2e plan at 2am every day
+  signadot plan schedule create --tag nightly-e2e --cron "0 2 * * *" --param env=staging`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createSchedule(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+func createSchedule(cfg *config.PlanScheduleCreate, out, log io.Writer) error {
+	cron, err := parseCron(cfg.Cron)
+	if err != nil {
+		return err
+	}
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Tag
+		if name == "" {
+			name = cfg.PlanID
+		}
+	}
+	if !scheduleNameRx.MatchString(name) {
+		return fmt.Errorf("invalid schedule name %q: it should match %s", name, scheduleNameRx)
+	}
+
+	st, err := newScheduleStore()
+	if err != nil {
+		return err
+	}
+	s := &planSchedule{
+		Name:       name,
+		Tag:        cfg.Tag,
+		PlanID:     cfg.PlanID,
+		Cron:       cfg.Cron,
+		Cluster:    cfg.Cluster,
+		Sandbox:    cfg.Sandbox,
+		RouteGroup: cfg.RouteGroup,
+		Params:     templateValsMap(cfg.Params),
+		Secrets:    templateValsMap(cfg.Secrets),
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := st.create(s); err != nil {
+		if errors.Is(err, errScheduleExists) {
+			return fmt.Errorf("plan schedule %q already exists; delete it first or use --name", name)
+		}
+		return err
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		fmt.Fprintf(log, "Created plan schedule %q, next run at %s.\n", name, formatNextRun(cron, false))
+		fmt.Fprintln(log, "Schedules fire while 'signadot plan schedule run-local' is running.")
+		return nil
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, s)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, s)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+func templateValsMap(tvs config.TemplateVals) map[string]string {
+	if len(tvs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(tvs))
+	for _, tv := range tvs {
+		m[tv.Var] = tv.Val
+	}
+	return m
+}
+
+func formatNextRun(cron *cronSchedule, paused bool) string {
+	if paused {
+		return "-"
+	}
+	next := cron.next(time.Now())
+	if next.IsZero() {
+		return "never"
+	}
+	return next.Format("2006-01-02 15:04 MST")
+}
+
+func newScheduleList(schedule *config.PlanSchedule) *cobra.Command {
+	cfg := &config.PlanScheduleList{PlanSchedule: schedule}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List plan schedules",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listSchedules(cfg, cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+type scheduleRow struct {
+	Name    string `sdtab:"NAME"`
+	Plan    string `sdtab:"PLAN"`
+	Cron    string `sdtab:"CRON"`
+	NextRun string `sdtab:"NEXT RUN"`
+	Status  string `sdtab:"STATUS"`
+}
+
+func listSchedules(cfg *config.PlanScheduleList, out io.Writer) error {
+	st, err := newScheduleStore()
+	if err != nil {
+		return err
+	}
+	schedules, err := st.list()
+	if err != nil {
+		return err
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		t := sdtab.New[scheduleRow](out)
+		t.AddHeader()
+		for _, s := range schedules {
+			row := scheduleRow{
+				Name:   s.Name,
+				Plan:   s.PlanID,
+				Cron:   s.Cron,
+				Status: "active",
+			}
+			if s.Tag != "" {
+				row.Plan = "tag " + s.Tag
+			}
+			if s.Paused {
+				row.Status = "paused"
+			}
+			if cron, err := parseCron(s.Cron); err != nil {
+				row.NextRun = "invalid cron"
+			} else {
+				row.NextRun = formatNextRun(cron, s.Paused)
+			}
+			t.AddRow(row)
+		}
+		return t.Flush()
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, schedules)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, schedules)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+func newSchedulePause(schedule *config.PlanSchedule) *cobra.Command {
+	cfg := &config.PlanSchedulePause{PlanSchedule: schedule}
+
+	cmd := &cobra.Command{
+		Use:   "pause NAME",
+		Short: "Pause a plan schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pauseSchedule(cfg, cmd.ErrOrStderr(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+func newScheduleResume(schedule *config.PlanSchedule) *cobra.Command {
+	cfg := &config.PlanScheduleResume{PlanSchedule: schedule}
+
+	cmd := &cobra.Command{
+		Use:   "resume NAME",
+		Short: "Resume a paused plan schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resumeSchedule(cfg, cmd.ErrOrStderr(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+func pauseSchedule(cfg *config.PlanSchedulePause, log io.Writer, name string) error {
+	return setSchedulePaused(log, name, true)
+}
+
+func resumeSchedule(cfg *config.PlanScheduleResume, log io.Writer, name string) error {
+	return setSchedulePaused(log, name, false)
+}
+
+func setSchedulePaused(log io.Writer, name string, paused bool) error {
+	st, err := newScheduleStore()
+	if err != nil {
+		return err
+	}
+	s, err := st.get(name)
+	if err != nil {
+		return err
+	}
+	s.Paused = paused
+	if err := st.update(s); err != nil {
+		return err
+	}
+	if paused {
+		fmt.Fprintf(log, "Paused plan schedule %q.\n", name)
+	} else {
+		fmt.Fprintf(log, "Resumed plan schedule %q.\n", name)
+	}
+	return nil
+}
+
+func newScheduleDelete(schedule *config.PlanSchedule) *cobra.Command {
+	cfg := &config.PlanScheduleDelete{PlanSchedule: schedule}
+
+	cmd := &cobra.Command{
+		Use:   "delete NAME",
+		Short: "Delete a plan schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteSchedule(cfg, cmd.ErrOrStderr(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+func deleteSchedule(cfg *config.PlanScheduleDelete, log io.Writer, name string) error {
+	st, err := newScheduleStore()
+	if err != nil {
+		return err
+	}
+	if err := st.remove(name); err != nil {
+		return err
+	}
+	fmt.Fprintf(log, "Deleted plan schedule %q.\n", name)
+	return nil
+}
@@ -0,0 +1,47 @@
+package plan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	t.Parallel()
+	// A Monday.
+	from := time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 2 * * *", time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 19, 10, 45, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * sat,sun", time.Date(2026, 10, 24, 9, 0, 0, 0, time.UTC)},
+		{"30 8 1 jan *", time.Date(2027, 1, 1, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * 5", time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+		// a day of month or of week matching every day is unrestricted
+		{"0 0 1-31 * fri", time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{"0 0 25 * 0-7", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 25 * */1", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		s, err := parseCron(c.expr)
+		if err != nil {
+			t.Errorf("parseCron(%q): %v", c.expr, err)
+			continue
+		}
+		if got := s.next(from); !got.Equal(c.want) {
+			t.Errorf("next(%q) = %s, want %s", c.expr, got, c.want)
+		}
+		if !c.want.IsZero() && !s.matches(c.want) {
+			t.Errorf("%q doesn't match %s", c.expr, c.want)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "5-1 * * * *", "*/0 * * * *", "* * * foo *"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
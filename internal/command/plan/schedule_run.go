@@ -0,0 +1,211 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/command/planexec"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newScheduleRunLocal(schedule *config.PlanSchedule) *cobra.Command {
+	cfg := &config.PlanScheduleRunLocal{PlanSchedule: schedule}
+
+	cmd := &cobra.Command{
+		Use:   "run-local",
+		Short: "Fire the plan schedules from this machine until interrupted",
+		Long: `Run in the foreground, firing the plan schedules as 'signadot plan run'
+would, and recording each run as a JSON file in the history directory, under
+a subdirectory per schedule.
+
+Changes to the schedules are picked up without restarting. A schedule does
+not fire while its previous run is still going. When interrupted, the
+executions still running are left to finish.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSchedulesLocally(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+// scheduleRun is the record of a run of a plan schedule.
+type scheduleRun struct {
+	Schedule    string                `json:"schedule"`
+	FiredAt     string                `json:"firedAt"`
+	ExecutionID string                `json:"executionID,omitempty"`
+	Phase       string                `json:"phase,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	Execution   *models.PlanExecution `json:"execution,omitempty"`
+}
+
+func runSchedulesLocally(cfg *config.PlanScheduleRunLocal, out, log io.Writer) error {
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	historyDir := cfg.HistoryDir
+	if historyDir == "" {
+		var err error
+		if historyDir, err = defaultScheduleHistoryDir(); err != nil {
+			return err
+		}
+	}
+	st, err := newScheduleStore()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(log, "Firing the plan schedules of %s, recording runs in %s\n", st, historyDir)
+
+	var (
+		mu      sync.Mutex
+		running = map[string]bool{}
+		wg      sync.WaitGroup
+	)
+	minute := time.Now().Truncate(time.Minute)
+	for {
+		minute = minute.Add(time.Minute)
+		select {
+		case <-time.After(time.Until(minute)):
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		}
+
+		schedules, err := st.list()
+		if err != nil {
+			fmt.Fprintf(log, "Warning: %v\n", err)
+			continue
+		}
+		for _, s := range schedules {
+			if s.Paused {
+				continue
+			}
+			cron, err := parseCron(s.Cron)
+			if err != nil {
+				fmt.Fprintf(log, "Warning: schedule %q: %v\n", s.Name, err)
+				continue
+			}
+			if !cron.matches(minute) {
+				continue
+			}
+			mu.Lock()
+			if running[s.Name] {
+				mu.Unlock()
+				fmt.Fprintf(log, "Warning: skipping schedule %q, its previous run is still going\n", s.Name)
+				continue
+			}
+			running[s.Name] = true
+			mu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rec := fireSchedule(ctx, cfg, s, minute)
+				mu.Lock()
+				delete(running, s.Name)
+				mu.Unlock()
+
+				if err := writeScheduleRun(historyDir, rec); err != nil {
+					fmt.Fprintf(log, "Warning: recording run of schedule %q: %v\n", s.Name, err)
+				}
+				status := rec.Phase
+				if rec.Error != "" {
+					status += " (" + rec.Error + ")"
+				}
+				fmt.Fprintf(out, "%s %s: execution %s %s\n", rec.FiredAt, s.Name, rec.ExecutionID, status)
+			}()
+		}
+	}
+}
+
+// fireSchedule runs a plan schedule as 'signadot plan run' does and waits
+// for the execution to finish.
+func fireSchedule(ctx context.Context, cfg *config.PlanScheduleRunLocal, s *planSchedule, at time.Time) *scheduleRun {
+	rec := &scheduleRun{Schedule: s.Name, FiredAt: at.Format(time.RFC3339)}
+	runCfg := &config.PlanRun{
+		Plan:         cfg.Plan,
+		Tag:          s.Tag,
+		Cluster:      s.Cluster,
+		Sandbox:      s.Sandbox,
+		RouteGroup:   s.RouteGroup,
+		Params:       mapTemplateVals(s.Params),
+		Secrets:      mapTemplateVals(s.Secrets),
+		PlanExecWait: config.PlanExecWait{Wait: true},
+	}
+	var args []string
+	if s.PlanID != "" {
+		args = []string{s.PlanID}
+	}
+
+	plan, params, secrets, err := prepareRun(ctx, runCfg, args)
+	if err != nil {
+		rec.Error = err.Error()
+		return rec
+	}
+	created, err := createExecution(ctx, runCfg, plan.ID, params, secrets)
+	if err != nil {
+		rec.Error = err.Error()
+		return rec
+	}
+	rec.ExecutionID = created.ID
+	exec, err := planexec.WaitExecution(ctx, runCfg.API, &runCfg.PlanExecWait, nil, created)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			rec.Error = "stopped waiting for the execution: run-local was interrupted"
+		} else {
+			rec.Error = err.Error()
+		}
+		rec.Execution = created
+		return rec
+	}
+	rec.Execution = exec
+	rec.Phase = string(exec.Status.Phase)
+	rec.Error = exec.Status.Error
+	return rec
+}
+
+// mapTemplateVals turns params kept by name back into flag values, sorted
+// by name.
+func mapTemplateVals(m map[string]string) config.TemplateVals {
+	var tvs config.TemplateVals
+	for k, v := range m {
+		tvs = append(tvs, config.TemplateVal{Var: k, Val: v})
+	}
+	sort.Slice(tvs, func(i, j int) bool { return tvs[i].Var < tvs[j].Var })
+	return tvs
+}
+
+func writeScheduleRun(historyDir string, rec *scheduleRun) error {
+	dir := filepath.Join(historyDir, rec.Schedule)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	at, err := time.Parse(time.RFC3339, rec.FiredAt)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, at.UTC().Format("20060102T150405Z")+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return print.RawJSON(f, rec)
+}
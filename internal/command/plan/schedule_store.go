@@ -0,0 +1,213 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/signadot/cli/internal/utils/system"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const (
+	planSchedulesFile      = "plan-schedules.yaml"
+	planScheduleHistoryDir = "plan-schedule-history"
+)
+
+// planSchedule is a recurring run of a plan. Params and secret bindings are
+// kept as given on the command line, to be resolved when the run fires.
+type planSchedule struct {
+	Name       string            `json:"name"`
+	Tag        string            `json:"tag,omitempty"`
+	PlanID     string            `json:"planID,omitempty"`
+	Cron       string            `json:"cron"`
+	Cluster    string            `json:"cluster,omitempty"`
+	Sandbox    string            `json:"sandbox,omitempty"`
+	RouteGroup string            `json:"routeGroup,omitempty"`
+	Params     map[string]string `json:"params,omitempty"`
+	Secrets    map[string]string `json:"secrets,omitempty"`
+	Paused     bool              `json:"paused,omitempty"`
+	CreatedAt  string            `json:"createdAt"`
+}
+
+type planScheduleFile struct {
+	Schedules []*planSchedule `json:"schedules"`
+}
+
+// scheduleStore holds the plan schedules. There is no server API for plan
+// schedules yet, so they are kept locally by fileScheduleStore, and fired by
+// 'plan schedule run-local'.
+type scheduleStore interface {
+	// list returns the schedules, sorted by name.
+	list() ([]*planSchedule, error)
+	// get returns the schedule with the given name, or an error if there
+	// is none.
+	get(name string) (*planSchedule, error)
+	// create adds a schedule, failing if one with the same name exists.
+	create(s *planSchedule) error
+	// update replaces the schedule with the same name.
+	update(s *planSchedule) error
+	// remove deletes the schedule with the given name.
+	remove(name string) error
+	// String describes where the schedules are kept.
+	String() string
+}
+
+// errScheduleExists is returned when creating a schedule whose name is taken.
+var errScheduleExists = errors.New("plan schedule already exists")
+
+func newScheduleStore() (scheduleStore, error) {
+	signadotDir, err := system.GetSignadotDir()
+	if err != nil {
+		return nil, err
+	}
+	return &fileScheduleStore{path: filepath.Join(signadotDir, planSchedulesFile)}, nil
+}
+
+// fileScheduleStore keeps the schedules in a file of the signadot directory.
+type fileScheduleStore struct {
+	path string
+}
+
+var _ scheduleStore = &fileScheduleStore{}
+
+func (st *fileScheduleStore) String() string {
+	return st.path
+}
+
+func (st *fileScheduleStore) list() ([]*planSchedule, error) {
+	d, err := os.ReadFile(st.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f planScheduleFile
+	if err := k8syaml.Unmarshal(d, &f); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", st.path, err)
+	}
+	sort.Slice(f.Schedules, func(i, j int) bool {
+		return f.Schedules[i].Name < f.Schedules[j].Name
+	})
+	return f.Schedules, nil
+}
+
+func (st *fileScheduleStore) get(name string) (*planSchedule, error) {
+	schedules, err := st.list()
+	if err != nil {
+		return nil, err
+	}
+	if i := findSchedule(schedules, name); i >= 0 {
+		return schedules[i], nil
+	}
+	return nil, fmt.Errorf("no plan schedule named %q", name)
+}
+
+func (st *fileScheduleStore) create(s *planSchedule) error {
+	return st.modify(func(schedules []*planSchedule) ([]*planSchedule, error) {
+		if findSchedule(schedules, s.Name) >= 0 {
+			return nil, fmt.Errorf("%w: %q", errScheduleExists, s.Name)
+		}
+		return append(schedules, s), nil
+	})
+}
+
+func (st *fileScheduleStore) update(s *planSchedule) error {
+	return st.modify(func(schedules []*planSchedule) ([]*planSchedule, error) {
+		i := findSchedule(schedules, s.Name)
+		if i < 0 {
+			return nil, fmt.Errorf("no plan schedule named %q", s.Name)
+		}
+		schedules[i] = s
+		return schedules, nil
+	})
+}
+
+func (st *fileScheduleStore) remove(name string) error {
+	return st.modify(func(schedules []*planSchedule) ([]*planSchedule, error) {
+		i := findSchedule(schedules, name)
+		if i < 0 {
+			return nil, fmt.Errorf("no plan schedule named %q", name)
+		}
+		return append(schedules[:i], schedules[i+1:]...), nil
+	})
+}
+
+// modify replaces the schedules by the ones fn returns, holding the lock of
+// the file so that concurrent changes aren't lost. The file is left as is if
+// fn returns an error.
+func (st *fileScheduleStore) modify(fn func([]*planSchedule) ([]*planSchedule, error)) error {
+	unlock, err := st.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	schedules, err := st.list()
+	if err != nil {
+		return err
+	}
+	schedules, err = fn(schedules)
+	if err != nil {
+		return err
+	}
+	return st.save(schedules)
+}
+
+// lock takes an exclusive lock on the schedules, as several CLI sessions can
+// change them concurrently. The returned function releases it.
+func (st *fileScheduleStore) lock() (func(), error) {
+	if err := system.CreateDirIfNotExist(filepath.Dir(st.path)); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(st.path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error locking %s: %w", f.Name(), err)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// save writes the schedules, replacing the file atomically so that a
+// running 'plan schedule run-local' never reads a partial file.
+func (st *fileScheduleStore) save(schedules []*planSchedule) error {
+	d, err := k8syaml.Marshal(planScheduleFile{Schedules: schedules})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o755); err != nil {
+		return err
+	}
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, d, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
+
+func findSchedule(schedules []*planSchedule, name string) int {
+	for i, s := range schedules {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func defaultScheduleHistoryDir() (string, error) {
+	signadotDir, err := system.GetSignadotDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(signadotDir, planScheduleHistoryDir), nil
+}
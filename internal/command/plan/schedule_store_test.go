@@ -0,0 +1,51 @@
+package plan
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileScheduleStore(t *testing.T) {
+	t.Parallel()
+	st := &fileScheduleStore{path: filepath.Join(t.TempDir(), planSchedulesFile)}
+
+	schedules, err := st.list()
+	if err != nil || len(schedules) != 0 {
+		t.Fatalf("got %v, %v for a missing file, want no schedules", schedules, err)
+	}
+	for _, name := range []string{"nightly", "hourly"} {
+		if err := st.create(&planSchedule{Name: name, Tag: name, Cron: "@daily"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.create(&planSchedule{Name: "nightly"}); !errors.Is(err, errScheduleExists) {
+		t.Errorf("got error %v creating a duplicate, want %v", err, errScheduleExists)
+	}
+
+	s, err := st.get("nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Paused = true
+	if err := st.update(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.remove("hourly"); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.remove("hourly"); err == nil {
+		t.Error("expected an error removing a missing schedule")
+	}
+
+	schedules, err = st.list()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedules) != 1 || schedules[0].Name != "nightly" || !schedules[0].Paused {
+		t.Errorf("got schedules %+v, want only nightly, paused", schedules)
+	}
+	if _, err := st.get("hourly"); err == nil {
+		t.Error("expected an error getting a removed schedule")
+	}
+}
@@ -18,6 +18,9 @@ func New(plan *config.Plan) *cobra.Command {
 		newList(cfg),
 		newGet(cfg),
 		newCancel(cfg),
+		newRetry(cfg),
+		newCompare(cfg),
+		newWatch(cfg),
 		newOutputs(cfg),
 		newGetOutput(cfg),
 		newLogs(cfg),
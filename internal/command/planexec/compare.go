@@ -0,0 +1,611 @@
+package planexec
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/go-openapi/runtime"
+	"github.com/signadot/cli/internal/command/planshared"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/signadot/cli/internal/textdiff"
+	"github.com/signadot/go-sdk/client"
+	planlogs "github.com/signadot/go-sdk/client/plan_execution_logs"
+	planexecs "github.com/signadot/go-sdk/client/plan_executions"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+// maxLogDiffCells bounds the size (lines of A times lines of B) of the log
+// diffs computed, as their cost is quadratic.
+const maxLogDiffCells = 25_000_000
+
+func newCompare(exec *config.PlanExecution) *cobra.Command {
+	cfg := &config.PlanExecCompare{PlanExecution: exec}
+
+	cmd := &cobra.Command{
+		Use:   "compare EXECUTION_A EXECUTION_B",
+		Short: "Compare two executions of a plan",
+		Long: `Compare two executions of the same plan, or of the plans a tag pointed to
+over time, to find what changed between them: the params they were given,
+the phase of each step, the values of their outputs (artifacts
+by size and SHA-256 digest) and, for each step, the diff of its captured
+logs.
+
+Steps and outputs are aligned by ID. Only differing params, outputs and logs
+are listed.
+
+The durations compared are those of the whole executions, from their creation
+to their completion: step statuses carry no timestamps, so the durations of
+the steps can't be compared.`,
+		Example: `  # What changed between last night's run and tonight's
+  signadot plan x compare EXEC_A EXEC_B
+
+  # Skip downloading logs
+  signadot plan x compare EXEC_A EXEC_B --logs=false -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return compare(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], args[1])
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+// execComparison is the comparison of two executions, A and B.
+type execComparison struct {
+	A       execSummary  `json:"a"`
+	B       execSummary  `json:"b"`
+	Params  []valueDiff  `json:"params"`
+	Steps   []stepDiff   `json:"steps"`
+	Outputs []outputDiff `json:"outputs"`
+	Logs    []logDiff    `json:"logs,omitempty"`
+}
+
+type execSummary struct {
+	ID       string `json:"id"`
+	PlanID   string `json:"planID"`
+	Phase    string `json:"phase"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// valueDiff is a param with different values in A and B. A missing value is
+// empty.
+type valueDiff struct {
+	Name string `json:"name"`
+	A    string `json:"a,omitempty"`
+	B    string `json:"b,omitempty"`
+}
+
+type stepDiff struct {
+	Step   string `json:"step"`
+	PhaseA string `json:"phaseA,omitempty"`
+	PhaseB string `json:"phaseB,omitempty"`
+}
+
+type outputDiff struct {
+	Step string       `json:"step,omitempty"`
+	Name string       `json:"name"`
+	A    *outputValue `json:"a,omitempty"`
+	B    *outputValue `json:"b,omitempty"`
+}
+
+// outputValue describes the value of an output: inline values by their
+// display form, artifacts by size and digest.
+type outputValue struct {
+	Value  string `json:"value,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+
+	// raw is the JSON encoding of an inline value, to compare values which
+	// display alike once truncated.
+	raw string
+}
+
+type logDiff struct {
+	Step   string `json:"step"`
+	Stream string `json:"stream"`
+	Diff   string `json:"diff,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+func compare(cfg *config.PlanExecCompare, out, log io.Writer, idA, idB string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	a, err := getExecution(cfg.API, idA)
+	if err != nil {
+		return err
+	}
+	b, err := getExecution(cfg.API, idB)
+	if err != nil {
+		return err
+	}
+	if planID(a) != planID(b) {
+		fmt.Fprintf(log, "Warning: the executions are of different plans (%s and %s); steps are aligned by ID.\n",
+			planID(a), planID(b))
+	}
+
+	cmp := &execComparison{
+		A:     summarizeExec(a),
+		B:     summarizeExec(b),
+		Steps: compareSteps(stepStatuses(a), stepStatuses(b)),
+	}
+	var paramsA, paramsB any
+	var secretsA, secretsB map[string]string
+	if a.Spec != nil {
+		paramsA, secretsA = a.Spec.Params, a.Spec.Secrets
+	}
+	if b.Spec != nil {
+		paramsB, secretsB = b.Spec.Params, b.Spec.Secrets
+	}
+	cmp.Params = compareParams(planshared.ParamsAsMap(paramsA), planshared.ParamsAsMap(paramsB), secretsA, secretsB)
+	cmp.Outputs, err = compareOutputs(cfg.API, a, b)
+	if err != nil {
+		return err
+	}
+	if cfg.Logs {
+		cmp.Logs, err = compareLogs(cfg.API, a, b)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		return printComparison(out, cmp)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, cmp)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, cmp)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+func getExecution(api *config.API, execID string) (*models.PlanExecution, error) {
+	params := planexecs.NewGetPlanExecutionParams().
+		WithOrgName(api.Org).
+		WithExecutionID(execID)
+	resp, err := api.Client.PlanExecutions.GetPlanExecution(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execution %s: %w", execID, err)
+	}
+	return resp.Payload, nil
+}
+
+func planID(ex *models.PlanExecution) string {
+	if ex.Spec == nil {
+		return ""
+	}
+	return ex.Spec.PlanID
+}
+
+func stepStatuses(ex *models.PlanExecution) []*models.PlanStepStatus {
+	if ex.Status == nil {
+		return nil
+	}
+	return ex.Status.Steps
+}
+
+func summarizeExec(ex *models.PlanExecution) execSummary {
+	s := execSummary{ID: ex.ID, PlanID: planID(ex)}
+	if ex.Status != nil {
+		s.Phase = string(ex.Status.Phase)
+		if d := report.Between(ex.Status.CreatedAt, ex.Status.CompletedAt); d > 0 {
+			s.Duration = d.String()
+		}
+	}
+	return s
+}
+
+// compareParams returns the params (or secret bindings) whose values differ
+// between A and B, sorted by name.
+func compareParams(a, b map[string]any, secretsA, secretsB map[string]string) []valueDiff {
+	values := func(params map[string]any, secrets map[string]string) map[string]string {
+		m := make(map[string]string, len(params)+len(secrets))
+		for k, v := range params {
+			d, err := json.Marshal(v)
+			if err != nil {
+				d = []byte(fmt.Sprint(v))
+			}
+			m[k] = string(d)
+		}
+		for k, v := range secrets {
+			m[k] = "(secret " + v + ")"
+		}
+		return m
+	}
+	va, vb := values(a, secretsA), values(b, secretsB)
+
+	display := func(params map[string]any, secrets map[string]string, name string) string {
+		if s, ok := secrets[name]; ok {
+			return "(secret " + s + ")"
+		}
+		if v, ok := params[name]; ok {
+			return planshared.FormatValue(v)
+		}
+		return ""
+	}
+	var diffs []valueDiff
+	for _, name := range unionKeys(va, vb) {
+		x, okA := va[name]
+		y, okB := vb[name]
+		if okA == okB && x == y {
+			continue
+		}
+		diffs = append(diffs, valueDiff{
+			Name: name,
+			A:    display(a, secretsA, name),
+			B:    display(b, secretsB, name),
+		})
+	}
+	return diffs
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// compareSteps aligns the steps of A and B by ID, in the order of A then of
+// the steps only in B.
+func compareSteps(a, b []*models.PlanStepStatus) []stepDiff {
+	byID := map[string]*models.PlanStepStatus{}
+	for _, s := range b {
+		if s != nil {
+			byID[s.ID] = s
+		}
+	}
+	var diffs []stepDiff
+	seen := map[string]bool{}
+	add := func(sa, sb *models.PlanStepStatus) {
+		var d stepDiff
+		if sa != nil {
+			d.Step = sa.ID
+			d.PhaseA = string(sa.Phase)
+		}
+		if sb != nil {
+			d.Step = sb.ID
+			d.PhaseB = string(sb.Phase)
+		}
+		diffs = append(diffs, d)
+	}
+	for _, s := range a {
+		if s == nil {
+			continue
+		}
+		seen[s.ID] = true
+		add(s, byID[s.ID])
+	}
+	for _, s := range b {
+		if s != nil && !seen[s.ID] {
+			add(nil, s)
+		}
+	}
+	return diffs
+}
+
+// outputKey identifies an output across executions.
+type outputKey struct {
+	step, name string
+}
+
+// compareOutputs returns the outputs whose values differ between A and B.
+// Ready artifacts are downloaded to compare their digests.
+func compareOutputs(api *config.API, a, b *models.PlanExecution) ([]outputDiff, error) {
+	va, err := outputValues(api, a)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := outputValues(api, b)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]outputKey, 0, len(va)+len(vb))
+	for k := range va {
+		keys = append(keys, k)
+	}
+	for k := range vb {
+		if _, ok := va[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].step != keys[j].step {
+			return keys[i].step < keys[j].step
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	var diffs []outputDiff
+	for _, k := range keys {
+		x, y := va[k], vb[k]
+		if x != nil && y != nil && *x == *y {
+			continue
+		}
+		diffs = append(diffs, outputDiff{Step: k.step, Name: k.name, A: x, B: y})
+	}
+	return diffs, nil
+}
+
+// outputValues returns the values of the plan-level outputs (with no step)
+// and step-level outputs of an execution.
+func outputValues(api *config.API, ex *models.PlanExecution) (map[outputKey]*outputValue, error) {
+	values := map[outputKey]*outputValue{}
+	if ex.Status == nil {
+		return values, nil
+	}
+	for _, o := range ex.Status.Outputs {
+		if o == nil {
+			continue
+		}
+		v, err := outputValueOf(api, ex.ID, "", o.Name, o.Value, o.Artifact)
+		if err != nil {
+			return nil, err
+		}
+		values[outputKey{name: o.Name}] = v
+	}
+	for _, s := range ex.Status.Steps {
+		for _, o := range s.Outputs {
+			if o == nil {
+				continue
+			}
+			v, err := outputValueOf(api, ex.ID, s.ID, o.Name, o.Value, o.Artifact)
+			if err != nil {
+				return nil, err
+			}
+			values[outputKey{step: s.ID, name: o.Name}] = v
+		}
+	}
+	return values, nil
+}
+
+func outputValueOf(api *config.API, execID, stepID, name string, value any, art *models.PlanArtifactRef) (*outputValue, error) {
+	if art == nil {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			raw = []byte(fmt.Sprint(value))
+		}
+		return &outputValue{Value: planshared.FormatValue(value), raw: string(raw)}, nil
+	}
+	v := &outputValue{Size: art.Size}
+	if !art.Ready {
+		v.Value = "(not ready)"
+		return v, nil
+	}
+	sum, err := artifactDigest(api, execID, stepID, name)
+	if err != nil {
+		return nil, err
+	}
+	v.SHA256 = sum
+	return v, nil
+}
+
+// artifactDigest downloads an artifact output and returns its SHA-256
+// digest, in hex.
+func artifactDigest(api *config.API, execID, stepID, name string) (string, error) {
+	if stepID != "" {
+		name = stepID + "/" + name
+	}
+	h := sha256.New()
+	if err := fetchOutput(context.Background(), api, execID, name, h); err != nil {
+		return "", fmt.Errorf("downloading output %q of execution %s: %w", name, execID, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// compareLogs returns the diffs of the captured logs of the steps of A and B
+// which differ.
+func compareLogs(api *config.API, a, b *models.PlanExecution) ([]logDiff, error) {
+	logsA, err := capturedLogs(api, a)
+	if err != nil {
+		return nil, err
+	}
+	logsB, err := capturedLogs(api, b)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]outputKey, 0, len(logsA)+len(logsB))
+	for k := range logsA {
+		keys = append(keys, k)
+	}
+	for k := range logsB {
+		if _, ok := logsA[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].step != keys[j].step {
+			return keys[i].step < keys[j].step
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	var diffs []logDiff
+	for _, k := range keys {
+		x, y := logsA[k], logsB[k]
+		if x == y {
+			continue
+		}
+		d := logDiff{Step: k.step, Stream: k.name}
+		la, lb := textdiff.Split(x), textdiff.Split(y)
+		if len(la)*len(lb) > maxLogDiffCells {
+			d.Note = fmt.Sprintf("logs differ (%d and %d lines), too large to diff", len(la), len(lb))
+		} else {
+			var buf bytes.Buffer
+			textdiff.PrintHunks(&buf, textdiff.Lines(la, lb), 3)
+			d.Diff = buf.String()
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// capturedLogs downloads the ready captured logs of the steps of an
+// execution, keyed by step and stream.
+func capturedLogs(api *config.API, ex *models.PlanExecution) (map[outputKey]string, error) {
+	logs := map[outputKey]string{}
+	if ex.Status == nil {
+		return logs, nil
+	}
+	transportCfg := api.GetBaseTransport()
+	transportCfg.OverrideConsumers = true
+	transportCfg.Consumers = map[string]runtime.Consumer{
+		"*/*": runtime.ByteStreamConsumer(),
+	}
+
+	err := api.APIClientWithCustomTransport(transportCfg,
+		func(c *client.SignadotAPI) error {
+			for _, s := range ex.Status.Steps {
+				for _, l := range s.Logs {
+					if l.Artifact != nil && !l.Artifact.Ready {
+						continue
+					}
+					var buf bytes.Buffer
+					params := planlogs.NewDownloadStepLogParams().
+						WithTimeout(4 * time.Minute).
+						WithOrgName(api.Org).
+						WithExecutionID(ex.ID).
+						WithStepID(s.ID).
+						WithStream(string(l.Stream))
+					if _, _, err := c.PlanExecutionLogs.DownloadStepLog(params, nil, &buf); err != nil {
+						return fmt.Errorf("downloading %s log of step %q of execution %s: %w", l.Stream, s.ID, ex.ID, err)
+					}
+					logs[outputKey{step: s.ID, name: string(l.Stream)}] = buf.String()
+				}
+			}
+			return nil
+		})
+	return logs, err
+}
+
+type paramDiffRow struct {
+	Param string `sdtab:"PARAM"`
+	A     string `sdtab:"A"`
+	B     string `sdtab:"B"`
+}
+
+type stepDiffRow struct {
+	Step   string `sdtab:"STEP"`
+	PhaseA string `sdtab:"PHASE A"`
+	PhaseB string `sdtab:"PHASE B"`
+}
+
+type outputDiffRow struct {
+	Output string `sdtab:"OUTPUT"`
+	A      string `sdtab:"A"`
+	B      string `sdtab:"B"`
+}
+
+func printComparison(out io.Writer, cmp *execComparison) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "\tA\tB\n")
+	fmt.Fprintf(tw, "ID:\t%s\t%s\n", cmp.A.ID, cmp.B.ID)
+	fmt.Fprintf(tw, "Plan:\t%s\t%s\n", cmp.A.PlanID, cmp.B.PlanID)
+	fmt.Fprintf(tw, "Phase:\t%s\t%s\n", cmp.A.Phase, cmp.B.Phase)
+	fmt.Fprintf(tw, "Duration:\t%s\t%s\n", orDash(cmp.A.Duration), orDash(cmp.B.Duration))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(cmp.Params) == 0 {
+		fmt.Fprintln(out, "Params: no differences")
+	} else {
+		fmt.Fprintln(out, "Params:")
+		t := sdtab.New[paramDiffRow](out)
+		t.AddHeader()
+		for _, p := range cmp.Params {
+			t.AddRow(paramDiffRow{Param: p.Name, A: orDash(p.A), B: orDash(p.B)})
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Steps:")
+	st := sdtab.New[stepDiffRow](out)
+	st.AddHeader()
+	for _, s := range cmp.Steps {
+		st.AddRow(stepDiffRow{
+			Step:   s.Step,
+			PhaseA: orDash(s.PhaseA),
+			PhaseB: orDash(s.PhaseB),
+		})
+	}
+	if err := st.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(cmp.Outputs) == 0 {
+		fmt.Fprintln(out, "Outputs: no differences")
+	} else {
+		fmt.Fprintln(out, "Outputs:")
+		t := sdtab.New[outputDiffRow](out)
+		t.AddHeader()
+		for _, o := range cmp.Outputs {
+			name := o.Name
+			if o.Step != "" {
+				name = o.Step + "/" + o.Name
+			}
+			t.AddRow(outputDiffRow{Output: name, A: formatOutputValue(o.A), B: formatOutputValue(o.B)})
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range cmp.Logs {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Logs of step %s (%s):\n", l.Step, l.Stream)
+		if l.Note != "" {
+			fmt.Fprintf(out, "  %s\n", l.Note)
+			continue
+		}
+		fmt.Fprintf(out, "--- %s\n+++ %s\n", cmp.A.ID, cmp.B.ID)
+		fmt.Fprint(out, l.Diff)
+	}
+	return nil
+}
+
+func formatOutputValue(v *outputValue) string {
+	switch {
+	case v == nil:
+		return "-"
+	case v.SHA256 != "":
+		return fmt.Sprintf("%s, sha256:%s", units.HumanSize(float64(v.Size)), v.SHA256[:12])
+	default:
+		return orDash(v.Value)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
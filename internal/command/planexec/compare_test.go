@@ -0,0 +1,22 @@
+package planexec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareParams(t *testing.T) {
+	t.Parallel()
+	a := map[string]any{"env": "staging", "replicas": 2.0, "same": "x", "empty": ""}
+	b := map[string]any{"env": "prod", "replicas": 2.0, "same": "x", "extra": true}
+	got := compareParams(a, b, map[string]string{"token": "tok-a"}, map[string]string{"token": "tok-b"})
+	want := []valueDiff{
+		{Name: "empty", A: `""`},
+		{Name: "env", A: "staging", B: "prod"},
+		{Name: "extra", B: "true"},
+		{Name: "token", A: "(secret tok-a)", B: "(secret tok-b)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,389 @@
+package planexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/signadot/cli/internal/config"
+	sdkprint "github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/cli/internal/spinner"
+	sdkclient "github.com/signadot/go-sdk/client"
+	planlogs "github.com/signadot/go-sdk/client/plan_execution_logs"
+	planexecs "github.com/signadot/go-sdk/client/plan_executions"
+	"github.com/signadot/go-sdk/models"
+)
+
+// followConfig is the configuration needed to follow an execution.
+type followConfig struct {
+	*config.API
+	*config.PlanExecWait
+}
+
+// FollowExecution waits for a newly created execution as requested by the
+// --wait, --attach, --timeout, --output-dir and --report flags, prints its
+// result and exits with the code of its phase: 0 = completed, 1 = failed,
+// 2 = cancelled. On interrupt or timeout, the execution is cancelled.
+func FollowExecution(ctx context.Context, api *config.API, wait *config.PlanExecWait, out, log io.Writer,
+	created *models.PlanExecution, planSpec *models.PlanSpec) error {
+	cfg := &followConfig{API: api, PlanExecWait: wait}
+	execID := created.ID
+	var planID string
+	if created.Spec != nil {
+		planID = created.Spec.PlanID
+	}
+
+	// Fire-and-forget mode.
+	if !cfg.Wait {
+		return writeRunOutput(cfg, out, created, planSpec)
+	}
+
+	// Wait for completion: attach streams structured events, otherwise poll with spinner.
+	var (
+		exec *models.PlanExecution
+		err  error
+	)
+	if cfg.Attach {
+		aw := sdkprint.NewAttachWriter(out, cfg.OutputFormat == config.OutputFormatJSON)
+		exec, err = attachExecution(ctx, cfg, aw, execID, planID)
+	} else {
+		exec, err = pollExecution(ctx, cfg, log, execID)
+	}
+	if err != nil {
+		// On interrupt or timeout, try to cancel the execution.
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintf(log, "\nCancelling execution %s...\n", execID)
+			CancelExecution(api, execID)
+			os.Exit(2)
+		}
+		return err
+	}
+
+	// Export outputs if --output-dir specified.
+	if cfg.OutputDir != "" {
+		if err := ExportOutputs(api, cfg.OutputDir, log, exec); err != nil {
+			fmt.Fprintf(log, "Warning: output export failed: %v\n", err)
+		}
+	}
+
+	// Write test reports if --report specified.
+	if len(cfg.Reports) > 0 {
+		suite := ExecutionSuite(api, exec, planSpec, planID)
+		if err := report.Write(cfg.Reports, suite); err != nil {
+			fmt.Fprintf(log, "Warning: %v\n", err)
+		}
+	}
+
+	// In attach mode, events were already emitted to stdout. Just exit.
+	if cfg.Attach {
+		switch exec.Status.Phase {
+		case models.PlansExecutionPhaseFailed:
+			os.Exit(1)
+		case models.PlansExecutionPhaseCancelled:
+			os.Exit(2)
+		}
+		return nil
+	}
+
+	// Print result and exit with appropriate code.
+	// On failure/cancellation, write details to stderr so stdout stays clean.
+	switch exec.Status.Phase {
+	case models.PlansExecutionPhaseFailed:
+		if err := writeRunOutput(cfg, log, exec, planSpec); err != nil {
+			fmt.Fprintf(log, "error rendering output: %v\n", err)
+		}
+		os.Exit(1)
+	case models.PlansExecutionPhaseCancelled:
+		if err := writeRunOutput(cfg, log, exec, planSpec); err != nil {
+			fmt.Fprintf(log, "error rendering output: %v\n", err)
+		}
+		os.Exit(2)
+	default:
+		return writeRunOutput(cfg, out, exec, planSpec)
+	}
+	return nil
+}
+
+// WaitExecution waits for an execution to finish, streaming its events to
+// aw with --attach, silently otherwise.
+func WaitExecution(ctx context.Context, api *config.API, wait *config.PlanExecWait, aw *sdkprint.AttachWriter,
+	created *models.PlanExecution) (*models.PlanExecution, error) {
+	cfg := &followConfig{API: api, PlanExecWait: wait}
+	if cfg.Attach {
+		var planID string
+		if created.Spec != nil {
+			planID = created.Spec.PlanID
+		}
+		return attachExecution(ctx, cfg, aw, created.ID, planID)
+	}
+	return pollExecution(ctx, cfg, io.Discard, created.ID)
+}
+
+// CancelExecution makes a best effort to cancel an execution, for when
+// waiting for it is interrupted.
+func CancelExecution(api *config.API, execID string) {
+	cancelCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cancelParams := planexecs.NewCancelPlanExecutionParams().
+		WithContext(cancelCtx).
+		WithOrgName(api.Org).
+		WithExecutionID(execID)
+	api.Client.PlanExecutions.CancelPlanExecution(cancelParams, nil)
+}
+
+// buildOutputEvent translates a plan-level PlanOutputStatus into the
+// AttachEvent shape so an --attach text/JSON consumer can tell inline
+// outputs (Kind=inline, Value set) from artifact outputs (Kind=artifact,
+// Size/Ready/ContentType set) instead of seeing value=<nil> for the
+// latter.
+func buildOutputEvent(o *models.PlanOutputStatus) sdkprint.AttachEvent {
+	evt := sdkprint.AttachEvent{
+		Type: "output",
+		Name: o.Name,
+	}
+	if o.StepRef != nil {
+		evt.Step = o.StepRef.StepID
+	}
+	if o.Artifact != nil {
+		evt.Kind = "artifact"
+		evt.Size = o.Artifact.Size
+		ready := o.Artifact.Ready
+		evt.Ready = &ready
+		if ct := o.Metadata["contentType"]; ct != "" {
+			evt.ContentType = ct
+		}
+		evt.Error = o.Artifact.Error
+	} else {
+		evt.Kind = "inline"
+		evt.Value = o.Value
+	}
+	return evt
+}
+
+func pollExecution(ctx context.Context, cfg *followConfig, log io.Writer, execID string) (*models.PlanExecution, error) {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	spinWriter := log
+	if cfg.OutputFormat != config.OutputFormatDefault {
+		spinWriter = io.Discard
+	}
+	spin := spinner.Start(spinWriter, "Execution")
+	defer spin.Stop()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		params := planexecs.NewGetPlanExecutionParams().
+			WithContext(ctx).
+			WithOrgName(cfg.Org).
+			WithExecutionID(execID)
+		resp, err := cfg.Client.PlanExecutions.GetPlanExecution(params, nil)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				spin.StopFail()
+				return nil, err
+			}
+			spin.Messagef("error: %v", err)
+		} else {
+			ex := resp.Payload
+			if IsTerminalPhase(ex.Status.Phase) {
+				switch ex.Status.Phase {
+				case models.PlansExecutionPhaseCompleted:
+					spin.StopMessage(string(ex.Status.Phase))
+				default:
+					spin.StopFail()
+				}
+				return ex, nil
+			}
+			msg := string(ex.Status.Phase)
+			if sc := ex.Status.StepCounts; sc != nil {
+				total := sc.Init + sc.Waiting + sc.Running + sc.Completed + sc.Failed + sc.Skipped
+				msg = fmt.Sprintf("%s (%d/%d steps completed)", msg, sc.Completed, total)
+			}
+			spin.Message(msg)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			spin.StopFail()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func attachExecution(ctx context.Context, cfg *followConfig, aw *sdkprint.AttachWriter, execID, planID string) (*models.PlanExecution, error) {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	// First event in the stream — same shape as the "Created execution"
+	// stderr banner emitted in non-attach mode, so consumers piping
+	// through jq see a consistent event sequence from creation to result.
+	aw.Emit(sdkprint.AttachEvent{
+		Type:   "created",
+		ID:     execID,
+		PlanID: planID,
+	})
+
+	// Stream aggregated logs in background, emitting structured events.
+	logCtx, logCancel := context.WithCancel(ctx)
+	defer logCancel()
+
+	logDone := make(chan error, 1)
+	go func() {
+		logDone <- streamExecutionLogs(logCtx, cfg.API, aw, execID)
+	}()
+
+	// Poll for terminal phase.
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		params := planexecs.NewGetPlanExecutionParams().
+			WithContext(ctx).
+			WithOrgName(cfg.Org).
+			WithExecutionID(execID)
+		resp, err := cfg.Client.PlanExecutions.GetPlanExecution(params, nil)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				logCancel()
+				<-logDone
+				return nil, err
+			}
+		} else if IsTerminalPhase(resp.Payload.Status.Phase) {
+			logCancel()
+			<-logDone
+
+			ex := resp.Payload
+			// Emit output events for resolved plan-level outputs.
+			if ex.Status != nil {
+				for _, o := range ex.Status.Outputs {
+					aw.Emit(buildOutputEvent(o))
+				}
+			}
+			// Emit result event.
+			resultEvent := sdkprint.AttachEvent{
+				Type:  "result",
+				ID:    ex.ID,
+				Phase: string(ex.Status.Phase),
+			}
+			if ex.Status.Error != "" {
+				resultEvent.Error = ex.Status.Error
+			}
+			aw.Emit(resultEvent)
+
+			return ex, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logCancel()
+			<-logDone
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// streamExecutionLogs streams the aggregated logs of all the steps of an
+// execution as log events to aw, until ctx is done or the stream ends.
+func streamExecutionLogs(ctx context.Context, api *config.API, aw *sdkprint.AttachWriter, execID string) error {
+	transportCfg := api.GetBaseTransport()
+	transportCfg.Consumers = map[string]runtime.Consumer{
+		"text/event-stream": runtime.ByteStreamConsumer(),
+	}
+	return api.APIClientWithCustomTransport(transportCfg,
+		func(c *sdkclient.SignadotAPI) error {
+			reader, writer := io.Pipe()
+			errch := make(chan error, 2)
+
+			go func() {
+				_, err := sdkprint.ParseSSEAttach(reader, aw)
+				if errors.Is(err, io.ErrClosedPipe) {
+					err = nil
+				}
+				reader.Close()
+				errch <- err
+			}()
+
+			go func() {
+				params := planlogs.NewStreamPlanExecutionLogsParams().
+					WithContext(ctx).
+					WithTimeout(0).
+					WithOrgName(api.Org).
+					WithExecutionID(execID)
+				_, err := c.PlanExecutionLogs.StreamPlanExecutionLogs(params, nil, writer)
+				if errors.Is(err, io.ErrClosedPipe) || errors.Is(err, context.Canceled) {
+					err = nil
+				}
+				writer.Close()
+				errch <- err
+			}()
+
+			return errors.Join(<-errch, <-errch)
+		})
+}
+
+func writeRunOutput(cfg *followConfig, out io.Writer, exec *models.PlanExecution, planSpec *models.PlanSpec) error {
+	switch cfg.OutputFormat {
+	case config.OutputFormatJSON:
+		return sdkprint.RawJSON(out, exec)
+	case config.OutputFormatYAML:
+		return sdkprint.RawYAML(out, exec)
+	default:
+		return PrintRunResult(out, exec, planSpec)
+	}
+}
+
+// ExportOutputs downloads the plan-level outputs of an execution to dir.
+func ExportOutputs(cfg *config.API, dir string, log io.Writer, exec *models.PlanExecution) error {
+	if exec.Status == nil || len(exec.Status.Outputs) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	transportCfg := cfg.GetBaseTransport()
+	transportCfg.OverrideConsumers = true
+	transportCfg.Consumers = map[string]runtime.Consumer{
+		"*/*": runtime.ByteStreamConsumer(),
+	}
+
+	return cfg.APIClientWithCustomTransport(transportCfg,
+		func(c *sdkclient.SignadotAPI) error {
+			for _, o := range exec.Status.Outputs {
+				outPath := filepath.Join(dir, o.Name)
+				f, err := os.Create(outPath)
+				if err != nil {
+					return fmt.Errorf("creating %s: %w", outPath, err)
+				}
+				params := planexecs.NewGetPlanExecutionOutputParams().
+					WithOrgName(cfg.Org).
+					WithExecutionID(exec.ID).
+					WithOutputName(o.Name)
+				_, _, err = c.PlanExecutions.GetPlanExecutionOutput(params, nil, f)
+				f.Close()
+				if err != nil {
+					return fmt.Errorf("downloading %q: %w", o.Name, err)
+				}
+				fmt.Fprintf(log, "Exported %s\n", outPath)
+			}
+			return nil
+		})
+}
@@ -1,9 +1,11 @@
 package planexec
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
+	"github.com/signadot/cli/internal/command/planshared"
 	"github.com/signadot/cli/internal/config"
 	"github.com/signadot/cli/internal/print"
 	planexecs "github.com/signadot/go-sdk/client/plan_executions"
@@ -18,12 +20,20 @@ func newGet(exec *config.PlanExecution) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get EXECUTION_ID",
 		Short: "Get plan execution details",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get the details of a plan execution.
+
+With --graph, show the dependency graph of the steps of the plan instead,
+coloured by step phase, as an ASCII tree or, to paste in documents, in the
+Graphviz DOT or Mermaid languages.`,
+		Example: `  signadot plan x get EXECUTION_ID --graph
+  signadot plan x get EXECUTION_ID --graph=dot | dot -Tsvg > exec.svg`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return getExec(cfg, cmd.OutOrStdout(), args[0])
 		},
 	}
 
+	cfg.AddFlags(cmd)
 	return cmd
 }
 
@@ -39,6 +49,13 @@ func getExec(cfg *config.PlanExecGet, out io.Writer, execID string) error {
 		return err
 	}
 
+	if cfg.Graph != config.GraphFormatNone {
+		if cfg.OutputFormat != config.OutputFormatDefault {
+			return errors.New("--graph can't be combined with --output")
+		}
+		return printExecGraph(cfg, out, resp.Payload)
+	}
+
 	switch cfg.OutputFormat {
 	case config.OutputFormatDefault:
 		return printExecDetails(out, resp.Payload, fetchPlanSpec(cfg.API, resp.Payload))
@@ -51,6 +68,18 @@ func getExec(cfg *config.PlanExecGet, out io.Writer, execID string) error {
 	}
 }
 
+func printExecGraph(cfg *config.PlanExecGet, out io.Writer, ex *models.PlanExecution) error {
+	spec := fetchPlanSpec(cfg.API, ex)
+	if spec == nil {
+		return fmt.Errorf("couldn't get the plan of execution %s", ex.ID)
+	}
+	var steps []*models.PlanStepStatus
+	if ex.Status != nil {
+		steps = ex.Status.Steps
+	}
+	return planshared.PrintStepGraph(out, planshared.NewStepGraph(spec).WithPhases(steps), string(cfg.Graph))
+}
+
 // fetchPlanSpec returns the spec of the plan referenced by ex, or nil
 // if the plan can't be fetched (deleted, network error, etc). The
 // detail printer renders without resolved values when the spec is nil.
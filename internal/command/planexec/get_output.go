@@ -1,6 +1,7 @@
 package planexec
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -63,39 +64,43 @@ func getOutput(cfg *config.PlanExecGetOutput, out io.Writer, execID, name string
 		return err
 	}
 
-	transportCfg := cfg.GetBaseTransport()
+	if err := fetchOutput(context.Background(), cfg.API, execID, name, out); err != nil {
+		return fmt.Errorf("downloading output %q: %w", name, err)
+	}
+	return nil
+}
+
+// fetchOutput downloads an output of an execution to w, name being
+// "STEP/NAME" for a step-level output.
+func fetchOutput(ctx context.Context, api *config.API, execID, name string, w io.Writer) error {
+	transportCfg := api.GetBaseTransport()
 	transportCfg.OverrideConsumers = true
 	transportCfg.Consumers = map[string]runtime.Consumer{
 		"*/*": runtime.ByteStreamConsumer(),
 	}
 
-	return cfg.APIClientWithCustomTransport(transportCfg,
+	return api.APIClientWithCustomTransport(transportCfg,
 		func(c *client.SignadotAPI) error {
 			// If name contains '/', treat as step_id/output_name.
 			if stepID, outputName, ok := strings.Cut(name, "/"); ok {
 				params := planexecs.NewGetStepOutputParams().
-					WithTimeout(4*time.Minute).
-					WithOrgName(cfg.Org).
+					WithContext(ctx).
+					WithTimeout(4 * time.Minute).
+					WithOrgName(api.Org).
 					WithExecutionID(execID).
 					WithStepID(stepID).
 					WithOutputName(outputName)
-				_, _, err := c.PlanExecutions.GetStepOutput(params, nil, out)
-				if err != nil {
-					return fmt.Errorf("downloading output %q: %w", name, err)
-				}
-				return nil
+				_, _, err := c.PlanExecutions.GetStepOutput(params, nil, w)
+				return err
 			}
-
 			params := planexecs.NewGetPlanExecutionOutputParams().
-				WithTimeout(4*time.Minute).
-				WithOrgName(cfg.Org).
+				WithContext(ctx).
+				WithTimeout(4 * time.Minute).
+				WithOrgName(api.Org).
 				WithExecutionID(execID).
 				WithOutputName(name)
-			_, _, err := c.PlanExecutions.GetPlanExecutionOutput(params, nil, out)
-			if err != nil {
-				return fmt.Errorf("downloading output %q: %w", name, err)
-			}
-			return nil
+			_, _, err := c.PlanExecutions.GetPlanExecutionOutput(params, nil, w)
+			return err
 		})
 }
 
@@ -122,7 +122,7 @@ func listCapturedLogs(cfg *config.PlanExecLogs, out, log io.Writer, execID strin
 // The hint is written to log (stderr) so piped output stays clean.
 func renderCapturedLogs(out, log io.Writer, ex *models.PlanExecution, format config.OutputFormat) error {
 	logs := collectAllLogs(ex)
-	running := ex != nil && ex.Status != nil && !isTerminalPhase(ex.Status.Phase)
+	running := ex != nil && ex.Status != nil && !IsTerminalPhase(ex.Status.Phase)
 
 	switch format {
 	case config.OutputFormatDefault:
@@ -219,7 +219,7 @@ func bulkExportLogs(cfg *config.PlanExecLogs, log io.Writer, execID string) erro
 	}
 
 	// Warn if execution is still running — the export will be a partial snapshot.
-	if !isTerminalPhase(resp.Payload.Status.Phase) {
+	if !IsTerminalPhase(resp.Payload.Status.Phase) {
 		fmt.Fprintf(log, "Warning: execution is still %s; export may be incomplete.\n", resp.Payload.Status.Phase)
 	}
 
@@ -454,10 +454,11 @@ func execTerminalPhase(cfg *config.PlanExecLogs, execID string) (bool, models.Pl
 	if resp.Payload.Status == nil {
 		return false, "", nil
 	}
-	return isTerminalPhase(resp.Payload.Status.Phase), resp.Payload.Status.Phase, nil
+	return IsTerminalPhase(resp.Payload.Status.Phase), resp.Payload.Status.Phase, nil
 }
 
-func isTerminalPhase(phase models.PlansExecutionPhase) bool {
+// IsTerminalPhase reports whether an execution in the given phase is over.
+func IsTerminalPhase(phase models.PlansExecutionPhase) bool {
 	switch phase {
 	case models.PlansExecutionPhaseCompleted,
 		models.PlansExecutionPhaseFailed,
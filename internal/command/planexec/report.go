@@ -0,0 +1,122 @@
+package planexec
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/go-sdk/client"
+	planlogs "github.com/signadot/go-sdk/client/plan_execution_logs"
+	"github.com/signadot/go-sdk/models"
+)
+
+// ExecutionSuite returns the test report of a finished execution, named
+// name: a test case per step of the plan, holding the captured logs of the
+// step as output.
+func ExecutionSuite(api *config.API, exec *models.PlanExecution, planSpec *models.PlanSpec, name string) report.Suite {
+	suite := report.Suite{Name: name}
+	if exec.Status == nil {
+		return suite
+	}
+	suite.Timestamp = report.ParseTime(exec.Status.CreatedAt)
+	suite.Time = report.Between(exec.Status.CreatedAt, exec.Status.CompletedAt)
+	classname := name
+	if exec.Spec != nil && exec.Spec.PlanID != "" {
+		classname = exec.Spec.PlanID
+	}
+
+	seen := map[string]bool{}
+	for _, s := range exec.Status.Steps {
+		if s == nil {
+			continue
+		}
+		seen[s.ID] = true
+		c := stepCase(exec.Status.Phase, s)
+		c.Classname = classname
+		c.Output = stepLogs(api, exec.ID, s)
+		suite.Cases = append(suite.Cases, c)
+	}
+	// Steps the execution never got to have no status.
+	if planSpec != nil {
+		for _, s := range planSpec.Steps {
+			if s == nil || seen[s.ID] {
+				continue
+			}
+			suite.Cases = append(suite.Cases, report.Case{
+				Name:      s.ID,
+				Classname: classname,
+				Status:    report.StatusSkipped,
+				Message:   "not run",
+			})
+		}
+	}
+	return suite
+}
+
+func stepCase(execPhase models.PlansExecutionPhase, s *models.PlanStepStatus) report.Case {
+	c := report.Case{Name: s.ID}
+	switch s.Phase {
+	case models.PlansStepPhaseCompleted:
+		c.Status = report.StatusPassed
+	case models.PlansStepPhaseFailed:
+		c.Status = report.StatusFailed
+		c.Message = s.Error
+		if c.Message == "" {
+			c.Message = "step failed"
+		}
+	case models.PlansStepPhaseSkipped:
+		c.Status = report.StatusSkipped
+		c.Message = "skipped"
+	default:
+		if execPhase == models.PlansExecutionPhaseCancelled {
+			c.Status = report.StatusSkipped
+			c.Message = fmt.Sprintf("execution cancelled while step was %s", s.Phase)
+		} else {
+			c.Status = report.StatusError
+			c.Message = fmt.Sprintf("step did not finish (%s)", s.Phase)
+		}
+	}
+	return c
+}
+
+// stepLogs downloads the captured logs of a step, each stream under a
+// header when there are several. Logs that can't be downloaded are noted
+// instead, so the report is still written.
+func stepLogs(api *config.API, execID string, s *models.PlanStepStatus) string {
+	if len(s.Logs) == 0 {
+		return ""
+	}
+	transportCfg := api.GetBaseTransport()
+	transportCfg.OverrideConsumers = true
+	transportCfg.Consumers = map[string]runtime.Consumer{
+		"*/*": runtime.ByteStreamConsumer(),
+	}
+
+	var out bytes.Buffer
+	api.APIClientWithCustomTransport(transportCfg,
+		func(c *client.SignadotAPI) error {
+			for _, l := range s.Logs {
+				if len(s.Logs) > 1 {
+					fmt.Fprintf(&out, "--- %s ---\n", l.Stream)
+				}
+				if l.Artifact != nil && !l.Artifact.Ready {
+					fmt.Fprintln(&out, "(log not ready)")
+					continue
+				}
+				params := planlogs.NewDownloadStepLogParams().
+					WithTimeout(time.Minute).
+					WithOrgName(api.Org).
+					WithExecutionID(execID).
+					WithStepID(s.ID).
+					WithStream(string(l.Stream))
+				if _, _, err := c.PlanExecutionLogs.DownloadStepLog(params, nil, &out); err != nil {
+					fmt.Fprintf(&out, "(downloading log: %v)\n", err)
+				}
+			}
+			return nil
+		})
+	return out.String()
+}
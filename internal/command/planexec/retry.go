@@ -0,0 +1,227 @@
+package planexec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/signadot/cli/internal/command/planshared"
+	"github.com/signadot/cli/internal/config"
+	planexecs "github.com/signadot/go-sdk/client/plan_executions"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newRetry(exec *config.PlanExecution) *cobra.Command {
+	cfg := &config.PlanExecRetry{PlanExecution: exec}
+
+	cmd := &cobra.Command{
+		Use:   "retry EXECUTION_ID [--from-step STEP]",
+		Short: "Run a finished plan execution again, passing on the outputs of its completed steps",
+		Long: `Create a new execution of the plan of a finished execution, on the same
+cluster and with the same params and secret bindings.
+
+The captured outputs of the steps which completed (see 'signadot plan x
+outputs') are passed to the new execution as the params the plan declares
+with the same names, unless the retried execution set them already. With
+--from-step, the outputs of the given step and of the steps depending on it
+are not passed on, so that they are computed again.
+
+Executions can't skip steps: every step of the plan runs again, and it is up
+to the plan to use such params to avoid redoing work, for instance in the
+conditions of its steps. Executions don't record the execution they retry
+either: the ID of the retried execution is only printed.
+
+As with 'signadot plan run', use --attach to stream structured events.
+Exit codes: 0 = completed, 1 = failed, 2 = cancelled.`,
+		Example: `  # Run a failed execution again, reusing what its completed steps produced
+  signadot plan x retry EXECUTION_ID
+
+  # Compute the outputs of the deploy step and of the steps after it again
+  signadot plan x retry EXECUTION_ID --from-step deploy --attach`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return retry(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+func retry(cfg *config.PlanExecRetry, out, log io.Writer, execID string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if cfg.Attach && cfg.OutputFormat == config.OutputFormatYAML {
+		return fmt.Errorf("--attach does not support -o yaml; use -o json for structured output")
+	}
+	if err := cfg.PlanExecWait.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+
+	getParams := planexecs.NewGetPlanExecutionParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithExecutionID(execID)
+	resp, err := cfg.Client.PlanExecutions.GetPlanExecution(getParams, nil)
+	if err != nil {
+		return err
+	}
+	parent := resp.Payload
+	if parent.Spec == nil || parent.Status == nil {
+		return fmt.Errorf("execution %s has no spec or status", execID)
+	}
+	if !IsTerminalPhase(parent.Status.Phase) {
+		return fmt.Errorf("execution %s is still %s; wait for it to finish or cancel it first", execID, parent.Status.Phase)
+	}
+	planSpec := fetchPlanSpec(cfg.API, parent)
+	if planSpec == nil {
+		return fmt.Errorf("couldn't get plan %s of execution %s", parent.Spec.PlanID, execID)
+	}
+
+	reused, err := reusedOutputs(planSpec, parent, cfg.FromStep)
+	if err != nil {
+		return err
+	}
+	params := maps.Clone(parent.Spec.Params)
+	if params == nil && len(reused) > 0 {
+		params = make(map[string]any, len(reused))
+	}
+	for _, r := range reused {
+		v := r.Value
+		if r.Artifact {
+			var buf bytes.Buffer
+			if err := fetchOutput(ctx, cfg.API, execID, r.Step+"/"+r.Output, &buf); err != nil {
+				return fmt.Errorf("downloading output %s/%s: %w", r.Step, r.Output, err)
+			}
+			v = strings.TrimSuffix(buf.String(), "\n")
+		}
+		params[r.Param] = v
+	}
+
+	spec := &models.PlanExecutionSpec{
+		PlanID:  parent.Spec.PlanID,
+		Cluster: parent.Spec.Cluster,
+		Params:  params,
+		Secrets: parent.Spec.Secrets,
+	}
+	createParams := planexecs.NewCreatePlanExecutionParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithData(spec)
+	createResp, err := cfg.Client.PlanExecutions.CreatePlanExecution(createParams, nil)
+	if err != nil {
+		return fmt.Errorf("creating execution: %w", err)
+	}
+	if cfg.OutputFormat == config.OutputFormatDefault && !cfg.Attach {
+		fmt.Fprintf(log, "Created execution %s retrying %s\n", createResp.Payload.ID, execID)
+		for _, r := range reused {
+			fmt.Fprintf(log, "  param %s = output %s of step %s\n", r.Param, r.Output, r.Step)
+		}
+	}
+
+	return FollowExecution(ctx, cfg.API, &cfg.PlanExecWait, out, log, createResp.Payload, planSpec)
+}
+
+// reusedOutput is a captured output of a retried execution, passed as a
+// param to the retry.
+type reusedOutput struct {
+	Param    string
+	Step     string
+	Output   string
+	Value    any
+	Artifact bool
+}
+
+// reusedOutputs returns the outputs of the completed steps of an execution
+// which a retry passes on as the params of the same names: the params the
+// plan declares and the execution didn't set. The outputs of fromStep and of
+// the steps depending on it, when fromStep is set, are left out.
+func reusedOutputs(planSpec *models.PlanSpec, parent *models.PlanExecution, fromStep string) ([]reusedOutput, error) {
+	rerun := map[string]bool{}
+	if fromStep != "" {
+		if findStep(planSpec, fromStep) == nil {
+			return nil, fmt.Errorf("the plan has no step %q", fromStep)
+		}
+		rerun = dependentSteps(planshared.NewStepGraph(planSpec), fromStep)
+	}
+
+	// the outputs which can be passed on, by name
+	byName := map[string][]reusedOutput{}
+	for _, s := range parent.Status.Steps {
+		if s == nil || s.Phase != models.PlansStepPhaseCompleted || rerun[s.ID] {
+			continue
+		}
+		for _, o := range s.Outputs {
+			if o == nil {
+				continue
+			}
+			byName[o.Name] = append(byName[o.Name], reusedOutput{
+				Param:    o.Name,
+				Step:     s.ID,
+				Output:   o.Name,
+				Value:    o.Value,
+				Artifact: o.Artifact != nil,
+			})
+		}
+	}
+
+	var res []reusedOutput
+	for _, p := range planSpec.Params {
+		if p == nil {
+			continue
+		}
+		if _, ok := parent.Spec.Params[p.Name]; ok {
+			continue
+		}
+		if _, ok := parent.Spec.Secrets[p.Name]; ok {
+			continue
+		}
+		switch outs := byName[p.Name]; len(outs) {
+		case 0:
+		case 1:
+			res = append(res, outs[0])
+		default:
+			steps := make([]string, 0, len(outs))
+			for _, o := range outs {
+				steps = append(steps, o.Step)
+			}
+			return nil, fmt.Errorf("param %q matches outputs of several steps (%s); use --from-step to compute some of them again",
+				p.Name, strings.Join(steps, ", "))
+		}
+	}
+	return res, nil
+}
+
+// dependentSteps returns the given step along with the steps depending on
+// it, directly or not.
+func dependentSteps(g *planshared.StepGraph, id string) map[string]bool {
+	res := map[string]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for _, s := range g.Steps {
+			if res[s] {
+				continue
+			}
+			for _, d := range g.Deps[s] {
+				if res[d] {
+					res[s] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+package planexec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/signadot/cli/internal/command/planshared"
+)
+
+func TestDependentSteps(t *testing.T) {
+	t.Parallel()
+	g := &planshared.StepGraph{
+		Steps: []string{"checkout", "build", "lint", "deploy", "smoke", "a", "b"},
+		Deps: map[string][]string{
+			"build":  {"checkout"},
+			"lint":   {"checkout"},
+			"deploy": {"build"},
+			"smoke":  {"deploy", "lint"},
+			"a":      {"b"},
+			"b":      {"a"},
+		},
+	}
+	cases := []struct {
+		step string
+		want map[string]bool
+	}{
+		{"build", map[string]bool{"build": true, "deploy": true, "smoke": true}},
+		{"lint", map[string]bool{"lint": true, "smoke": true}},
+		{"smoke", map[string]bool{"smoke": true}},
+		{"a", map[string]bool{"a": true, "b": true}},
+	}
+	for _, c := range cases {
+		if got := dependentSteps(g, c.step); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.step, got, c.want)
+		}
+	}
+}
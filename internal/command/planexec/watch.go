@@ -0,0 +1,135 @@
+package planexec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/signadot/cli/internal/config"
+	sdkprint "github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/tui"
+	planexecs "github.com/signadot/go-sdk/client/plan_executions"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newWatch(exec *config.PlanExecution) *cobra.Command {
+	cfg := &config.PlanExecWatch{PlanExecution: exec}
+
+	cmd := &cobra.Command{
+		Use:   "watch EXECUTION_ID [--dir DIR]",
+		Short: "Watch a plan execution live in a terminal UI",
+		Long: `Open a terminal UI monitoring an execution: its steps with their phases
+and durations, the stdout and stderr of the selected step as they are
+streamed, and the outputs as they are produced.
+
+Step durations are measured from the phase changes seen while watching; the
+steps already running when the watch started show the time since then,
+marked with ≥.
+
+Keys:
+  tab      move between the steps, outputs, stdout and stderr panes
+  ↑/↓      select a step or output, or scroll the logs
+  f        toggle following the end of the logs
+  c        cancel the execution (press twice to confirm)
+  d        download the selected artifact output to --dir
+  q        quit, leaving the execution running`,
+		Example: `  # Watch an execution, downloading artifacts to ./outputs
+  signadot plan x watch EXECUTION_ID --dir ./outputs`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watch(cfg, args[0])
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+func watch(cfg *config.PlanExecWatch, execID string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	// Fail early on an unknown execution rather than in the UI.
+	if _, err := getExecution(cfg.API, execID); err != nil {
+		return err
+	}
+	src := &watchSource{api: cfg.API, execID: execID, dir: cfg.Dir}
+	if err := tui.NewPlanExecWatch(src).Run(); err != nil {
+		return fmt.Errorf("error running plan execution watch: %w", err)
+	}
+	return nil
+}
+
+// watchSource gives the plan execution watch UI access to an execution.
+type watchSource struct {
+	api    *config.API
+	execID string
+	dir    string
+}
+
+func (s *watchSource) ExecutionID() string {
+	return s.execID
+}
+
+func (s *watchSource) Get(ctx context.Context) (*models.PlanExecution, error) {
+	params := planexecs.NewGetPlanExecutionParams().
+		WithContext(ctx).
+		WithOrgName(s.api.Org).
+		WithExecutionID(s.execID)
+	resp, err := s.api.Client.PlanExecutions.GetPlanExecution(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Payload, nil
+}
+
+func (s *watchSource) StreamLogs(ctx context.Context, aw *sdkprint.AttachWriter) error {
+	return streamExecutionLogs(ctx, s.api, aw, s.execID)
+}
+
+func (s *watchSource) Cancel(ctx context.Context) error {
+	params := planexecs.NewCancelPlanExecutionParams().
+		WithContext(ctx).
+		WithOrgName(s.api.Org).
+		WithExecutionID(s.execID)
+	_, err := s.api.Client.PlanExecutions.CancelPlanExecution(params, nil)
+	return err
+}
+
+// Download writes a plan-level output to <dir>/<name> and a step-level one
+// to <dir>/<step>/<name>.
+func (s *watchSource) Download(ctx context.Context, name string) (string, error) {
+	stepID, outputName, isStep := strings.Cut(name, "/")
+	if !isStep {
+		stepID, outputName = "", name
+	}
+	if err := validatePathComponent(outputName); err != nil {
+		return "", err
+	}
+	dir := s.dir
+	if isStep {
+		if err := validatePathComponent(stepID); err != nil {
+			return "", err
+		}
+		dir = filepath.Join(dir, stepID)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	outPath := filepath.Join(dir, outputName)
+	f, err := os.Create(outPath)
+	if err != nil {
+		return "", err
+	}
+
+	err = fetchOutput(ctx, s.api, s.execID, name, f)
+	if err != nil {
+		f.Close()
+		os.Remove(outPath)
+		return "", err
+	}
+	return outPath, f.Close()
+}
@@ -0,0 +1,255 @@
+package planshared
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/signadot/go-sdk/models"
+)
+
+// StepGraph is the dependency graph of the steps of a plan: a step depends
+// on the steps whose outputs its inputs refer to.
+type StepGraph struct {
+	// Steps holds the step IDs, in spec order.
+	Steps []string
+	// Deps holds the steps each step depends on, in spec order.
+	Deps map[string][]string
+	// Phases optionally holds the phase of each step of an execution.
+	Phases map[string]models.PlansStepPhase
+}
+
+// NewStepGraph builds the step graph of a plan spec. Refs to unknown steps
+// are left out.
+func NewStepGraph(spec *models.PlanSpec) *StepGraph {
+	g := &StepGraph{Deps: map[string][]string{}}
+	if spec == nil {
+		return g
+	}
+	order := map[string]int{}
+	for _, s := range spec.Steps {
+		if s == nil {
+			continue
+		}
+		if _, ok := order[s.ID]; ok {
+			continue
+		}
+		order[s.ID] = len(g.Steps)
+		g.Steps = append(g.Steps, s.ID)
+	}
+	for _, s := range spec.Steps {
+		if s == nil || s.Args == nil {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, ref := range s.Args.Refs {
+			r, ok := ParsePlanRef(ref)
+			if !ok || r.Step == "" || seen[r.Step] {
+				continue
+			}
+			if _, ok := order[r.Step]; !ok {
+				continue
+			}
+			seen[r.Step] = true
+			g.Deps[s.ID] = append(g.Deps[s.ID], r.Step)
+		}
+		sort.Slice(g.Deps[s.ID], func(i, j int) bool {
+			return order[g.Deps[s.ID][i]] < order[g.Deps[s.ID][j]]
+		})
+	}
+	return g
+}
+
+// WithPhases records the phases of the steps of an execution, steps which
+// didn't start yet having no phase.
+func (g *StepGraph) WithPhases(steps []*models.PlanStepStatus) *StepGraph {
+	g.Phases = map[string]models.PlansStepPhase{}
+	for _, s := range steps {
+		if s == nil {
+			continue
+		}
+		g.Phases[s.ID] = s.Phase
+		if s.Phase == "" {
+			g.Phases[s.ID] = "pending"
+		}
+	}
+	return g
+}
+
+// children returns the steps depending on each step, in spec order.
+func (g *StepGraph) children() map[string][]string {
+	children := map[string][]string{}
+	for _, id := range g.Steps {
+		for _, d := range g.Deps[id] {
+			children[d] = append(children[d], id)
+		}
+	}
+	return children
+}
+
+// PrintStepGraph renders the graph in the given format: "ascii", "dot" or
+// "mermaid".
+func PrintStepGraph(out io.Writer, g *StepGraph, format string) error {
+	switch format {
+	case "ascii":
+		g.PrintASCII(out)
+	case "dot":
+		g.PrintDOT(out)
+	case "mermaid":
+		g.PrintMermaid(out)
+	default:
+		return fmt.Errorf("unsupported graph format: %q", format)
+	}
+	return nil
+}
+
+// PrintASCII renders the graph as a tree from the steps without
+// dependencies. A step depending on several steps is expanded under the
+// first one and shown as "(see above)" under the others. Steps in
+// dependency cycles, which no root leads to, are listed last.
+func (g *StepGraph) PrintASCII(out io.Writer) {
+	children := g.children()
+	printed := map[string]bool{}
+
+	var walk func(id, prefix, branch string, ancestors map[string]bool)
+	walk = func(id, prefix, branch string, ancestors map[string]bool) {
+		label := g.asciiLabel(id)
+		if ancestors[id] {
+			fmt.Fprintf(out, "%s%s%s (cycle)\n", prefix, branch, label)
+			return
+		}
+		if printed[id] {
+			fmt.Fprintf(out, "%s%s%s (see above)\n", prefix, branch, label)
+			return
+		}
+		printed[id] = true
+		fmt.Fprintf(out, "%s%s%s\n", prefix, branch, label)
+
+		childPrefix := prefix
+		switch branch {
+		case "├── ":
+			childPrefix += "│   "
+		case "└── ":
+			childPrefix += "    "
+		}
+		ancestors[id] = true
+		kids := children[id]
+		for i, c := range kids {
+			b := "├── "
+			if i == len(kids)-1 {
+				b = "└── "
+			}
+			walk(c, childPrefix, b, ancestors)
+		}
+		delete(ancestors, id)
+	}
+
+	for _, id := range g.Steps {
+		if len(g.Deps[id]) == 0 {
+			walk(id, "  ", "", map[string]bool{})
+		}
+	}
+	for _, id := range g.Steps {
+		if !printed[id] {
+			walk(id, "  ", "", map[string]bool{})
+		}
+	}
+}
+
+func (g *StepGraph) asciiLabel(id string) string {
+	phase, ok := g.Phases[id]
+	if !ok {
+		return id
+	}
+	return phaseColor(phase).Sprintf("%s [%s]", id, phase)
+}
+
+func phaseColor(phase models.PlansStepPhase) *color.Color {
+	switch phase {
+	case models.PlansStepPhaseCompleted:
+		return color.New(color.FgGreen)
+	case models.PlansStepPhaseFailed:
+		return color.New(color.FgRed)
+	case models.PlansStepPhaseRunning:
+		return color.New(color.FgYellow)
+	case models.PlansStepPhaseSkipped:
+		return color.New(color.Faint)
+	default:
+		return color.New()
+	}
+}
+
+// phaseFill returns the fill color of a step in DOT and Mermaid graphs.
+func phaseFill(phase models.PlansStepPhase) string {
+	switch phase {
+	case models.PlansStepPhaseCompleted:
+		return "#c8e6c9"
+	case models.PlansStepPhaseFailed:
+		return "#ffcdd2"
+	case models.PlansStepPhaseRunning:
+		return "#fff9c4"
+	case models.PlansStepPhaseSkipped:
+		return "#eeeeee"
+	default:
+		return "#ffffff"
+	}
+}
+
+// PrintDOT renders the graph in the Graphviz DOT language.
+func (g *StepGraph) PrintDOT(out io.Writer) {
+	fmt.Fprintln(out, "digraph plan {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, `  node [shape=box, style="rounded,filled", fillcolor="#ffffff"];`)
+	for _, id := range g.Steps {
+		phase, ok := g.Phases[id]
+		if !ok {
+			fmt.Fprintf(out, "  %s;\n", dotQuote(id))
+			continue
+		}
+		fmt.Fprintf(out, "  %s [label=%s, fillcolor=%s];\n",
+			dotQuote(id), dotQuote(id+"\n"+string(phase)), dotQuote(phaseFill(phase)))
+	}
+	for _, id := range g.Steps {
+		for _, d := range g.Deps[id] {
+			fmt.Fprintf(out, "  %s -> %s;\n", dotQuote(d), dotQuote(id))
+		}
+	}
+	fmt.Fprintln(out, "}")
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// PrintMermaid renders the graph as a Mermaid flowchart.
+func (g *StepGraph) PrintMermaid(out io.Writer) {
+	// Mermaid node IDs are restricted, so steps get positional IDs and their
+	// names as labels.
+	nodeID := map[string]string{}
+	for i, id := range g.Steps {
+		nodeID[id] = fmt.Sprintf("s%d", i)
+	}
+	fmt.Fprintln(out, "flowchart LR")
+	for _, id := range g.Steps {
+		label := id
+		if phase, ok := g.Phases[id]; ok {
+			label += "<br/>" + string(phase)
+		}
+		fmt.Fprintf(out, "  %s[\"%s\"]\n", nodeID[id], strings.ReplaceAll(label, `"`, "#quot;"))
+	}
+	for _, id := range g.Steps {
+		for _, d := range g.Deps[id] {
+			fmt.Fprintf(out, "  %s --> %s\n", nodeID[d], nodeID[id])
+		}
+	}
+	for _, id := range g.Steps {
+		if phase, ok := g.Phases[id]; ok {
+			fmt.Fprintf(out, "  style %s fill:%s\n", nodeID[id], phaseFill(phase))
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package planshared
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStepGraphPrintASCII(t *testing.T) {
+	t.Parallel()
+	g := &StepGraph{
+		Steps: []string{"checkout", "build", "lint", "deploy", "a", "b"},
+		Deps: map[string][]string{
+			"build":  {"checkout"},
+			"lint":   {"checkout"},
+			"deploy": {"build", "lint"},
+			"a":      {"b"},
+			"b":      {"a"},
+		},
+	}
+	var b strings.Builder
+	g.PrintASCII(&b)
+	want := `  checkout
+  ├── build
+  │   └── deploy
+  └── lint
+      └── deploy (see above)
+  a
+  └── b
+      └── a (cycle)
+`
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,26 @@
+package planshared
+
+import "strings"
+
+// PlanRef is a ref parsed from a step input or a plan output: either a plan
+// param or an output of a step.
+type PlanRef struct {
+	Param  string
+	Step   string
+	Output string
+}
+
+// ParsePlanRef parses the "params.NAME" and "steps.ID.outputs.NAME" forms of
+// refs. Other forms are not recognized, callers are expected to skip them
+// rather than report them as invalid.
+func ParsePlanRef(s string) (PlanRef, bool) {
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	switch {
+	case len(parts) == 2 && parts[0] == "params" && parts[1] != "":
+		return PlanRef{Param: parts[1]}, true
+	case len(parts) == 4 && parts[0] == "steps" && parts[2] == "outputs" &&
+		parts[1] != "" && parts[3] != "":
+		return PlanRef{Step: parts[1], Output: parts[3]}, true
+	}
+	return PlanRef{}, false
+}
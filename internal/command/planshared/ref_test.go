@@ -0,0 +1,26 @@
+package planshared
+
+import "testing"
+
+func TestParsePlanRef(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		in   string
+		want PlanRef
+		ok   bool
+	}{
+		{"params.env", PlanRef{Param: "env"}, true},
+		{" params.env ", PlanRef{Param: "env"}, true},
+		{"steps.build.outputs.image", PlanRef{Step: "build", Output: "image"}, true},
+		{"steps.build.image", PlanRef{}, false},
+		{"steps..outputs.image", PlanRef{}, false},
+		{"params.", PlanRef{}, false},
+		{"build", PlanRef{}, false},
+	}
+	for _, c := range cases {
+		got, ok := ParsePlanRef(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("ParsePlanRef(%q) = %+v, %v, want %+v, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
@@ -50,8 +50,11 @@ func applyTag(cfg *config.PlanTagApply, out io.Writer, tagName string) error {
 	if err != nil {
 		return err
 	}
+	return writeTag(out, cfg.OutputFormat, tag)
+}
 
-	switch cfg.OutputFormat {
+func writeTag(out io.Writer, format config.OutputFormat, tag *models.PlanTag) error {
+	switch format {
 	case config.OutputFormatDefault:
 		return printTagDetails(out, tag)
 	case config.OutputFormatJSON:
@@ -59,6 +62,6 @@ func applyTag(cfg *config.PlanTagApply, out io.Writer, tagName string) error {
 	case config.OutputFormatYAML:
 		return print.RawYAML(out, tag)
 	default:
-		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+		return fmt.Errorf("unsupported output format: %q", format)
 	}
 }
@@ -19,6 +19,9 @@ func New(plan *config.Plan) *cobra.Command {
 		newGet(cfg),
 		newApply(cfg),
 		newDelete(cfg),
+		newHistory(cfg),
+		newRollback(cfg),
+		newPromote(cfg),
 	)
 
 	return cmd
@@ -1,12 +1,11 @@
 package plantag
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/signadot/cli/internal/config"
-	"github.com/signadot/cli/internal/print"
 	plantags "github.com/signadot/go-sdk/client/plan_tags"
+	"github.com/signadot/go-sdk/models"
 	"github.com/spf13/cobra"
 )
 
@@ -29,22 +28,21 @@ func getTag(cfg *config.PlanTagGet, out io.Writer, tagName string) error {
 	if err := cfg.InitAPIConfig(); err != nil {
 		return err
 	}
+	tag, err := fetchTag(cfg.Plan, tagName)
+	if err != nil {
+		return err
+	}
+	return writeTag(out, cfg.OutputFormat, tag)
+}
+
+// fetchTag gets a plan tag. Caller must have already called InitAPIConfig.
+func fetchTag(cfg *config.Plan, tagName string) (*models.PlanTag, error) {
 	params := plantags.NewGetPlanTagParams().
 		WithOrgName(cfg.Org).
 		WithPlanTagName(tagName)
 	resp, err := cfg.Client.PlanTags.GetPlanTag(params, nil)
 	if err != nil {
-		return err
-	}
-
-	switch cfg.OutputFormat {
-	case config.OutputFormatDefault:
-		return printTagDetails(out, resp.Payload)
-	case config.OutputFormatJSON:
-		return print.RawJSON(out, resp.Payload)
-	case config.OutputFormatYAML:
-		return print.RawYAML(out, resp.Payload)
-	default:
-		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+		return nil, err
 	}
+	return resp.Payload, nil
 }
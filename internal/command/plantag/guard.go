@@ -0,0 +1,70 @@
+package plantag
+
+import (
+	"fmt"
+
+	"github.com/signadot/cli/internal/command/planexec"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/report"
+	planexecs "github.com/signadot/go-sdk/client/plan_executions"
+	"github.com/signadot/go-sdk/models"
+)
+
+// requirePassingExec checks that the latest finished execution of a plan
+// completed. Caller must have already called InitAPIConfig.
+func requirePassingExec(cfg *config.Plan, planID string) error {
+	latest, err := latestFinishedExec(func(cursor *string) ([]*models.PlanExecutionQueryResult, error) {
+		params := planexecs.NewListPlanExecutionsParams().
+			WithOrgName(cfg.Org).
+			WithPlanID(&planID)
+		if cursor != nil {
+			params.WithCursor(cursor)
+		}
+		resp, err := cfg.Client.PlanExecutions.ListPlanExecutions(params, nil)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Payload, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing executions of plan %s: %w", planID, err)
+	}
+	if latest == nil {
+		return fmt.Errorf("plan %s has no finished execution", planID)
+	}
+	if latest.Status.Phase != models.PlansExecutionPhaseCompleted {
+		return fmt.Errorf("the latest execution of plan %s, %s, %s", planID, latest.ID, latest.Status.Phase)
+	}
+	return nil
+}
+
+// latestFinishedExec returns the latest finished execution among those
+// listPage returns, page by page from the given cursor, or nil if none is.
+// Executions are listed newest first, so pages are only fetched until one
+// holds a finished execution.
+func latestFinishedExec(listPage func(cursor *string) ([]*models.PlanExecutionQueryResult, error)) (*models.PlanExecutionQueryResult, error) {
+	var cursor *string
+	for {
+		page, err := listPage(cursor)
+		if err != nil {
+			return nil, err
+		}
+		var latest *models.PlanExecutionQueryResult
+		for _, r := range page {
+			if r == nil || r.Status == nil || !planexec.IsTerminalPhase(r.Status.Phase) {
+				continue
+			}
+			if latest == nil || report.ParseTime(r.Status.CreatedAt).After(report.ParseTime(latest.Status.CreatedAt)) {
+				latest = r
+			}
+		}
+		if latest != nil || len(page) == 0 {
+			return latest, nil
+		}
+		last := page[len(page)-1]
+		if last == nil || last.Cursor == "" {
+			return nil, nil
+		}
+		cursor = &last.Cursor
+	}
+}
@@ -0,0 +1,66 @@
+package plantag
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/signadot/go-sdk/models"
+)
+
+func TestLatestFinishedExec(t *testing.T) {
+	// pages of executions, newest first
+	var pages [][]*models.PlanExecutionQueryResult
+	if err := json.Unmarshal([]byte(`[
+		[
+			{"id": "x5", "cursor": "c5", "status": {"phase": "running", "createdAt": "2026-01-05T00:00:00Z"}}
+		],
+		[
+			{"id": "x3", "cursor": "c3", "status": {"phase": "completed", "createdAt": "2026-01-03T00:00:00Z"}},
+			{"id": "x4", "cursor": "c4", "status": {"phase": "failed", "createdAt": "2026-01-04T00:00:00Z"}}
+		],
+		[
+			{"id": "x1", "cursor": "c1", "status": {"phase": "completed", "createdAt": "2026-01-01T00:00:00Z"}}
+		]
+	]`), &pages); err != nil {
+		t.Fatal(err)
+	}
+	var fetched int
+	listPage := func(cursor *string) ([]*models.PlanExecutionQueryResult, error) {
+		// each page is fetched from the cursor of the last execution of the
+		// previous one
+		if want := map[int]string{1: "c5", 2: "c4"}[fetched]; want != "" && (cursor == nil || *cursor != want) {
+			t.Errorf("page %d fetched from cursor %v, want %q", fetched, cursor, want)
+		}
+		if fetched >= len(pages) {
+			return nil, nil
+		}
+		fetched++
+		return pages[fetched-1], nil
+	}
+
+	latest, err := latestFinishedExec(listPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest == nil || latest.ID != "x4" {
+		t.Errorf("got %v, want execution x4", latest)
+	}
+	if fetched != 2 {
+		t.Errorf("fetched %d pages, want 2: the pages after the first one with a finished execution aren't needed", fetched)
+	}
+
+	fetched = 0
+	pages = pages[:1]
+	latest, err = latestFinishedExec(listPage)
+	if err != nil || latest != nil {
+		t.Errorf("no finished execution: got %v, %v", latest, err)
+	}
+
+	boom := errors.New("boom")
+	if _, err := latestFinishedExec(func(*string) ([]*models.PlanExecutionQueryResult, error) {
+		return nil, boom
+	}); !errors.Is(err, boom) {
+		t.Errorf("got error %v, want %v", err, boom)
+	}
+}
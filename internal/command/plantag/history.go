@@ -0,0 +1,68 @@
+package plantag
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newHistory(tag *config.PlanTag) *cobra.Command {
+	cfg := &config.PlanTagHistory{PlanTag: tag}
+
+	cmd := &cobra.Command{
+		Use:   "history TAG_NAME",
+		Short: "List the plans a tag pointed at, newest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tagHistory(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+func tagHistory(cfg *config.PlanTagHistory, out, log io.Writer, tagName string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	tag, err := fetchTag(cfg.Plan, tagName)
+	if err != nil {
+		return err
+	}
+	history := sortedHistory(tag.History)
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		if len(history) == 0 {
+			fmt.Fprintf(log, "No history for tag %q.\n", tagName)
+			return nil
+		}
+		return printHistoryTable(out, history)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, history)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, history)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+// sortedHistory returns the mappings of a tag, newest first.
+func sortedHistory(history []*models.TagMapping) []*models.TagMapping {
+	sorted := make([]*models.TagMapping, 0, len(history))
+	for _, h := range history {
+		if h != nil {
+			sorted = append(sorted, h)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return report.ParseTime(sorted[i].TaggedAt).After(report.ParseTime(sorted[j].TaggedAt))
+	})
+	return sorted
+}
@@ -0,0 +1,76 @@
+package plantag
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/signadot/cli/internal/config"
+	plantags "github.com/signadot/go-sdk/client/plan_tags"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newPromote(tag *config.PlanTag) *cobra.Command {
+	cfg := &config.PlanTagPromote{PlanTag: tag}
+
+	cmd := &cobra.Command{
+		Use:   "promote --from TAG_NAME --to TAG_NAME",
+		Short: "Point a plan tag at the plan of another tag",
+		Long: `Point the --to tag at the plan the --from tag points at, creating it if
+needed, e.g. to promote a plan validated under a staging tag to the
+production tag.
+
+With --require-passing-exec, the tag is only moved if the latest finished
+execution of the promoted plan completed.`,
+		Example: `  # Promote the staging plan to prod once its last run passed
+  signadot plan tag promote --from staging-e2e --to prod-e2e --require-passing-exec`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return promoteTag(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+func promoteTag(cfg *config.PlanTagPromote, out, log io.Writer) error {
+	if cfg.From == cfg.To {
+		return fmt.Errorf("--from and --to are the same tag")
+	}
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	from, err := fetchTag(cfg.Plan, cfg.From)
+	if err != nil {
+		return fmt.Errorf("getting tag %q: %w", cfg.From, err)
+	}
+	if from.Spec == nil || from.Spec.PlanID == "" {
+		return fmt.Errorf("tag %q has no plan", cfg.From)
+	}
+	planID := from.Spec.PlanID
+
+	// The --to tag may not exist yet: look it up in the list rather than
+	// getting it.
+	resp, err := cfg.Client.PlanTags.ListPlanTags(
+		plantags.NewListPlanTagsParams().WithOrgName(cfg.Org), nil)
+	if err != nil {
+		return err
+	}
+	var current *models.PlanTag
+	for _, t := range resp.Payload {
+		if t.Name == cfg.To {
+			current = t
+			break
+		}
+	}
+	var currentPlanID string
+	if current != nil && current.Spec != nil {
+		currentPlanID = current.Spec.PlanID
+	}
+	if currentPlanID == planID {
+		fmt.Fprintf(log, "Tag %q already points at plan %s.\n", cfg.To, planID)
+		return writeTag(out, cfg.OutputFormat, current)
+	}
+	return moveTag(cfg.Plan, &cfg.PlanTagGuard, out, log, cfg.To, currentPlanID, planID)
+}
@@ -0,0 +1,98 @@
+package plantag
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newRollback(tag *config.PlanTag) *cobra.Command {
+	cfg := &config.PlanTagRollback{PlanTag: tag}
+
+	cmd := &cobra.Command{
+		Use:   "rollback TAG_NAME [--to PLAN_ID]",
+		Short: "Move a plan tag back to a plan it pointed at before",
+		Long: `Move a tag back to the plan it pointed at before the current one, or with
+--to, to any plan listed in 'signadot plan tag history TAG_NAME'.
+
+With --require-passing-exec, the tag is only moved if the latest finished
+execution of the plan it moves to completed.`,
+		Example: `  # Undo the last move of the prod-e2e tag
+  signadot plan tag rollback prod-e2e
+
+  # Go back to a specific plan, if it last ran fine
+  signadot plan tag rollback prod-e2e --to PLAN_ID --require-passing-exec`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rollbackTag(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+
+	cfg.AddFlags(cmd)
+	return cmd
+}
+
+func rollbackTag(cfg *config.PlanTagRollback, out, log io.Writer, tagName string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	tag, err := fetchTag(cfg.Plan, tagName)
+	if err != nil {
+		return err
+	}
+	var current string
+	if tag.Spec != nil {
+		current = tag.Spec.PlanID
+	}
+	target, err := rollbackTarget(tag.History, current, cfg.To)
+	if err != nil {
+		return fmt.Errorf("tag %q: %w", tagName, err)
+	}
+	return moveTag(cfg.Plan, &cfg.PlanTagGuard, out, log, tagName, current, target)
+}
+
+// rollbackTarget returns the plan a rollback moves a tag to: to if set,
+// which must be a past target of the tag, else the plan the tag pointed at
+// before the current one.
+func rollbackTarget(history []*models.TagMapping, current, to string) (string, error) {
+	if to != "" {
+		if to == current {
+			return "", fmt.Errorf("already points at plan %s", to)
+		}
+		for _, h := range history {
+			if h != nil && h.PlanID == to {
+				return to, nil
+			}
+		}
+		return "", fmt.Errorf("never pointed at plan %s; use 'signadot plan tag apply' to move it there", to)
+	}
+	for _, h := range sortedHistory(history) {
+		if h.PlanID != current {
+			return h.PlanID, nil
+		}
+	}
+	return "", fmt.Errorf("has no previous plan to roll back to")
+}
+
+// moveTag points a tag at a plan, once the guard passes, and prints the
+// moved tag. Caller must have already called InitAPIConfig.
+func moveTag(cfg *config.Plan, guard *config.PlanTagGuard, out, log io.Writer, tagName, from, to string) error {
+	if guard.RequirePassingExec {
+		if err := requirePassingExec(cfg, to); err != nil {
+			return fmt.Errorf("not moving tag %q: %w", tagName, err)
+		}
+	}
+	tag, err := ApplyTag(cfg, to, tagName)
+	if err != nil {
+		return err
+	}
+	if from == "" {
+		fmt.Fprintf(log, "Pointed tag %q at plan %s.\n", tagName, to)
+	} else {
+		fmt.Fprintf(log, "Moved tag %q from plan %s to plan %s.\n", tagName, from, to)
+	}
+	return writeTag(out, cfg.OutputFormat, tag)
+}
@@ -0,0 +1,41 @@
+package plantag
+
+import (
+	"testing"
+
+	"github.com/signadot/go-sdk/models"
+)
+
+func TestRollbackTarget(t *testing.T) {
+	history := []*models.TagMapping{
+		{PlanID: "p1", TaggedAt: "2026-01-01T00:00:00Z", UntaggedAt: "2026-01-02T00:00:00Z"},
+		{PlanID: "p3", TaggedAt: "2026-01-03T00:00:00Z"},
+		{PlanID: "p2", TaggedAt: "2026-01-02T00:00:00Z", UntaggedAt: "2026-01-03T00:00:00Z"},
+	}
+	tests := []struct {
+		name    string
+		to      string
+		want    string
+		wantErr bool
+	}{
+		{name: "previous", want: "p2"},
+		{name: "explicit", to: "p1", want: "p1"},
+		{name: "current", to: "p3", wantErr: true},
+		{name: "unknown", to: "p9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rollbackTarget(history, "p3", tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := rollbackTarget(history[1:2], "p3", ""); err == nil {
+		t.Error("expected an error without a previous plan")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/signadot/cli/internal/config"
 	resourceplugins "github.com/signadot/go-sdk/client/resource_plugins"
+	"github.com/signadot/go-sdk/models"
 	"github.com/signadot/go-sdk/transport"
 	"github.com/spf13/cobra"
 )
@@ -39,14 +40,19 @@ func apply(cfg *config.ResourcePluginApply, out, log io.Writer, args []string) e
 	if err != nil {
 		return err
 	}
+	return applyResourcePlugin(cfg.ResourcePlugin, log, req)
+}
 
+// applyResourcePlugin publishes the version of a resource plugin named by
+// req.Name.
+func applyResourcePlugin(cfg *config.ResourcePlugin, log io.Writer, req *models.ResourcePlugin) error {
 	// req.Name carries the combined wire form ("bareName[@semver]").
 	// Split it back for the URL path (which must be the bare name) and
 	// for the version description used in user-facing messages.
 	bareName, version := splitNameVersion(req.Name)
 	params := resourceplugins.NewApplyResourcePluginParams().
 		WithOrgName(cfg.Org).WithPluginName(bareName).WithData(req)
-	_, err = cfg.Client.ResourcePlugins.ApplyResourcePlugin(params, nil)
+	_, err := cfg.Client.ResourcePlugins.ApplyResourcePlugin(params, nil)
 	if err != nil {
 		// The go-sdk's transport middleware (FixAPIErrors) intercepts
 		// 4xx/5xx responses before the per-endpoint typed reader runs,
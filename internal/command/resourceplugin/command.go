@@ -21,6 +21,10 @@ func New(api *config.API) *cobra.Command {
 		newVersions(cfg),
 		newApply(cfg),
 		newDelete(cfg),
+		newTest(cfg),
+		newPublish(cfg),
+		newDiff(cfg),
+		newUsage(cfg),
 	)
 
 	return cmd
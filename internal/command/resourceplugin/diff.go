@@ -0,0 +1,79 @@
+package resourceplugin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/textdiff"
+	resourceplugins "github.com/signadot/go-sdk/client/resource_plugins"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newDiff(resourcePlugin *config.ResourcePlugin) *cobra.Command {
+	cfg := &config.ResourcePluginDiff{ResourcePlugin: resourcePlugin}
+
+	cmd := &cobra.Command{
+		Use:   "diff NAME@VERSION NAME@VERSION",
+		Short: "Show the differences between the specs of two resource plugin versions",
+		Long: `Show the differences between the specs of two published versions of a
+resource plugin, as a line diff of their YAML forms. A reference without a
+version stands for the highest published version.`,
+		Example: `  # What changed in my-plugin 1.3.0
+  signadot resourceplugin diff my-plugin@1.2.0 my-plugin@1.3.0`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func diff(cfg *config.ResourcePluginDiff, out, log io.Writer, fromRef, toRef string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	from, err := getSpecYAML(cfg.ResourcePlugin, fromRef)
+	if err != nil {
+		return err
+	}
+	to, err := getSpecYAML(cfg.ResourcePlugin, toRef)
+	if err != nil {
+		return err
+	}
+	lines := textdiff.Lines(textdiff.Split(from), textdiff.Split(to))
+	if !textdiff.HasChanges(lines) {
+		fmt.Fprintf(log, "No differences between %s and %s.\n", fromRef, toRef)
+		return nil
+	}
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", fromRef, toRef)
+	for _, l := range lines {
+		fmt.Fprintf(out, "%c %s\n", l.Op, l.Text)
+	}
+	return nil
+}
+
+func getSpecYAML(cfg *config.ResourcePlugin, ref string) (string, error) {
+	name, version := splitNameVersion(ref)
+	params := resourceplugins.NewGetResourcePluginParams().WithOrgName(cfg.Org).WithPluginName(name)
+	if version != "" {
+		params = params.WithVersion(&version)
+	}
+	resp, err := cfg.Client.ResourcePlugins.GetResourcePlugin(params, nil)
+	if err != nil {
+		return "", fmt.Errorf("resource plugin %s: %w", ref, err)
+	}
+	return specYAML(resp.Payload)
+}
+
+func specYAML(rp *models.ResourcePlugin) (string, error) {
+	var buf bytes.Buffer
+	if err := print.RawYAML(&buf, rp.Spec); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,120 @@
+package resourceplugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Masterminds/semver"
+	"github.com/signadot/cli/internal/config"
+	resourceplugins "github.com/signadot/go-sdk/client/resource_plugins"
+	"github.com/spf13/cobra"
+)
+
+func newPublish(resourcePlugin *config.ResourcePlugin) *cobra.Command {
+	cfg := &config.ResourcePluginPublish{ResourcePlugin: resourcePlugin}
+
+	cmd := &cobra.Command{
+		Use:   "publish -f FILENAME [--bump major|minor|patch]",
+		Short: "Publish the next version of a resource plugin",
+		Long: `Publish a resource plugin as the next version after the highest published one.
+
+The name in the file is the bare plugin name (no @semver suffix): the version
+is computed by incrementing the major, minor or patch part of the highest
+published version, starting from 0.0.0 for a new plugin. To publish an
+explicit version, use 'signadot resourceplugin apply'.`,
+		Example: `  # my-plugin is at 1.2.3, publish my-plugin@1.3.0
+  signadot resourceplugin publish -f my-plugin.yaml --bump minor`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return publish(cfg, cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func publish(cfg *config.ResourcePluginPublish, log io.Writer) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	if cfg.Filename == "" {
+		return errors.New("must specify resource plugin request file with '-f' flag")
+	}
+	req, err := loadResourcePlugin(cfg.Filename, cfg.TemplateVals, false /*forDelete */)
+	if err != nil {
+		return err
+	}
+	bareName, version := splitNameVersion(req.Name)
+	if version != "" {
+		return fmt.Errorf("the name in %s carries the version %q; remove the @%s suffix to publish the next version, or use 'signadot resourceplugin apply' to publish it as is",
+			cfg.Filename, version, version)
+	}
+
+	latest, err := getHighestVersion(cfg.ResourcePlugin, bareName)
+	if err != nil {
+		return err
+	}
+	next := bumpVersion(latest, cfg.Bump)
+	if latest != nil {
+		fmt.Fprintf(log, "Highest published version of %s is %s.\n", bareName, latest)
+	}
+	req.Name = bareName + "@" + next
+	return applyResourcePlugin(cfg.ResourcePlugin, log, req)
+}
+
+// getHighestVersion returns the highest published version of a resource
+// plugin, or nil if it was never published.
+func getHighestVersion(cfg *config.ResourcePlugin, name string) (*semver.Version, error) {
+	params := resourceplugins.NewListResourcePluginVersionsParams().
+		WithOrgName(cfg.Org).WithPluginName(name)
+	resp, err := cfg.Client.ResourcePlugins.ListResourcePluginVersions(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	wireNames := make([]string, 0, len(resp.Payload))
+	for _, rp := range resp.Payload {
+		wireNames = append(wireNames, rp.Name)
+	}
+	return highestVersion(wireNames), nil
+}
+
+// highestVersion returns the highest version of the given plugin names
+// (name[@version], no version standing for 0.0.0), or nil if there are none.
+// Versions which are not semver are ignored.
+func highestVersion(wireNames []string) *semver.Version {
+	var highest *semver.Version
+	for _, wireName := range wireNames {
+		v, err := semver.NewVersion(displayVersion(wireName))
+		if err != nil {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+		}
+	}
+	return highest
+}
+
+// bumpVersion increments the given part of a version, nil standing for a
+// plugin which was never published.
+func bumpVersion(v *semver.Version, part string) string {
+	if v == nil {
+		v = semver.MustParse("0.0.0")
+	}
+	var next semver.Version
+	switch part {
+	case "major":
+		next = v.IncMajor()
+	case "minor":
+		next = v.IncMinor()
+	default:
+		next = v.IncPatch()
+	}
+	return next.String()
+}
@@ -0,0 +1,74 @@
+package resourceplugin
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+)
+
+func TestHighestVersion(t *testing.T) {
+	cases := []struct {
+		name      string
+		wireNames []string
+		want      string
+	}{
+		{name: "never published"},
+		{name: "unversioned", wireNames: []string{"my-plugin"}, want: "0.0.0"},
+		{
+			name:      "semver order",
+			wireNames: []string{"my-plugin@1.2.3", "my-plugin@1.10.0", "my-plugin@1.9.9"},
+			want:      "1.10.0",
+		},
+		{
+			name:      "prerelease",
+			wireNames: []string{"my-plugin@2.0.0-rc.1", "my-plugin@1.9.0"},
+			want:      "2.0.0-rc.1",
+		},
+		{
+			name:      "invalid versions ignored",
+			wireNames: []string{"my-plugin@latest", "my-plugin@0.1.0"},
+			want:      "0.1.0",
+		},
+		{name: "only invalid versions", wireNames: []string{"my-plugin@latest"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := highestVersion(c.wireNames)
+			if c.want == "" {
+				if got != nil {
+					t.Errorf("got %s, want none", got)
+				}
+				return
+			}
+			if got == nil || got.String() != c.want {
+				t.Errorf("got %v, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		part    string
+		want    string
+	}{
+		{version: "", part: "patch", want: "0.0.1"},
+		{version: "", part: "minor", want: "0.1.0"},
+		{version: "", part: "major", want: "1.0.0"},
+		{version: "1.2.3", part: "patch", want: "1.2.4"},
+		{version: "1.2.3", part: "minor", want: "1.3.0"},
+		{version: "1.2.3", part: "major", want: "2.0.0"},
+		{version: "1.2.3", part: "", want: "1.2.4"},
+		{version: "2.0.0-rc.1", part: "patch", want: "2.0.0"},
+	}
+	for _, c := range cases {
+		var v *semver.Version
+		if c.version != "" {
+			v = semver.MustParse(c.version)
+		}
+		if got := bumpVersion(v, c.part); got != c.want {
+			t.Errorf("bumpVersion(%q, %q): got %s, want %s", c.version, c.part, got, c.want)
+		}
+	}
+}
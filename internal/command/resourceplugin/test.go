@@ -0,0 +1,254 @@
+package resourceplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/locald/sandboxmanager"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func newTest(resourcePlugin *config.ResourcePlugin) *cobra.Command {
+	cfg := &config.ResourcePluginTest{ResourcePlugin: resourcePlugin}
+
+	cmd := &cobra.Command{
+		Use:   "test -f FILENAME [--input key=value ...]",
+		Short: "Run the steps of a resource plugin locally",
+		Long: `Run the create steps of a resource plugin, show the resource outputs
+and run the delete steps, without publishing the plugin.
+
+With --runtime kube (the default), each step runs as a pod in the runner
+namespace of the cluster to which signadot is connected ('signadot local
+connect'). With --runtime docker, each step runs in a local container of the
+runner image. The runner image needs a shell and base64.
+
+Inputs with valueFromSandbox take their value from --input, like the params of
+a resource in a sandbox spec.`,
+		Example: `  # Test a plugin creating a database named test
+  signadot resourceplugin test -f mariadb.yaml --input dbname=test
+
+  # Run the steps in local containers
+  signadot resourceplugin test -f mariadb.yaml --input dbname=test --runtime docker`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return test(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+// testPluginSpec is the part of a resource plugin spec needed to run its
+// steps.
+type testPluginSpec struct {
+	Runner struct {
+		Image              string `json:"image"`
+		Namespace          string `json:"namespace"`
+		PodTemplateOverlay string `json:"podTemplateOverlay"`
+	} `json:"runner"`
+	Create []*testPluginStep `json:"create"`
+	Delete []*testPluginStep `json:"delete"`
+}
+
+type testPluginStep struct {
+	Name   string `json:"name"`
+	Inputs []struct {
+		Name             string `json:"name"`
+		ValueFromSandbox bool   `json:"valueFromSandbox"`
+		ValueFromStep    *struct {
+			Name   string `json:"name"`
+			Output string `json:"output"`
+		} `json:"valueFromStep"`
+		As struct {
+			Env  string `json:"env"`
+			Path string `json:"path"`
+		} `json:"as"`
+	} `json:"inputs"`
+	Script  string `json:"script"`
+	Outputs []struct {
+		Name          string `json:"name"`
+		ValueFromPath string `json:"valueFromPath"`
+	} `json:"outputs"`
+}
+
+func test(cfg *config.ResourcePluginTest, out, log io.Writer) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	name, spec, err := loadTestPlugin(cfg.Filename, cfg.TemplateVals)
+	if err != nil {
+		return err
+	}
+	if spec.Runner.Image == "" {
+		return fmt.Errorf("resource plugin %q has no runner image", name)
+	}
+	if spec.Runner.PodTemplateOverlay != "" {
+		fmt.Fprintf(log, "Warning: the runner podTemplateOverlay is ignored when testing.\n")
+	}
+
+	var rt stepRuntime
+	switch cfg.Runtime {
+	case "docker":
+		rt = &dockerRuntime{}
+	default:
+		rt, err = newKubeRuntime(spec.Runner.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	// outputs of the create steps, by step then output name
+	stepOutputs := map[string]map[string]string{}
+	createErr := runSteps(ctx, cfg, rt, log, "create", spec.Runner.Image, spec.Create, stepOutputs)
+	if ctx.Err() != nil {
+		return createErr
+	}
+	// the delete path runs even if a create step failed, to clean up what
+	// was created
+	deleteErr := runSteps(ctx, cfg, rt, log, "delete", spec.Runner.Image, spec.Delete, stepOutputs)
+
+	outputs := []sandboxmanager.ResourceOutput{}
+	for _, step := range spec.Create {
+		for _, o := range step.Outputs {
+			v, ok := stepOutputs[step.Name][o.Name]
+			if !ok {
+				continue
+			}
+			outputs = append(outputs, sandboxmanager.ResourceOutput{
+				Resource: cfg.ResourceName,
+				Output:   step.Name + "." + o.Name,
+				Value:    v,
+			})
+		}
+	}
+	if err := printTestOutputs(cfg, out, outputs); err != nil {
+		return err
+	}
+	return errors.Join(createErr, deleteErr)
+}
+
+func loadTestPlugin(file string, tplVals config.TemplateVals) (string, *testPluginSpec, error) {
+	template, err := utils.LoadUnstructuredTemplate(file, tplVals, false /* forDelete */)
+	if err != nil {
+		return "", nil, err
+	}
+	name, rawSpec, err := utils.UnstructuredToNameAndSpec(template)
+	if err != nil {
+		return "", nil, err
+	}
+	d, err := json.Marshal(rawSpec)
+	if err != nil {
+		return "", nil, err
+	}
+	spec := &testPluginSpec{}
+	if err := json.Unmarshal(d, spec); err != nil {
+		return "", nil, fmt.Errorf("couldn't parse resource plugin spec: %w", err)
+	}
+	return name, spec, nil
+}
+
+// runSteps runs the given steps in order, stopping at the first failure, and
+// records their outputs in stepOutputs.
+func runSteps(ctx context.Context, cfg *config.ResourcePluginTest, rt stepRuntime, log io.Writer,
+	phase, image string, steps []*testPluginStep, stepOutputs map[string]map[string]string) error {
+	for _, step := range steps {
+		run := &stepRun{
+			Name:  fmt.Sprintf("%s-%s", phase, step.Name),
+			Image: image,
+			Env: map[string]string{
+				"SIGNADOT_SANDBOX_NAME":  cfg.Sandbox,
+				"SIGNADOT_RESOURCE_NAME": cfg.ResourceName,
+			},
+			Files:  map[string]string{},
+			Script: step.Script,
+		}
+		for _, in := range step.Inputs {
+			var (
+				v  string
+				ok bool
+			)
+			switch {
+			case in.ValueFromSandbox:
+				v, ok = cfg.Inputs[in.Name]
+				if !ok {
+					return fmt.Errorf("%s step %q: missing input %q, specify it with --input %s=VALUE", phase, step.Name, in.Name, in.Name)
+				}
+			case in.ValueFromStep != nil:
+				v, ok = stepOutputs[in.ValueFromStep.Name][in.ValueFromStep.Output]
+				if !ok {
+					return fmt.Errorf("%s step %q: input %q refers to the missing output %s.%s", phase, step.Name,
+						in.Name, in.ValueFromStep.Name, in.ValueFromStep.Output)
+				}
+			default:
+				return fmt.Errorf("%s step %q: input %q has no value source", phase, step.Name, in.Name)
+			}
+			if in.As.Env != "" {
+				run.Env[in.As.Env] = v
+			}
+			if in.As.Path != "" {
+				run.Files[in.As.Path] = v
+			}
+		}
+		for _, o := range step.Outputs {
+			run.Outputs = append(run.Outputs, stepOutputPath{Name: o.Name, Path: o.ValueFromPath})
+		}
+
+		fmt.Fprintf(log, "Running %s step %q...\n", phase, step.Name)
+		stepCtx, cancel := context.WithTimeout(ctx, cfg.StepTimeout)
+		outputs, err := rt.run(stepCtx, run, log)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s step %q failed: %w", phase, step.Name, err)
+		}
+		stepOutputs[step.Name] = outputs
+	}
+	return nil
+}
+
+type resourceOutputRow struct {
+	Resource string `sdtab:"RESOURCE"`
+	Output   string `sdtab:"OUTPUT"`
+	Value    string `sdtab:"VALUE,trunc"`
+}
+
+func printTestOutputs(cfg *config.ResourcePluginTest, out io.Writer, outputs []sandboxmanager.ResourceOutput) error {
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		if len(outputs) == 0 {
+			return nil
+		}
+		fmt.Fprintln(out)
+		t := sdtab.New[resourceOutputRow](out)
+		t.AddHeader()
+		for _, o := range outputs {
+			t.AddRow(resourceOutputRow{
+				Resource: o.Resource,
+				Output:   o.Output,
+				Value:    o.Value,
+			})
+		}
+		return t.Flush()
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, outputs)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, outputs)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
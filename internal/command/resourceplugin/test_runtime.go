@@ -0,0 +1,250 @@
+package resourceplugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/signadot/cli/internal/local"
+	"github.com/signadot/cli/internal/poll"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// stepOutputMarker prefixes the lines through which a step reports its
+	// outputs, followed by the output name and its base64 encoded value
+	stepOutputMarker = "::signadot-output::"
+	// defaultRunnerNamespace is the namespace of the step pods when the
+	// runner does not specify one
+	defaultRunnerNamespace = "signadot"
+)
+
+// stepRun is a step of a resource plugin, with its inputs resolved.
+type stepRun struct {
+	Name    string
+	Image   string
+	Env     map[string]string
+	Files   map[string]string
+	Script  string
+	Outputs []stepOutputPath
+}
+
+type stepOutputPath struct {
+	Name string
+	Path string
+}
+
+// stepRuntime runs the steps of a resource plugin, returning their outputs
+// by name.
+type stepRuntime interface {
+	run(ctx context.Context, run *stepRun, log io.Writer) (map[string]string, error)
+}
+
+// stepScript returns the shell script running a step: it writes the input
+// files, runs the step script, and reports the outputs on stdout.
+func stepScript(run *stepRun) string {
+	var b strings.Builder
+	b.WriteString("set -e\n")
+	paths := make([]string, 0, len(run.Files))
+	for p := range run.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		fmt.Fprintf(&b, "mkdir -p \"$(dirname %s)\"\n", shellQuote(p))
+		fmt.Fprintf(&b, "printf '%%s' %s | base64 -d > %s\n",
+			base64.StdEncoding.EncodeToString([]byte(run.Files[p])), shellQuote(p))
+	}
+	fmt.Fprintf(&b, "printf '%%s' %s | base64 -d > /tmp/signadot-step\n",
+		base64.StdEncoding.EncodeToString([]byte(run.Script)))
+	b.WriteString("chmod +x /tmp/signadot-step\n")
+	b.WriteString("IFS= read -r first < /tmp/signadot-step || true\n")
+	b.WriteString("case \"$first\" in '#!'*) /tmp/signadot-step ;; *) sh /tmp/signadot-step ;; esac\n")
+	for _, o := range run.Outputs {
+		fmt.Fprintf(&b, "if [ -f %s ]; then printf '%%s%%s %%s\\n' %s %s \"$(base64 < %s | tr -d '\\n')\"; fi\n",
+			shellQuote(o.Path), stepOutputMarker, shellQuote(o.Name), shellQuote(o.Path))
+	}
+	return b.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// parseStepOutput copies the output of a step to log, except for the lines
+// reporting outputs, which are returned by name.
+func parseStepOutput(r io.Reader, log io.Writer) (map[string]string, error) {
+	outputs := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		rest, ok := strings.CutPrefix(line, stepOutputMarker)
+		if !ok {
+			fmt.Fprintf(log, "  %s\n", line)
+			continue
+		}
+		name, enc, _ := strings.Cut(rest, " ")
+		v, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of output %q: %w", name, err)
+		}
+		outputs[name] = string(v)
+	}
+	return outputs, scanner.Err()
+}
+
+// dockerRuntime runs the steps in local containers.
+type dockerRuntime struct{}
+
+func (*dockerRuntime) run(ctx context.Context, run *stepRun, log io.Writer) (map[string]string, error) {
+	args := []string{"run", "--rm"}
+	keys := make([]string, 0, len(run.Env))
+	for k := range run.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+run.Env[k])
+	}
+	args = append(args, "--entrypoint", "/bin/sh", run.Image, "-c", stepScript(run))
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stderr = &prefixWriter{w: log}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("unable to run docker: %w", err)
+	}
+	outputs, parseErr := parseStepOutput(stdout, log)
+	if err := cmd.Wait(); err != nil {
+		return nil, err
+	}
+	return outputs, parseErr
+}
+
+// prefixWriter indents the lines written to w, like parseStepOutput does.
+type prefixWriter struct {
+	w       io.Writer
+	midLine bool
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		if line == "" {
+			continue
+		}
+		if !p.midLine {
+			io.WriteString(p.w, "  ")
+		}
+		io.WriteString(p.w, line)
+		p.midLine = !strings.HasSuffix(line, "\n")
+	}
+	return len(b), nil
+}
+
+// kubeRuntime runs the steps as pods in the cluster to which signadot is
+// connected.
+type kubeRuntime struct {
+	clientset *kubernetes.Clientset
+	namespace string
+}
+
+func newKubeRuntime(namespace string) (*kubeRuntime, error) {
+	restConfig, err := local.GetLocalKubeConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = defaultRunnerNamespace
+	}
+	return &kubeRuntime{clientset: clientset, namespace: namespace}, nil
+}
+
+var podNameRegexp = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func (k *kubeRuntime) run(ctx context.Context, run *stepRun, log io.Writer) (map[string]string, error) {
+	pods := k.clientset.CoreV1().Pods(k.namespace)
+
+	name := strings.Trim(podNameRegexp.ReplaceAllString(strings.ToLower(run.Name), "-"), "-")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rp-test-" + name + "-" + rand.String(5),
+			Namespace: k.namespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:    "step",
+				Image:   run.Image,
+				Command: []string{"/bin/sh", "-c", stepScript(run)},
+			}},
+		},
+	}
+	keys := make([]string, 0, len(run.Env))
+	for k := range run.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env,
+			corev1.EnvVar{Name: key, Value: run.Env[key]})
+	}
+
+	pod, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// the step context may be done already
+		delCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		pods.Delete(delCtx, pod.Name, metav1.DeleteOptions{})
+	}()
+
+	var phase corev1.PodPhase
+	err = poll.NewPoll().WithDelay(2*time.Second).UntilWithError(ctx, func(ctx context.Context) (bool, error) {
+		p, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = p.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pod %s/%s: %w", k.namespace, pod.Name, err)
+	}
+
+	logs, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{}).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer logs.Close()
+	outputs, err := parseStepOutput(logs, log)
+	if err != nil {
+		return nil, err
+	}
+	if phase == corev1.PodFailed {
+		return nil, fmt.Errorf("pod %s/%s failed", k.namespace, pod.Name)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,42 @@
+package resourceplugin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestParseStepOutput(t *testing.T) {
+	enc := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+	in := strings.Join([]string{
+		"creating database",
+		stepOutputMarker + "host " + enc("db.example.svc"),
+		"done",
+		stepOutputMarker + "password " + enc("multi\nline"),
+		stepOutputMarker + "empty ",
+	}, "\n")
+	log := &bytes.Buffer{}
+	outputs, err := parseStepOutput(strings.NewReader(in), log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"host":     "db.example.svc",
+		"password": "multi\nline",
+		"empty":    "",
+	}
+	if len(outputs) != len(want) {
+		t.Errorf("got outputs %v, want %v", outputs, want)
+	}
+	for k, v := range want {
+		if outputs[k] != v {
+			t.Errorf("output %q: got %q, want %q", k, outputs[k], v)
+		}
+	}
+	if got := log.String(); got != "  creating database\n  done\n" {
+		t.Errorf("got log %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+package resourceplugin
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/sdtab"
+	resourceplugins "github.com/signadot/go-sdk/client/resource_plugins"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+func newUsage(resourcePlugin *config.ResourcePlugin) *cobra.Command {
+	cfg := &config.ResourcePluginUsage{ResourcePlugin: resourcePlugin}
+
+	cmd := &cobra.Command{
+		Use:   "usage NAME",
+		Short: "Show which sandboxes use each version of a resource plugin",
+		Long: `Show the sandbox resources using each published version of a resource
+plugin, highest semver first. Versions no sandbox uses are listed with "-",
+so they can be told apart as safe to delete.`,
+		Example: `  # Which sandboxes still use old versions of my-plugin
+  signadot resourceplugin usage my-plugin`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return usage(cfg, cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+// versionUsage is the output of `rp usage` for a version.
+type versionUsage struct {
+	Version   string                 `json:"version"`
+	Resources []*models.ResourceInfo `json:"resources"`
+}
+
+func usage(cfg *config.ResourcePluginUsage, out io.Writer, name string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	params := resourceplugins.NewListResourcePluginVersionsParams().
+		WithOrgName(cfg.Org).WithPluginName(name)
+	resp, err := cfg.Client.ResourcePlugins.ListResourcePluginVersions(params, nil)
+	if err != nil {
+		return err
+	}
+	// See versions: an empty list means the plugin was never published.
+	if len(resp.Payload) == 0 {
+		return fmt.Errorf("resource plugin %q not found", name)
+	}
+
+	usages := make([]versionUsage, 0, len(resp.Payload))
+	for _, rp := range resp.Payload {
+		u := versionUsage{Version: displayVersion(rp.Name), Resources: []*models.ResourceInfo{}}
+		if rp.Status != nil {
+			u.Resources = append(u.Resources, rp.Status.Resources...)
+		}
+		usages = append(usages, u)
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		return printUsageTable(out, usages)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, usages)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, usages)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+type usageRow struct {
+	Version  string `sdtab:"VERSION"`
+	Sandbox  string `sdtab:"SANDBOX"`
+	Resource string `sdtab:"RESOURCE NAME"`
+	Cluster  string `sdtab:"CLUSTER"`
+}
+
+func printUsageTable(out io.Writer, usages []versionUsage) error {
+	t := sdtab.New[usageRow](out)
+	t.AddHeader()
+	for _, u := range usages {
+		if len(u.Resources) == 0 {
+			t.AddRow(usageRow{Version: u.Version, Sandbox: "-", Resource: "-", Cluster: "-"})
+			continue
+		}
+		for _, r := range u.Resources {
+			t.AddRow(usageRow{
+				Version:  u.Version,
+				Sandbox:  r.Sandbox,
+				Resource: r.Name,
+				Cluster:  r.Cluster,
+			})
+		}
+	}
+	return t.Flush()
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/signadot/cli/internal/builder"
 	"github.com/signadot/cli/internal/config"
-	"github.com/signadot/cli/internal/devbox"
 	sbmapi "github.com/signadot/cli/internal/locald/api/sandboxmanager"
 	sbmgr "github.com/signadot/cli/internal/locald/sandboxmanager"
 	"github.com/signadot/cli/internal/print"
@@ -65,6 +64,9 @@ func apply(cfg *config.SandboxApply, out, log io.Writer, args []string) error {
 		if err != nil {
 			return err
 		}
+		if status.DevboxSession == nil {
+			return errors.New("not connected with a devbox session (CLI locald version mismatch) please disconnect and reconnect")
+		}
 
 		// Set devbox ID for local sandboxes
 		sb, err := builder.
@@ -92,22 +94,15 @@ func apply(cfg *config.SandboxApply, out, log io.Writer, args []string) error {
 	fmt.Fprintf(log, "Created sandbox %q (routing key: %s) in cluster %q.\n\n",
 		req.Name, resp.RoutingKey, *req.Spec.Cluster)
 
-	if len(req.Spec.Local) > 0 && status.OperatorInfo == nil {
-		id, err := devbox.GetID(ctx, cfg.API, false, "" /* name set on connect */)
-		if err != nil {
-			return err
-		}
-		_ = id
-	}
-
 	if cfg.Wait {
 		// Wait for the sandbox to be ready.
 		// store latest resp for output below
 		resp, err = utils.WaitForSandboxReady(ctx, cfg.API, log, resp.Name, cfg.WaitTimeout)
 		if err != nil {
 			writeOutput(cfg, out, resp)
+			reportNotReady(ctx, cfg.API, log, resp)
 			fmt.Fprintf(log, "\nThe sandbox was applied, but it may not be ready yet. To check status, run:\n\n")
-			fmt.Fprintf(log, "  signadot sandbox get %v\n\n", req.Name)
+			fmt.Fprintf(log, "  signadot sandbox describe %v\n\n", req.Name)
 			return err
 		}
 		writeOutput(cfg, out, resp)
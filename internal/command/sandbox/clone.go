@@ -0,0 +1,201 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/signadot/cli/internal/auth"
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	sbmgr "github.com/signadot/cli/internal/locald/sandboxmanager"
+	"github.com/signadot/cli/internal/utils"
+	"github.com/signadot/go-sdk/client/sandboxes"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ownerLabel is the label conventionally holding the owner of a sandbox,
+	// which is re-assigned to the authenticated Signadot user in clones
+	ownerLabel = "owner"
+	// clonedFromLabel records the sandbox a clone was derived from
+	clonedFromLabel = "cloned-from"
+)
+
+func newClone(sandbox *config.Sandbox) *cobra.Command {
+	cfg := &config.SandboxClone{Sandbox: sandbox}
+
+	cmd := &cobra.Command{
+		Use:     "clone SRC NEW [--set key=value] [--local NAME[:PORT]=ADDR] [--keep-locals]",
+		Short:   "Create a sandbox from the spec of an existing one",
+		Aliases: []string{"fork-from"},
+		Long: `Create a new sandbox from the spec of an existing one.
+
+The new sandbox is labeled as cloned from the source sandbox and, if the source
+has an "owner" label, owned by the authenticated Signadot user. Forked workloads can be run
+locally instead with --local, mapping a port of the workload to a local
+address (the port defaults to the one of the local address).
+
+The local workloads and the traffic overrides of the source run on the
+workstation of its owner, so they are not part of the clone unless
+--keep-locals is given, in which case they run on this workstation.`,
+		Example: `  # Same sandbox as alice-feature, with the route workload running locally
+  signadot sandbox clone alice-feature my-feature --local route=localhost:8083
+
+  # Map port 80 of the frontend workload to a local port
+  signadot sandbox clone alice-feature my-feature --local frontend:80=localhost:8080`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return clone(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], args[1])
+		},
+	}
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func clone(cfg *config.SandboxClone, out, log io.Writer, srcName, name string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	src, err := utils.GetSandbox(ctx, cfg.API, srcName)
+	if err != nil {
+		return err
+	}
+
+	authInfo, err := auth.ResolveAuth()
+	if err != nil {
+		return err
+	}
+	owner := ""
+	if authInfo != nil {
+		owner = authInfo.Email()
+	}
+
+	// Only keep the spec, dropping the status and all the other fields set
+	// by the server, as well as the connection to the devbox of the source.
+	data := models.Sandbox{Name: name, Spec: src.Spec}
+	b := builder.BuildSandbox(name, builder.WithData(data))
+	req, err := cloneSpec(cfg, b, srcName, owner)
+	if err != nil {
+		return err
+	}
+	req.Spec.Connection = nil
+	if !cfg.KeepLocals {
+		nLocals := len(src.Spec.Local)
+		nOverrides := len(builder.GetAvailableOverrideMiddlewares(src))
+		if nLocals > 0 || nOverrides > 0 {
+			fmt.Fprintf(log, "Not cloning the %d local workload(s) and %d override(s) of %q, use --keep-locals to run them on this workstation.\n",
+				nLocals, nOverrides, srcName)
+		}
+	}
+
+	if len(req.Spec.Local) > 0 || req.Spec.Routing != nil && len(req.Spec.Routing.Forwards) > 0 {
+		// Validate sandboxmanager is running and connected to the right cluster
+		status, err := sbmgr.ValidateSandboxManager(req.Spec.Cluster)
+		if err != nil {
+			return err
+		}
+		if status.DevboxSession == nil {
+			return errors.New("no devbox session")
+		}
+		sb, err := builder.
+			BuildSandbox(name, builder.WithData(*req)).
+			SetDevboxID(status.DevboxSession.DevboxId).
+			Build()
+		if err != nil {
+			return err
+		}
+		req = &sb
+	}
+
+	params := sandboxes.NewApplySandboxParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithSandboxName(name).
+		WithData(req)
+	result, err := cfg.Client.Sandboxes.ApplySandbox(params, nil)
+	if err != nil {
+		return err
+	}
+	resp := result.Payload
+
+	fmt.Fprintf(log, "Created sandbox %q from %q (routing key: %s) in cluster %q.\n\n",
+		name, srcName, resp.RoutingKey, *req.Spec.Cluster)
+
+	applyCfg := &config.SandboxApply{Sandbox: cfg.Sandbox}
+	if cfg.Wait {
+		resp, err = utils.WaitForSandboxReady(ctx, cfg.API, log, name, cfg.WaitTimeout)
+		if err != nil {
+			writeOutput(applyCfg, out, resp)
+			reportNotReady(ctx, cfg.API, log, resp)
+			fmt.Fprintf(log, "\nThe sandbox was created, but it may not be ready yet. To check status, run:\n\n")
+			fmt.Fprintf(log, "  signadot sandbox describe %v\n\n", name)
+			return err
+		}
+		writeOutput(applyCfg, out, resp)
+		fmt.Fprintf(log, "\nThe sandbox %q was created and is ready.\n", name)
+		return nil
+	}
+	return writeOutput(applyCfg, out, resp)
+}
+
+// cloneSpec applies the labels and local workloads requested for the clone,
+// owned by the given user. Unless --keep-locals is given, the local workloads
+// and overrides of the source are dropped, as they run on the workstation of
+// its owner.
+func cloneSpec(cfg *config.SandboxClone, b *builder.SandboxBuilder, srcName, owner string) (*models.Sandbox, error) {
+	sb, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.KeepLocals {
+		for _, o := range builder.GetAvailableOverrideMiddlewares(&sb) {
+			b.DeleteOverrideMiddleware(o.Forward.Name)
+		}
+		b.DeleteLocals()
+	}
+	if _, ok := sb.Spec.Labels[ownerLabel]; ok {
+		if _, ok := cfg.Labels[ownerLabel]; !ok {
+			if owner == "" {
+				return nil, fmt.Errorf("could not determine the Signadot user owning the new sandbox, set it with --set %s=USER", ownerLabel)
+			}
+			b.SetLabel(ownerLabel, owner)
+		}
+	}
+	b.SetLabel(clonedFromLabel, srcName)
+	for k, v := range cfg.Labels {
+		b.SetLabel(k, v)
+	}
+
+	// group the port mappings by workload
+	var workloads []string
+	mappings := map[string][]*models.LocalMapping{}
+	for _, l := range cfg.Locals {
+		if _, ok := mappings[l.Workload]; !ok {
+			workloads = append(workloads, l.Workload)
+		}
+		mappings[l.Workload] = append(mappings[l.Workload], &models.LocalMapping{
+			Port:    l.Port,
+			ToLocal: l.ToLocal,
+		})
+	}
+	for _, w := range workloads {
+		b.ReplaceForkWithLocal(w, mappings[w])
+	}
+
+	sb, err = b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &sb, nil
+}
@@ -0,0 +1,69 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/go-sdk/models"
+)
+
+func cloneSource() *builder.SandboxBuilder {
+	kind, ns, name := "Deployment", "hotrod", "route"
+	return builder.BuildSandbox("src", builder.WithData(models.Sandbox{
+		Name: "src",
+		Spec: &models.SandboxSpec{
+			Labels: map[string]string{"team": "a"},
+			Forks: []*models.SandboxFork{{
+				ForkOf: &models.SandboxForkOf{Kind: &kind, Namespace: &ns, Name: &name},
+			}},
+		},
+	})).
+		AddLocal("Deployment", "hotrod", "frontend", []*models.LocalMapping{{Port: 8080, ToLocal: "localhost:8080"}}).
+		AddOverrideMiddleware(8081, "localhost:9999", []string{"driver"})
+}
+
+func TestCloneSpecDropsLocals(t *testing.T) {
+	src, err := cloneSource().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.SandboxClone{
+		Locals: []config.SandboxCloneLocal{{Workload: "route", Port: 8083, ToLocal: "localhost:8083"}},
+	}
+	sb, err := cloneSpec(cfg, builder.BuildSandbox("new", builder.WithData(src)), "src", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(builder.GetAvailableOverrideMiddlewares(sb)); n != 0 {
+		t.Errorf("got %d overrides of the source in the clone, want none", n)
+	}
+	if sb.Spec.Routing != nil && len(sb.Spec.Routing.Forwards) != 0 {
+		t.Errorf("got forwards %v in the clone, want none", sb.Spec.Routing.Forwards)
+	}
+	// only the local requested with --local is left
+	if len(sb.Spec.Local) != 1 || *sb.Spec.Local[0].From.Name != "route" {
+		t.Errorf("got locals %+v, want only the one of route", sb.Spec.Local)
+	}
+	if sb.Spec.Labels[clonedFromLabel] != "src" {
+		t.Errorf("clone not labeled as cloned from src")
+	}
+}
+
+func TestCloneSpecKeepLocals(t *testing.T) {
+	src, err := cloneSource().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.SandboxClone{KeepLocals: true}
+	sb, err := cloneSpec(cfg, builder.BuildSandbox("new", builder.WithData(src)), "src", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(builder.GetAvailableOverrideMiddlewares(sb)); n != 1 {
+		t.Errorf("got %d overrides in the clone, want 1", n)
+	}
+	if len(sb.Spec.Local) != 1 || *sb.Spec.Local[0].From.Name != "frontend" {
+		t.Errorf("got locals %+v, want the one of frontend", sb.Spec.Local)
+	}
+}
@@ -22,6 +22,11 @@ func New(api *config.API) *cobra.Command {
 		newDelete(cfg),
 		newGetEnv(cfg),
 		newGetFiles(cfg),
+		newTTL(cfg),
+		newExtend(cfg),
+		newClone(cfg),
+		newInit(cfg),
+		newDescribe(cfg),
 	)
 
 	return cmd
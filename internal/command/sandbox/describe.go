@@ -0,0 +1,452 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/local"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/sdtab"
+	"github.com/signadot/cli/internal/utils"
+	resourceplugins "github.com/signadot/go-sdk/client/resource_plugins"
+	"github.com/signadot/go-sdk/client/sandboxes"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+// diagnosticsTailLines is the number of log lines shown per workload and
+// resource step when a sandbox does not become ready in time.
+const diagnosticsTailLines = 10
+
+func newDescribe(sandbox *config.Sandbox) *cobra.Command {
+	cfg := &config.SandboxDescribe{Sandbox: sandbox}
+
+	cmd := &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Explain the readiness of a sandbox",
+		Long: `Explain the readiness of a sandbox.
+
+Shows the status of the sandbox, whether logs are available for the forked
+workloads (once their pods are scheduled) and the steps of the resources (once
+they have started), and the state of the tunnels to the local workloads (when
+connected with 'signadot local connect'), along with the recent logs of the
+forks and resource steps.
+
+The API does not report the rollout state of the forked workloads nor the
+status of the resource steps: whether their logs are available is the only
+hint of how far they got. A fork without logs has no pod scheduled yet, or
+its pods were never created; a resource step without logs has not started.
+The reason and message of the sandbox status tell which of them failed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return describe(cfg, cmd.OutOrStdout(), args[0])
+		},
+	}
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func describe(cfg *config.SandboxDescribe, out io.Writer, name string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sb, err := utils.GetSandbox(ctx, cfg.API, name)
+	if err != nil {
+		return err
+	}
+	diag := diagnoseSandbox(ctx, cfg.API, sb, int(cfg.TailLines))
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		return printSandboxDiagnostics(out, diag)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, diag)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, diag)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+// reportNotReady explains why a sandbox did not become ready after waiting
+// for it, the report going to log along with the wait progress.
+func reportNotReady(ctx context.Context, cfg *config.API, log io.Writer, sb *models.Sandbox) {
+	if sb == nil || ctx.Err() != nil {
+		// nothing was fetched, or the wait was interrupted
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	fmt.Fprintf(log, "\nThe sandbox %q is not ready:\n\n", sb.Name)
+	printSandboxDiagnostics(log, diagnoseSandbox(ctx, cfg, sb, diagnosticsTailLines))
+}
+
+type sandboxDiagnostics struct {
+	Name           string                      `json:"name"`
+	RoutingKey     string                      `json:"routingKey"`
+	Ready          bool                        `json:"ready"`
+	Reason         string                      `json:"reason,omitempty"`
+	Message        string                      `json:"message,omitempty"`
+	Forks          []*forkDiagnostics          `json:"forks,omitempty"`
+	Resources      []*resourceDiagnostics      `json:"resources,omitempty"`
+	LocalWorkloads []*localWorkloadDiagnostics `json:"localWorkloads,omitempty"`
+	Errors         []string                    `json:"errors,omitempty"`
+}
+
+// logs availability, as a hint of the state of a fork or resource step: the
+// logs of a fork are available once its pods are scheduled, the ones of a
+// resource step once it has started. The sandbox status has no rollout state
+// per fork nor status per resource step to report instead.
+const (
+	logsAvailable = "available"
+	logsNone      = "none"
+	logsUnknown   = "unknown"
+)
+
+type forkDiagnostics struct {
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Logs       string   `json:"logs"`
+	Containers []string `json:"containers,omitempty"`
+	LogTail    []string `json:"logTail,omitempty"`
+}
+
+type resourceDiagnostics struct {
+	Name   string                     `json:"name"`
+	Plugin string                     `json:"plugin"`
+	Steps  []*resourceStepDiagnostics `json:"steps,omitempty"`
+}
+
+type resourceStepDiagnostics struct {
+	Name    string   `json:"name"`
+	Logs    string   `json:"logs"`
+	LogTail []string `json:"logTail,omitempty"`
+}
+
+type localWorkloadDiagnostics struct {
+	Name      string `json:"name"`
+	Baseline  string `json:"baseline"`
+	Tunnel    string `json:"tunnel"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// diagnoseSandbox gathers what is known about the readiness of a sandbox.
+// Diagnostics which cannot be gathered are recorded as errors in the result
+// rather than failing the whole report.
+func diagnoseSandbox(ctx context.Context, cfg *config.API, sb *models.Sandbox, tailLines int) *sandboxDiagnostics {
+	diag := &sandboxDiagnostics{
+		Name:       sb.Name,
+		RoutingKey: sb.RoutingKey,
+	}
+	if sb.Status != nil {
+		diag.Ready = sb.Status.Ready
+		diag.Reason = sb.Status.Reason
+		diag.Message = sb.Status.Message
+	}
+	if sb.Spec == nil {
+		return diag
+	}
+
+	for _, f := range sb.Spec.Forks {
+		if f.ForkOf == nil || f.ForkOf.Name == nil {
+			continue
+		}
+		fd := &forkDiagnostics{Name: *f.ForkOf.Name}
+		if f.ForkOf.Kind != nil {
+			fd.Kind = *f.ForkOf.Kind
+		}
+		if f.ForkOf.Namespace != nil {
+			fd.Namespace = *f.ForkOf.Namespace
+		}
+		logs, err := getSandboxLogs(ctx, cfg, sb.Name, fd.Name, "", "")
+		if err != nil {
+			fd.Logs = logsUnknown
+			diag.Errors = append(diag.Errors, fmt.Sprintf("fork %s: %v", fd.Name, err))
+		} else {
+			fd.Containers = logContainers(logs)
+			fd.Logs = logsAvailable
+			if len(fd.Containers) == 0 {
+				fd.Logs = logsNone
+			}
+			fd.LogTail = tailLogs(logs, tailLines)
+		}
+		diag.Forks = append(diag.Forks, fd)
+	}
+
+	for _, r := range sb.Spec.Resources {
+		rd := &resourceDiagnostics{Name: r.Name, Plugin: r.Plugin}
+		steps, err := getPluginSteps(ctx, cfg, r.Plugin)
+		if err != nil {
+			diag.Errors = append(diag.Errors, fmt.Sprintf("resource %s: %v", r.Name, err))
+		}
+		for _, step := range steps {
+			sd := &resourceStepDiagnostics{Name: step}
+			logs, err := getSandboxLogs(ctx, cfg, sb.Name, "", r.Name, step)
+			if err != nil {
+				sd.Logs = logsUnknown
+				diag.Errors = append(diag.Errors, fmt.Sprintf("resource %s step %s: %v", r.Name, step, err))
+			} else {
+				sd.Logs = logsAvailable
+				if len(logs) == 0 {
+					sd.Logs = logsNone
+				}
+				sd.LogTail = tailLogs(logs, tailLines)
+			}
+			rd.Steps = append(rd.Steps, sd)
+		}
+		diag.Resources = append(diag.Resources, rd)
+	}
+
+	if len(sb.Spec.Local) > 0 {
+		lws, err := getLocalWorkloadDiagnostics(sb)
+		if err != nil {
+			diag.Errors = append(diag.Errors, fmt.Sprintf("local workloads: %v", strings.TrimSpace(err.Error())))
+		}
+		diag.LocalWorkloads = lws
+	}
+	return diag
+}
+
+// containerLogs are the log lines of a container of a fork or resource step.
+type containerLogs struct {
+	Container string
+	Lines     []string
+}
+
+func getSandboxLogs(ctx context.Context, cfg *config.API, sbName, workload, resource, step string) ([]containerLogs, error) {
+	params := sandboxes.NewGetSandboxLogsParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithSandboxName(sbName)
+	if workload != "" {
+		params.Workload = &workload
+	}
+	if resource != "" {
+		params.Resource = &resource
+	}
+	if step != "" {
+		params.Step = &step
+	}
+	resp, err := cfg.Client.Sandboxes.GetSandboxLogs(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]containerLogs, 0, len(resp.Payload))
+	for _, cl := range resp.Payload {
+		lines := make([]string, 0, len(cl.Logs))
+		for _, item := range cl.Logs {
+			lines = append(lines, item.Message)
+		}
+		res = append(res, containerLogs{Container: cl.Container, Lines: lines})
+	}
+	return res, nil
+}
+
+func getPluginSteps(ctx context.Context, cfg *config.API, pluginRef string) ([]string, error) {
+	name, version, _ := strings.Cut(pluginRef, "@")
+	params := resourceplugins.NewGetResourcePluginParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithPluginName(name)
+	if version != "" {
+		params = params.WithVersion(&version)
+	}
+	resp, err := cfg.Client.ResourcePlugins.GetResourcePlugin(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var steps []string
+	if resp.Payload.Spec != nil {
+		for _, step := range resp.Payload.Spec.Create {
+			steps = append(steps, step.Name)
+		}
+	}
+	return steps, nil
+}
+
+// getLocalWorkloadDiagnostics reports the tunnels of the local workloads of
+// the sandbox, as seen by the sandbox manager of this machine.
+func getLocalWorkloadDiagnostics(sb *models.Sandbox) ([]*localWorkloadDiagnostics, error) {
+	status, err := local.GetLocalStatus()
+	if err != nil {
+		return nil, err
+	}
+	var res []*localWorkloadDiagnostics
+	for _, sbStatus := range status.Sandboxes {
+		if sbStatus.Name != sb.Name {
+			continue
+		}
+		for _, lw := range sbStatus.LocalWorkloads {
+			lwd := &localWorkloadDiagnostics{
+				Name:   lw.Name,
+				Tunnel: "disconnected",
+			}
+			if b := lw.Baseline; b != nil {
+				lwd.Baseline = fmt.Sprintf("%s %s/%s", b.Kind, b.Namespace, b.Name)
+			}
+			if h := lw.TunnelHealth; h != nil {
+				if h.Healthy {
+					lwd.Tunnel = "connected"
+				}
+				lwd.LastError = h.LastErrorReason
+			}
+			res = append(res, lwd)
+		}
+	}
+	if res == nil {
+		return nil, fmt.Errorf("the sandbox is not known to the local sandbox manager, is it connected to cluster %q?", *sb.Spec.Cluster)
+	}
+	return res, nil
+}
+
+func logContainers(logs []containerLogs) []string {
+	res := make([]string, 0, len(logs))
+	for _, cl := range logs {
+		res = append(res, cl.Container)
+	}
+	return res
+}
+
+// tailLogs returns the last n lines of the given logs, prefixed by their
+// container when there are several.
+func tailLogs(logs []containerLogs, n int) []string {
+	var lines []string
+	multi := len(logs) > 1
+	for _, cl := range logs {
+		for _, line := range cl.Lines {
+			if multi {
+				lines = append(lines, fmt.Sprintf("[%s] %s", cl.Container, line))
+			} else {
+				lines = append(lines, line)
+			}
+		}
+	}
+	if n >= 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+type forkDiagnosticsRow struct {
+	Workload   string `sdtab:"FORK"`
+	Namespace  string `sdtab:"NAMESPACE"`
+	Logs       string `sdtab:"LOGS"`
+	Containers string `sdtab:"CONTAINERS"`
+}
+
+type resourceStepRow struct {
+	Resource string `sdtab:"RESOURCE"`
+	Plugin   string `sdtab:"PLUGIN"`
+	Step     string `sdtab:"STEP"`
+	Logs     string `sdtab:"LOGS"`
+}
+
+type localWorkloadRow struct {
+	Name      string `sdtab:"LOCAL WORKLOAD"`
+	Baseline  string `sdtab:"BASELINE"`
+	Tunnel    string `sdtab:"TUNNEL"`
+	LastError string `sdtab:"LAST ERROR,trunc"`
+}
+
+func printSandboxDiagnostics(out io.Writer, diag *sandboxDiagnostics) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", diag.Name)
+	fmt.Fprintf(tw, "Routing Key:\t%s\n", diag.RoutingKey)
+	status := "Not Ready"
+	if diag.Ready {
+		status = "Ready"
+	}
+	fmt.Fprintf(tw, "Status:\t%s (%s: %s)\n", status, diag.Reason, diag.Message)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(diag.Forks) > 0 {
+		fmt.Fprintln(out)
+		t := sdtab.New[forkDiagnosticsRow](out)
+		t.AddHeader()
+		for _, f := range diag.Forks {
+			t.AddRow(forkDiagnosticsRow{
+				Workload:   f.Kind + "/" + f.Name,
+				Namespace:  f.Namespace,
+				Logs:       f.Logs,
+				Containers: strings.Join(f.Containers, ","),
+			})
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(diag.Resources) > 0 {
+		fmt.Fprintln(out)
+		t := sdtab.New[resourceStepRow](out)
+		t.AddHeader()
+		for _, r := range diag.Resources {
+			if len(r.Steps) == 0 {
+				t.AddRow(resourceStepRow{Resource: r.Name, Plugin: r.Plugin, Step: "-", Logs: logsUnknown})
+			}
+			for _, s := range r.Steps {
+				t.AddRow(resourceStepRow{Resource: r.Name, Plugin: r.Plugin, Step: s.Name, Logs: s.Logs})
+			}
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(diag.LocalWorkloads) > 0 {
+		fmt.Fprintln(out)
+		t := sdtab.New[localWorkloadRow](out)
+		t.AddHeader()
+		for _, lw := range diag.LocalWorkloads {
+			t.AddRow(localWorkloadRow{
+				Name:      lw.Name,
+				Baseline:  lw.Baseline,
+				Tunnel:    lw.Tunnel,
+				LastError: lw.LastError,
+			})
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range diag.Forks {
+		printLogTail(out, fmt.Sprintf("fork %s/%s", f.Kind, f.Name), f.LogTail)
+	}
+	for _, r := range diag.Resources {
+		for _, s := range r.Steps {
+			printLogTail(out, fmt.Sprintf("resource %s, step %s", r.Name, s.Name), s.LogTail)
+		}
+	}
+
+	if len(diag.Errors) > 0 {
+		fmt.Fprintf(out, "\nIncomplete diagnostics:\n")
+		for _, e := range diag.Errors {
+			fmt.Fprintf(out, "  %s\n", e)
+		}
+	}
+	return nil
+}
+
+func printLogTail(out io.Writer, what string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nRecent logs of %s:\n", what)
+	for _, line := range lines {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+}
@@ -0,0 +1,86 @@
+package sandbox
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTailLogs(t *testing.T) {
+	single := []containerLogs{{Container: "main", Lines: []string{"a", "b", "c"}}}
+	multi := []containerLogs{
+		{Container: "main", Lines: []string{"a", "b"}},
+		{Container: "sidecar", Lines: []string{"c"}},
+	}
+	cases := []struct {
+		name string
+		logs []containerLogs
+		n    int
+		want []string
+	}{
+		{name: "all lines", logs: single, n: 10, want: []string{"a", "b", "c"}},
+		{name: "last lines", logs: single, n: 2, want: []string{"b", "c"}},
+		{name: "no lines", logs: single, n: 0, want: []string{}},
+		{name: "unlimited", logs: single, n: -1, want: []string{"a", "b", "c"}},
+		{name: "several containers", logs: multi, n: 2, want: []string{"[main] b", "[sidecar] c"}},
+		{name: "no logs", n: 10},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := tailLogs(c.logs, c.n); !slices.Equal(got, c.want) {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPrintSandboxDiagnostics(t *testing.T) {
+	diag := &sandboxDiagnostics{
+		Name:       "my-sandbox",
+		RoutingKey: "rk123",
+		Reason:     "ForksNotReady",
+		Message:    "waiting for forks",
+		Forks: []*forkDiagnostics{
+			{Kind: "Deployment", Namespace: "hotrod", Name: "route", Logs: logsAvailable,
+				Containers: []string{"route", "sidecar"}, LogTail: []string{"listening on :8083"}},
+			{Kind: "Deployment", Namespace: "hotrod", Name: "frontend", Logs: logsNone},
+		},
+		Resources: []*resourceDiagnostics{
+			{Name: "db", Plugin: "mariadb", Steps: []*resourceStepDiagnostics{
+				{Name: "provision", Logs: logsAvailable, LogTail: []string{"creating database"}},
+			}},
+			{Name: "queue", Plugin: "missing"},
+		},
+		LocalWorkloads: []*localWorkloadDiagnostics{
+			{Name: "local-driver", Baseline: "Deployment hotrod/driver", Tunnel: "disconnected", LastError: "dial failed"},
+		},
+		Errors: []string{"resource queue: plugin not found"},
+	}
+	var buf bytes.Buffer
+	if err := printSandboxDiagnostics(&buf, diag); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`Status:\s+Not Ready \(ForksNotReady: waiting for forks\)`,
+		`FORK\s+NAMESPACE\s+LOGS\s+CONTAINERS`,
+		`Deployment/route\s+hotrod\s+available\s+route,sidecar`,
+		`Deployment/frontend\s+hotrod\s+none`,
+		`RESOURCE\s+PLUGIN\s+STEP\s+LOGS`,
+		`db\s+mariadb\s+provision\s+available`,
+		`queue\s+missing\s+-\s+unknown`,
+		`local-driver\s+Deployment hotrod/driver\s+disconnected\s+dial failed`,
+		`Recent logs of fork Deployment/route:\n  listening on :8083`,
+		`Recent logs of resource db, step provision:\n  creating database`,
+		`Incomplete diagnostics:\n  resource queue: plugin not found`,
+	} {
+		if !regexp.MustCompile(want).MatchString(out) {
+			t.Errorf("output does not match %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Recent logs of fork Deployment/frontend") {
+		t.Errorf("logs printed for a fork without logs:\n%s", out)
+	}
+}
@@ -0,0 +1,114 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/client/sandboxes"
+	"github.com/spf13/cobra"
+)
+
+func newExtend(sandbox *config.Sandbox) *cobra.Command {
+	cfg := &config.SandboxExtend{Sandbox: sandbox}
+
+	cmd := &cobra.Command{
+		Use:   "extend NAME --by DURATION",
+		Short: "Extend the lifetime of a sandbox",
+		Long: `Extend the lifetime of a sandbox by updating its TTL.
+
+The rest of the sandbox spec is preserved. Sandboxes without a TTL live until
+deleted and cannot be extended.`,
+		Example: `  # Keep the sandbox my-sandbox for 2 more hours
+  signadot sandbox extend my-sandbox --by 2h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return extend(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+func extend(cfg *config.SandboxExtend, out, log io.Writer, name string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	params := sandboxes.NewGetSandboxParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithSandboxName(name)
+	resp, err := cfg.Client.Sandboxes.GetSandbox(params, nil)
+	if err != nil {
+		return err
+	}
+	current := resp.Payload
+	if current.Spec.TTL == nil {
+		return fmt.Errorf("sandbox %q has no TTL, it lives until deleted", name)
+	}
+	eol, err := sandboxExpiry(current)
+	if err != nil {
+		return fmt.Errorf("could not compute the expiry of sandbox %q: %v", name, err)
+	}
+
+	// The TTL is relative to either the creation or the last update of the
+	// sandbox, the latter being reset by the apply below.
+	now := time.Now()
+	base := now
+	if current.Spec.TTL.OffsetFrom == "createdAt" {
+		base, err = time.Parse(time.RFC3339, current.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("could not parse the creation time of sandbox %q: %w", name, err)
+		}
+	}
+	if eol.Before(now) {
+		eol = now
+	}
+	target := eol.Add(cfg.By)
+
+	req, err := builder.BuildSandbox(name, builder.WithData(*current)).Build()
+	if err != nil {
+		return err
+	}
+	req.Spec.TTL.Duration = formatTTLDuration(target.Sub(base))
+
+	applyParams := sandboxes.NewApplySandboxParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithSandboxName(name).
+		WithData(&req)
+	result, err := cfg.Client.Sandboxes.ApplySandbox(applyParams, nil)
+	if err != nil {
+		return err
+	}
+	updated := result.Payload
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		fmt.Fprintf(log, "Extended sandbox %q (TTL: %s from %s).\n\n",
+			name, req.Spec.TTL.Duration, req.Spec.TTL.OffsetFrom)
+		info, err := getTTLInfo(updated)
+		if err != nil {
+			return err
+		}
+		return printTTLInfo(out, info)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, updated)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, updated)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
@@ -18,14 +18,14 @@ func newGet(sandbox *config.Sandbox) *cobra.Command {
 		Short: "Get sandbox",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return get(cfg, cmd.OutOrStdout(), args[0])
+			return get(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
 		},
 	}
 
 	return cmd
 }
 
-func get(cfg *config.SandboxGet, out io.Writer, name string) error {
+func get(cfg *config.SandboxGet, out, errOut io.Writer, name string) error {
 	if err := cfg.InitAPIConfig(); err != nil {
 		return err
 	}
@@ -34,6 +34,7 @@ func get(cfg *config.SandboxGet, out io.Writer, name string) error {
 	if err != nil {
 		return err
 	}
+	defer warnNearExpiry(errOut, resp.Payload)
 
 	switch cfg.OutputFormat {
 	case config.OutputFormatDefault:
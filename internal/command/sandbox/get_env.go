@@ -46,54 +46,25 @@ func getEnv(cfg *config.SandboxGetEnv, out, errOut io.Writer, name string) error
 		return err
 	}
 	apiSB := resp.Payload
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-	// get resource outputs if needed
-	var resourceOutputs []sandboxmanager.ResourceOutput
-	if hasEnvResourceRefs(apiSB) {
-		resourceOutputs, err = sandboxmanager.GetResourceOutputs(ctx, apiSB.RoutingKey)
-		if err != nil {
-			return err
-		}
-	}
 	// get kube client
 	kc, err := local.GetLocalKubeClient()
 	if err != nil {
 		return err
 	}
-	// extract
-	containerEnv, sbLocal, err := extract(ctx, kc, apiSB, cfg.Local, cfg.Container)
-	if err != nil {
-		return err
-	}
-	// overrides
-	// 1. add downward API
-	sbEnv := append(sbLocal.Env, &models.SandboxEnvVar{
-		Name:  "SIGNADOT_SANDBOX_NAME",
-		Value: apiSB.Name,
-	}, &models.SandboxEnvVar{
-		Name:  "SIGNADOT_SANDBOX_ROUTING_KEY",
-		Value: apiSB.RoutingKey,
-	})
-
-	// 2. merge with baseline cluster values
-	resEnv, err := calculateOverrides(ctx, kc, *sbLocal.From.Namespace, resourceOutputs, containerEnv.Env, sbEnv)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	localEnv, err := resolveLocalEnv(ctx, kc, apiSB, cfg.Local, cfg.Container, localEnvParts{env: true})
 	if err != nil {
 		return err
 	}
 
 	// print errors
-	if err := printForbidden(errOut, containerEnv.Forbidden); err != nil {
+	if err := PrintLocalEnvWarnings(errOut, localEnv); err != nil {
 		return err
 	}
-	if err := printWarnings(errOut, containerEnv.Warnings); err != nil {
-		return err
-	}
-
-	resEnv = k8senv.ResolveEnv(ctx, resEnv)
 
 	// print output
-	return printEnv(out, cfg, resEnv)
+	return printEnv(out, cfg, localEnv.Env)
 }
 
 func printEnv(out io.Writer, cfg *config.SandboxGetEnv, resEnv []k8senv.EnvItem) error {
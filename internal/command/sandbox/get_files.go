@@ -58,25 +58,13 @@ func getFiles(cfg *config.SandboxGetFiles, out, errOut io.Writer, name string) e
 	if err != nil {
 		return err
 	}
-	// extract k8senv
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
-	k8sEnv, sbLocal, err := extract(ctx, kc, apiSB, cfg.Local, cfg.Container)
-	if err != nil {
-		return err
-	}
-
-	var resourceOutputs []sandboxmanager.ResourceOutput
-	if hasFileResourceOutput(apiSB) {
-		resourceOutputs, err = sandboxmanager.GetResourceOutputs(ctx, apiSB.RoutingKey)
-		if err != nil {
-			return err
-		}
-	}
-	err = calculateFileOverrides(ctx, kc, *sbLocal.From.Namespace, resourceOutputs, k8sEnv.Files, sbLocal.Files)
+	localEnv, err := resolveLocalEnv(ctx, kc, apiSB, cfg.Local, cfg.Container, localEnvParts{files: true})
 	if err != nil {
 		return err
 	}
+	files := localEnv.Files
 	if cfg.OutputDir == "" {
 		baseDir, err := system.GetSandboxLocalFilesBaseDir(name)
 		if err != nil {
@@ -102,30 +90,30 @@ func getFiles(cfg *config.SandboxGetFiles, out, errOut io.Writer, name string) e
 	}
 	// no-clobber
 	if cfg.NoClobber {
-		if _, err := noClobber(errOut, k8sEnv.Files, cfg.OutputDir); err != nil {
+		if _, err := noClobber(errOut, files, cfg.OutputDir); err != nil {
 			return err
 		}
 	}
 	// export
-	if err := k8sEnv.Files.ExportTo(cfg.OutputDir); err != nil {
+	if err := files.ExportTo(cfg.OutputDir); err != nil {
 		return err
 	}
 	// print
-	if err := printForbidden(errOut, k8sEnv.Forbidden); err != nil {
+	if err := printForbidden(errOut, localEnv.Forbidden); err != nil {
 		return err
 	}
-	k8sEnv.Files.Name = cfg.OutputDir
+	files.Name = cfg.OutputDir
 	switch cfg.OutputFormat {
 	case config.OutputFormatDefault:
 		w := tabwriter.NewWriter(os.Stdout, 0, 4, 1, ' ', tabwriter.TabIndent)
-		if err := printTree(w, k8sEnv.Files, cfg.OutputDir, []bool{}); err != nil {
+		if err := printTree(w, files, cfg.OutputDir, []bool{}); err != nil {
 			return err
 		}
 		return w.Flush()
 	case config.OutputFormatJSON:
-		return print.RawJSON(out, k8sEnv.Files)
+		return print.RawJSON(out, files)
 	case config.OutputFormatYAML:
-		return print.RawYAML(out, k8sEnv.Files)
+		return print.RawYAML(out, files)
 	default:
 		return fmt.Errorf("unknown output format %q", cfg.OutputFormat)
 	}
@@ -0,0 +1,411 @@
+package sandbox
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-rollouts/pkg/apis/rollouts"
+	rolloutapi "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	"github.com/signadot/cli/internal/builder"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/local"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/client/cluster"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// devMeshInjectAnnotation marks the pod templates of DevMesh enabled
+// workloads
+const devMeshInjectAnnotation = "sidecar.signadot.com/inject"
+
+func newInit(sandbox *config.Sandbox) *cobra.Command {
+	cfg := &config.SandboxInit{Sandbox: sandbox}
+
+	cmd := &cobra.Command{
+		Use:   "init --cluster CLUSTER [--fork NAMESPACE/NAME] [--local NAMESPACE/NAME[:PORT]]",
+		Short: "Generate a sandbox spec from the workloads of a cluster",
+		Long: `Generate a sandbox spec from the DevMesh enabled workloads of a cluster.
+
+The workloads to fork and to run locally are selected with --fork and --local,
+or interactively when neither is given and the input is a terminal. The
+generated spec uses the @{user} and @{cluster} placeholders, which are filled
+in by 'signadot sandbox apply --set'.
+
+Local workloads map each of their ports to the same port on localhost. Ports
+are taken from the workload containers with --from-kube, and must otherwise be
+given as NAMESPACE/NAME:PORT.`,
+		Example: `  # Fork frontend and run route locally on port 8083
+  signadot sandbox init --cluster staging --fork hotrod/frontend --local hotrod/route:8083 -f sandbox.yaml
+  signadot sandbox apply -f sandbox.yaml --set user=jane --set cluster=staging
+
+  # Pick the workloads interactively
+  signadot sandbox init --cluster staging --from-kube`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sbInit(cfg, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cfg.AddFlags(cmd)
+
+	return cmd
+}
+
+// initWorkload is a DevMesh enabled workload which can be forked or run
+// locally.
+type initWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Ports     []int64
+}
+
+func (w *initWorkload) String() string {
+	return w.Namespace + "/" + w.Name
+}
+
+func sbInit(cfg *config.SandboxInit, in io.Reader, out, log io.Writer) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var (
+		workloads []*initWorkload
+		err       error
+	)
+	if cfg.FromKube {
+		workloads, err = listKubeWorkloads(ctx, cfg.Namespace)
+	} else {
+		workloads, err = listDevMeshWorkloads(ctx, cfg)
+	}
+	if err != nil {
+		return err
+	}
+	if len(workloads) == 0 {
+		return fmt.Errorf("no DevMesh enabled workloads found in cluster %q", cfg.Cluster)
+	}
+
+	var (
+		forks  []*initWorkload
+		locals []*initWorkload
+	)
+	if len(cfg.Forks) == 0 && len(cfg.Locals) == 0 {
+		f, ok := in.(*os.File)
+		if !ok || !term.IsTerminal(int(f.Fd())) {
+			return fmt.Errorf("no workloads selected, use --fork or --local")
+		}
+		forks, locals, err = pickWorkloads(bufio.NewReader(in), log, workloads)
+	} else {
+		forks, locals, err = selectWorkloads(cfg, workloads)
+	}
+	if err != nil {
+		return err
+	}
+	if len(forks) == 0 && len(locals) == 0 {
+		return fmt.Errorf("no workloads selected")
+	}
+
+	spec, err := buildInitSpec(cfg, forks, locals)
+	if err != nil {
+		return err
+	}
+	if cfg.Filename == "" {
+		return print.RawK8SYAML(out, spec)
+	}
+	f, err := os.Create(cfg.Filename)
+	if err != nil {
+		return err
+	}
+	if err := print.RawK8SYAML(f, spec); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(log, "Wrote the sandbox spec to %s. To create the sandbox, run:\n\n", cfg.Filename)
+	fmt.Fprintf(log, "  signadot sandbox apply -f %s --set user=$USER --set cluster=%s\n\n", cfg.Filename, cfg.Cluster)
+	return nil
+}
+
+func listDevMeshWorkloads(ctx context.Context, cfg *config.SandboxInit) ([]*initWorkload, error) {
+	params := cluster.NewClusterDevmeshAnalyzeParams().
+		WithContext(ctx).WithOrgName(cfg.Org).WithClusterName(cfg.Cluster)
+	if cfg.Namespace != "" {
+		params = params.WithNamespace(&cfg.Namespace)
+	}
+	resp, err := cfg.Client.Cluster.ClusterDevmeshAnalyze(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*initWorkload, 0, len(resp.Payload))
+	for _, w := range resp.Payload {
+		if w.Workload == nil {
+			continue
+		}
+		res = append(res, &initWorkload{
+			Kind:      *w.Workload.Kind,
+			Namespace: *w.Workload.Namespace,
+			Name:      *w.Workload.Name,
+		})
+	}
+	sortWorkloads(res)
+	return res, nil
+}
+
+// listKubeWorkloads lists the DevMesh enabled deployments and rollouts with
+// the kube client of the local connection, along with their ports.
+func listKubeWorkloads(ctx context.Context, namespaces string) ([]*initWorkload, error) {
+	kc, err := local.GetLocalKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	nss := []string{""}
+	if namespaces != "" {
+		nss = strings.Split(namespaces, ",")
+	}
+	var res []*initWorkload
+	for _, ns := range nss {
+		deployments := &appsv1.DeploymentList{}
+		if err := kc.List(ctx, deployments, client.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		for i := range deployments.Items {
+			d := &deployments.Items[i]
+			if w := kubeWorkload("Deployment", d.Namespace, d.Name, &d.Spec.Template); w != nil {
+				res = append(res, w)
+			}
+		}
+		rolloutList := &rolloutapi.RolloutList{}
+		if err := kc.List(ctx, rolloutList, client.InNamespace(ns)); err != nil {
+			// argo rollouts may not be installed
+			continue
+		}
+		for i := range rolloutList.Items {
+			r := &rolloutList.Items[i]
+			if w := kubeWorkload(rollouts.RolloutKind, r.Namespace, r.Name, &r.Spec.Template); w != nil {
+				res = append(res, w)
+			}
+		}
+	}
+	sortWorkloads(res)
+	return res, nil
+}
+
+func kubeWorkload(kind, namespace, name string, tpl *corev1.PodTemplateSpec) *initWorkload {
+	if _, ok := tpl.Annotations[devMeshInjectAnnotation]; !ok {
+		return nil
+	}
+	w := &initWorkload{Kind: kind, Namespace: namespace, Name: name}
+	for _, c := range tpl.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Protocol == "" || p.Protocol == corev1.ProtocolTCP {
+				w.Ports = append(w.Ports, int64(p.ContainerPort))
+			}
+		}
+	}
+	return w
+}
+
+func sortWorkloads(ws []*initWorkload) {
+	sort.Slice(ws, func(i, j int) bool {
+		if ws[i].Namespace != ws[j].Namespace {
+			return ws[i].Namespace < ws[j].Namespace
+		}
+		return ws[i].Name < ws[j].Name
+	})
+}
+
+// selectWorkloads resolves the workloads given with --fork and --local.
+func selectWorkloads(cfg *config.SandboxInit, workloads []*initWorkload) ([]*initWorkload, []*initWorkload, error) {
+	var forks, locals []*initWorkload
+	for _, ref := range cfg.Forks {
+		w, err := findWorkload(cfg.Cluster, workloads, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		forks = append(forks, w)
+	}
+	for _, ref := range cfg.Locals {
+		ref, portStr, hasPort := strings.Cut(ref, ":")
+		w, err := findWorkload(cfg.Cluster, workloads, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasPort {
+			port, err := strconv.ParseUint(portStr, 10, 16)
+			if err != nil || port == 0 {
+				return nil, nil, fmt.Errorf("invalid port %q for local workload %s", portStr, ref)
+			}
+			lw := *w
+			lw.Ports = []int64{int64(port)}
+			w = &lw
+		}
+		if len(w.Ports) == 0 {
+			return nil, nil, fmt.Errorf("no ports known for local workload %s, specify one as %s:PORT", ref, ref)
+		}
+		locals = append(locals, w)
+	}
+	return forks, locals, nil
+}
+
+func findWorkload(clusterName string, workloads []*initWorkload, ref string) (*initWorkload, error) {
+	ns, name, ok := strings.Cut(ref, "/")
+	if !ok || ns == "" || name == "" {
+		return nil, fmt.Errorf("invalid workload %q, expected NAMESPACE/NAME", ref)
+	}
+	for _, w := range workloads {
+		if w.Namespace == ns && w.Name == name {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("workload %s is not DevMesh enabled in cluster %q", ref, clusterName)
+}
+
+// pickWorkloads lets the user pick the workloads to fork and run locally.
+func pickWorkloads(in *bufio.Reader, log io.Writer, workloads []*initWorkload) ([]*initWorkload, []*initWorkload, error) {
+	fmt.Fprintf(log, "DevMesh enabled workloads:\n\n")
+	for i, w := range workloads {
+		fmt.Fprintf(log, "  %3d  %-10s  %s\n", i+1, w.Kind, w)
+	}
+	fmt.Fprintln(log)
+
+	forks, err := pickIndexes(in, log, "Workloads to fork (e.g. 1,3): ", workloads)
+	if err != nil {
+		return nil, nil, err
+	}
+	picked, err := pickIndexes(in, log, "Workloads to run locally (e.g. 2): ", workloads)
+	if err != nil {
+		return nil, nil, err
+	}
+	locals := make([]*initWorkload, 0, len(picked))
+	for _, w := range picked {
+		lw := *w
+		prompt := fmt.Sprintf("Ports of %s to map to localhost", w)
+		if len(w.Ports) > 0 {
+			prompt += fmt.Sprintf(" [%s]", joinPorts(w.Ports))
+		}
+		for {
+			line, err := readLine(in, log, prompt+": ")
+			if err != nil {
+				return nil, nil, err
+			}
+			if line == "" && len(w.Ports) > 0 {
+				break
+			}
+			lw.Ports, err = parsePorts(line)
+			if err == nil {
+				break
+			}
+			fmt.Fprintf(log, "%v\n", err)
+		}
+		locals = append(locals, &lw)
+	}
+	return forks, locals, nil
+}
+
+func pickIndexes(in *bufio.Reader, log io.Writer, prompt string, workloads []*initWorkload) ([]*initWorkload, error) {
+	for {
+		line, err := readLine(in, log, prompt)
+		if err != nil {
+			return nil, err
+		}
+		res, err := parseIndexes(line, workloads)
+		if err == nil {
+			return res, nil
+		}
+		fmt.Fprintf(log, "%v\n", err)
+	}
+}
+
+func parseIndexes(line string, workloads []*initWorkload) ([]*initWorkload, error) {
+	var res []*initWorkload
+	for _, f := range strings.FieldsFunc(line, isListSep) {
+		i, err := strconv.Atoi(f)
+		if err != nil || i < 1 || i > len(workloads) {
+			return nil, fmt.Errorf("invalid workload number %q", f)
+		}
+		res = append(res, workloads[i-1])
+	}
+	return res, nil
+}
+
+func parsePorts(line string) ([]int64, error) {
+	var res []int64
+	for _, f := range strings.FieldsFunc(line, isListSep) {
+		port, err := strconv.ParseUint(f, 10, 16)
+		if err != nil || port == 0 {
+			return nil, fmt.Errorf("invalid port %q", f)
+		}
+		res = append(res, int64(port))
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("at least one port is needed")
+	}
+	return res, nil
+}
+
+func joinPorts(ports []int64) string {
+	strs := make([]string, len(ports))
+	for i, p := range ports {
+		strs[i] = strconv.FormatInt(p, 10)
+	}
+	return strings.Join(strs, ",")
+}
+
+func isListSep(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
+
+func readLine(in *bufio.Reader, log io.Writer, prompt string) (string, error) {
+	fmt.Fprint(log, prompt)
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// buildInitSpec builds the sandbox spec generated by init, as a template for
+// sandbox apply.
+func buildInitSpec(cfg *config.SandboxInit, forks, locals []*initWorkload) (*models.Sandbox, error) {
+	cluster := "@{cluster}"
+	b := builder.BuildSandbox(cfg.Name, builder.WithData(models.Sandbox{
+		Name: cfg.Name,
+		Spec: &models.SandboxSpec{
+			Cluster:     &cluster,
+			Description: "Sandbox of @{user}",
+		},
+	})).SetLabel(ownerLabel, "@{user}")
+	for _, w := range forks {
+		b.AddFork(w.Kind, w.Namespace, w.Name)
+	}
+	for _, w := range locals {
+		mappings := make([]*models.LocalMapping, 0, len(w.Ports))
+		for _, p := range w.Ports {
+			mappings = append(mappings, &models.LocalMapping{
+				Port:    p,
+				ToLocal: fmt.Sprintf("localhost:%d", p),
+			})
+		}
+		b.AddLocal(w.Kind, w.Namespace, w.Name, mappings)
+	}
+	sb, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &sb, nil
+}
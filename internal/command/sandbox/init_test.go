@@ -0,0 +1,170 @@
+package sandbox
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/signadot/cli/internal/config"
+)
+
+func testWorkloads() []*initWorkload {
+	return []*initWorkload{
+		{Kind: "Deployment", Namespace: "hotrod", Name: "frontend", Ports: []int64{8080}},
+		{Kind: "Deployment", Namespace: "hotrod", Name: "route"},
+		{Kind: "Rollout", Namespace: "hotrod", Name: "driver", Ports: []int64{8082, 9090}},
+	}
+}
+
+func workloadNames(ws []*initWorkload) []string {
+	res := make([]string, len(ws))
+	for i, w := range ws {
+		res[i] = w.String()
+	}
+	return res
+}
+
+func TestParseIndexes(t *testing.T) {
+	workloads := testWorkloads()
+	cases := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{line: "", want: []string{}},
+		{line: "1", want: []string{"hotrod/frontend"}},
+		{line: "3, 1", want: []string{"hotrod/driver", "hotrod/frontend"}},
+		{line: "1 2\t3", want: []string{"hotrod/frontend", "hotrod/route", "hotrod/driver"}},
+		{line: "0", wantErr: true},
+		{line: "4", wantErr: true},
+		{line: "1,x", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := parseIndexes(c.line, workloads)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%q: got error %v, want error: %v", c.line, err, c.wantErr)
+			continue
+		}
+		if !c.wantErr && !slices.Equal(workloadNames(got), c.want) {
+			t.Errorf("%q: got %v, want %v", c.line, workloadNames(got), c.want)
+		}
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	cases := []struct {
+		line    string
+		want    []int64
+		wantErr bool
+	}{
+		{line: "8080", want: []int64{8080}},
+		{line: "8080, 9090", want: []int64{8080, 9090}},
+		{line: "", wantErr: true},
+		{line: "0", wantErr: true},
+		{line: "65536", wantErr: true},
+		{line: "http", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := parsePorts(c.line)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%q: got error %v, want error: %v", c.line, err, c.wantErr)
+			continue
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%q: got %v, want %v", c.line, got, c.want)
+		}
+	}
+}
+
+func TestSelectWorkloads(t *testing.T) {
+	cases := []struct {
+		name       string
+		forks      []string
+		locals     []string
+		wantForks  []string
+		wantLocals []string
+		wantPorts  [][]int64
+		wantErr    bool
+	}{
+		{
+			name:       "forks and locals",
+			forks:      []string{"hotrod/frontend"},
+			locals:     []string{"hotrod/driver"},
+			wantForks:  []string{"hotrod/frontend"},
+			wantLocals: []string{"hotrod/driver"},
+			wantPorts:  [][]int64{{8082, 9090}},
+		},
+		{
+			name:       "local with a port",
+			locals:     []string{"hotrod/route:8083", "hotrod/driver:8082"},
+			wantLocals: []string{"hotrod/route", "hotrod/driver"},
+			wantPorts:  [][]int64{{8083}, {8082}},
+		},
+		{name: "local without ports", locals: []string{"hotrod/route"}, wantErr: true},
+		{name: "invalid port", locals: []string{"hotrod/route:0"}, wantErr: true},
+		{name: "unknown workload", forks: []string{"hotrod/location"}, wantErr: true},
+		{name: "missing namespace", forks: []string{"frontend"}, wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			workloads := testWorkloads()
+			cfg := &config.SandboxInit{Cluster: "staging", Forks: c.forks, Locals: c.locals}
+			forks, locals, err := selectWorkloads(cfg, workloads)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+			if !slices.Equal(workloadNames(forks), c.wantForks) {
+				t.Errorf("got forks %v, want %v", workloadNames(forks), c.wantForks)
+			}
+			if !slices.Equal(workloadNames(locals), c.wantLocals) {
+				t.Errorf("got locals %v, want %v", workloadNames(locals), c.wantLocals)
+			}
+			for i, l := range locals {
+				if !slices.Equal(l.Ports, c.wantPorts[i]) {
+					t.Errorf("local %s: got ports %v, want %v", l, l.Ports, c.wantPorts[i])
+				}
+			}
+			// the listed workloads are left untouched
+			if len(workloads[1].Ports) != 0 {
+				t.Errorf("workload ports modified: %v", workloads[1].Ports)
+			}
+		})
+	}
+}
+
+func TestBuildInitSpec(t *testing.T) {
+	workloads := testWorkloads()
+	route := *workloads[1]
+	route.Ports = []int64{8083}
+	cfg := &config.SandboxInit{Name: "my-sandbox"}
+	sb, err := buildInitSpec(cfg, workloads[:1], []*initWorkload{&route, workloads[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.Name != "my-sandbox" || *sb.Spec.Cluster != "@{cluster}" {
+		t.Errorf("got name %q, cluster %q", sb.Name, *sb.Spec.Cluster)
+	}
+	if sb.Spec.Labels[ownerLabel] != "@{user}" {
+		t.Errorf("got labels %v", sb.Spec.Labels)
+	}
+	if len(sb.Spec.Forks) != 1 || *sb.Spec.Forks[0].ForkOf.Name != "frontend" ||
+		*sb.Spec.Forks[0].ForkOf.Namespace != "hotrod" || *sb.Spec.Forks[0].ForkOf.Kind != "Deployment" {
+		t.Errorf("got forks %+v", sb.Spec.Forks)
+	}
+	if len(sb.Spec.Local) != 2 {
+		t.Fatalf("got %d locals, want 2", len(sb.Spec.Local))
+	}
+	driver := sb.Spec.Local[1]
+	if driver.Name != "local-driver" || *driver.From.Kind != "Rollout" {
+		t.Errorf("got local %q of kind %q", driver.Name, *driver.From.Kind)
+	}
+	var toLocal []string
+	for _, m := range driver.Mappings {
+		toLocal = append(toLocal, m.ToLocal)
+	}
+	if !slices.Equal(toLocal, []string{"localhost:8082", "localhost:9090"}) {
+		t.Errorf("got mappings %v", toLocal)
+	}
+}
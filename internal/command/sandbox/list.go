@@ -18,14 +18,14 @@ func newList(sandbox *config.Sandbox) *cobra.Command {
 		Short: "List sandboxes",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return list(cfg, cmd.OutOrStdout())
+			return list(cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
 		},
 	}
 
 	return cmd
 }
 
-func list(cfg *config.SandboxList, out io.Writer) error {
+func list(cfg *config.SandboxList, out, errOut io.Writer) error {
 	if err := cfg.InitAPIConfig(); err != nil {
 		return err
 	}
@@ -33,6 +33,7 @@ func list(cfg *config.SandboxList, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	defer warnNearExpiry(errOut, resp.Payload...)
 
 	switch cfg.OutputFormat {
 	case config.OutputFormatDefault:
@@ -0,0 +1,136 @@
+package sandbox
+
+import (
+	"context"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/signadot/cli/internal/locald/sandboxmanager"
+	"github.com/signadot/go-sdk/models"
+	"github.com/signadot/libconnect/common/k8senv"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LocalEnv is the environment and the files of a local workload in a
+// sandbox, as computed by get-env and get-files.
+type LocalEnv struct {
+	Env       []k8senv.EnvItem
+	Files     *k8senv.Files
+	Forbidden []k8senv.Forbidden
+	Warnings  []string
+}
+
+// localEnvParts selects the parts of a local environment to resolve, so that
+// get-env and get-files only fetch what they need and only fail on what they
+// print.
+type localEnvParts struct {
+	env   bool
+	files bool
+}
+
+// ResolveLocalEnv resolves the environment variables and the files of the
+// local workload localName (the first one if empty) of the given sandbox,
+// including the sandbox overrides and the resource outputs.
+func ResolveLocalEnv(ctx context.Context, kubeClient client.Client, sb *models.Sandbox, localName, containerName string) (*LocalEnv, error) {
+	return resolveLocalEnv(ctx, kubeClient, sb, localName, containerName, localEnvParts{env: true, files: true})
+}
+
+// resolveLocalEnv is like ResolveLocalEnv, but only resolves the given parts.
+// Env is nil unless parts.env is set, and Files is nil unless parts.files is
+// set.
+func resolveLocalEnv(ctx context.Context, kubeClient client.Client, sb *models.Sandbox, localName, containerName string, parts localEnvParts) (*LocalEnv, error) {
+	var (
+		resourceOutputs []sandboxmanager.ResourceOutput
+		err             error
+	)
+	if (parts.env && hasEnvResourceRefs(sb)) || (parts.files && hasFileResourceOutput(sb)) {
+		resourceOutputs, err = sandboxmanager.GetResourceOutputs(ctx, sb.RoutingKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	containerEnv, sbLocal, err := extract(ctx, kubeClient, sb, localName, containerName)
+	if err != nil {
+		return nil, err
+	}
+	ns := *sbLocal.From.Namespace
+	res := &LocalEnv{
+		Forbidden: containerEnv.Forbidden,
+		Warnings:  containerEnv.Warnings,
+	}
+
+	if parts.env {
+		// add downward API
+		sbEnv := make([]*models.SandboxEnvVar, 0, len(sbLocal.Env)+2)
+		sbEnv = append(sbEnv, sbLocal.Env...)
+		sbEnv = append(sbEnv, &models.SandboxEnvVar{
+			Name:  "SIGNADOT_SANDBOX_NAME",
+			Value: sb.Name,
+		}, &models.SandboxEnvVar{
+			Name:  "SIGNADOT_SANDBOX_ROUTING_KEY",
+			Value: sb.RoutingKey,
+		})
+		resEnv, err := calculateOverrides(ctx, kubeClient, ns, resourceOutputs, containerEnv.Env, sbEnv)
+		if err != nil {
+			return nil, err
+		}
+		res.Env = k8senv.ResolveEnv(ctx, resEnv)
+	}
+	if parts.files {
+		err = calculateFileOverrides(ctx, kubeClient, ns, resourceOutputs, containerEnv.Files, sbLocal.Files)
+		if err != nil {
+			return nil, err
+		}
+		res.Files = containerEnv.Files
+	}
+	return res, nil
+}
+
+// RewriteFilePaths returns a copy of env in which every value referring to a
+// path in files (as mounted in the container) is rewritten to point into dir,
+// where the files are expected to have been exported.
+func RewriteFilePaths(env []k8senv.EnvItem, files *k8senv.Files, dir string) []k8senv.EnvItem {
+	res := make([]k8senv.EnvItem, len(env))
+	copy(res, env)
+	if files == nil {
+		return res
+	}
+	for i := range res {
+		item := &res[i]
+		// container paths are always slash separated
+		if !strings.HasPrefix(item.Value, "/") {
+			continue
+		}
+		if !hasFilePath(files, item.Value) {
+			continue
+		}
+		item.Value = filepath.Join(dir, filepath.FromSlash(item.Value))
+	}
+	return res
+}
+
+func hasFilePath(files *k8senv.Files, p string) bool {
+	node := files
+	for _, elt := range strings.Split(path.Clean(p), "/") {
+		if elt == "" {
+			continue
+		}
+		child, ok := node.Children[elt]
+		if !ok {
+			return false
+		}
+		node = child
+	}
+	return node != files
+}
+
+// PrintLocalEnvWarnings prints the access and extraction warnings collected
+// while resolving a local environment.
+func PrintLocalEnvWarnings(out io.Writer, localEnv *LocalEnv) error {
+	if err := printForbidden(out, localEnv.Forbidden); err != nil {
+		return err
+	}
+	return printWarnings(out, localEnv.Warnings)
+}
@@ -0,0 +1,138 @@
+package sandbox
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/signadot/cli/internal/locald/sandboxmanager"
+	"github.com/signadot/go-sdk/models"
+	"github.com/signadot/libconnect/common/k8senv"
+)
+
+func TestCalculateOverrides(t *testing.T) {
+	xEnv := []k8senv.EnvItem{
+		{Name: "A", Value: "cluster-a"},
+		{Name: "B", Value: "cluster-b"},
+	}
+	sbEnv := []*models.SandboxEnvVar{
+		{Name: "C", Value: "sandbox-c"},
+		{Name: "B", Value: "sandbox-b"},
+		{Name: "D", Value: "sandbox-d"},
+	}
+	// constant values do not need a kube client
+	res, err := calculateOverrides(context.Background(), nil, "ns", nil, xEnv, sbEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ Name, Value string }{
+		{"A", "cluster-a"},
+		{"B", "sandbox-b"},
+		{"C", "sandbox-c"},
+		{"D", "sandbox-d"},
+	}
+	if len(res) != len(want) {
+		t.Fatalf("got %d env vars, want %d: %+v", len(res), len(want), res)
+	}
+	for i, w := range want {
+		if res[i].Name != w.Name || res[i].Value != w.Value {
+			t.Errorf("env[%d]: got %s=%s, want %s=%s", i, res[i].Name, res[i].Value, w.Name, w.Value)
+		}
+	}
+	if !res[1].Source.Override {
+		t.Errorf("overridden env var B not marked as override")
+	}
+}
+
+func TestCalculateFileOverrides(t *testing.T) {
+	files := &k8senv.Files{}
+	resOuts := []sandboxmanager.ResourceOutput{
+		{Resource: "db", Output: "host", Value: "db.local"},
+		{Resource: "db", Output: "port", Value: "5432"},
+		{Resource: "other", Output: "host", Value: "other.local"},
+	}
+	fileOps := []*models.SandboxFileOp{
+		{Path: "/etc/app/mode", Value: "debug"},
+		{Path: "/etc/db", ValueFrom: &models.SandboxFileOpValueFrom{
+			Resource: &models.SandboxFileOpValueFromResource{Name: "db"},
+		}},
+		{Path: "/etc/db-host", ValueFrom: &models.SandboxFileOpValueFrom{
+			Resource: &models.SandboxFileOpValueFromResource{Name: "db", OutputKey: "host"},
+		}},
+	}
+	err := calculateFileOverrides(context.Background(), nil, "ns", resOuts, files, fileOps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p, want := range map[string]string{
+		"/etc/app/mode": "debug",
+		"/etc/db/host":  "db.local",
+		"/etc/db/port":  "5432",
+		"/etc/db-host":  "db.local",
+	} {
+		if got := string(files.Path(p).Content); got != want {
+			t.Errorf("%s: got %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestRewriteFilePaths(t *testing.T) {
+	files := &k8senv.Files{}
+	files.Path("/etc/app/config.yaml").Content = []byte("x: 1")
+	env := []k8senv.EnvItem{
+		{Name: "CONFIG", Value: "/etc/app/config.yaml"},
+		{Name: "CONFIG_DIR", Value: "/etc/app"},
+		{Name: "OTHER", Value: "/etc/other"},
+		{Name: "ROOT", Value: "/"},
+		{Name: "PLAIN", Value: "etc/app/config.yaml"},
+	}
+	dir := filepath.Join("tmp", "files")
+	res := RewriteFilePaths(env, files, dir)
+	want := []string{
+		filepath.Join(dir, "etc", "app", "config.yaml"),
+		filepath.Join(dir, "etc", "app"),
+		"/etc/other",
+		"/",
+		"etc/app/config.yaml",
+	}
+	for i, w := range want {
+		if res[i].Value != w {
+			t.Errorf("%s: got %q, want %q", env[i].Name, res[i].Value, w)
+		}
+	}
+	if env[0].Value != "/etc/app/config.yaml" {
+		t.Errorf("RewriteFilePaths modified its input")
+	}
+	if got := RewriteFilePaths(env, nil, dir); got[0].Value != env[0].Value {
+		t.Errorf("nil files: got %q, want unchanged", got[0].Value)
+	}
+}
+
+func TestHasResourceRefs(t *testing.T) {
+	sb := func(local *models.Local) *models.Sandbox {
+		return &models.Sandbox{Spec: &models.SandboxSpec{Local: []*models.Local{local}}}
+	}
+	plain := sb(&models.Local{
+		Env:   []*models.SandboxEnvVar{{Name: "A", Value: "a"}},
+		Files: []*models.SandboxFileOp{{Path: "/a", Value: "a"}},
+	})
+	if hasEnvResourceRefs(plain) || hasFileResourceOutput(plain) {
+		t.Errorf("constant values reported as resource refs")
+	}
+	envRef := sb(&models.Local{
+		Env: []*models.SandboxEnvVar{{Name: "A", ValueFrom: &models.SandboxEnvValueFrom{
+			Resource: &models.SandboxEnvValueFromResource{Name: "db", OutputKey: "host"},
+		}}},
+	})
+	if !hasEnvResourceRefs(envRef) || hasFileResourceOutput(envRef) {
+		t.Errorf("env resource ref not detected")
+	}
+	fileRef := sb(&models.Local{
+		Files: []*models.SandboxFileOp{{Path: "/a", ValueFrom: &models.SandboxFileOpValueFrom{
+			Resource: &models.SandboxFileOpValueFromResource{Name: "db"},
+		}}},
+	})
+	if hasEnvResourceRefs(fileRef) || !hasFileResourceOutput(fileRef) {
+		t.Errorf("file resource ref not detected")
+	}
+}
@@ -3,7 +3,6 @@ package sandbox
 import (
 	"fmt"
 	"io"
-	"strconv"
 	"text/tabwriter"
 	"time"
 
@@ -81,49 +80,13 @@ func readiness(status *models.SandboxReadiness) string {
 }
 
 func formatTTL(sb *models.Sandbox) string {
-	ttl := sb.Spec.TTL
-	if ttl == nil {
+	if sb.Spec.TTL == nil {
 		return "- (forever)"
 	}
-	var (
-		ttlBase time.Time
-		err     error
-	)
-	switch ttl.OffsetFrom {
-	case "updatedAt":
-		ttlBase, err = time.Parse(time.RFC3339, sb.UpdatedAt)
-		if err != nil {
-			return fmt.Sprintf("?(e parse-updated-at %q)", sb.UpdatedAt)
-		}
-	case "createdAt":
-		ttlBase, err = time.Parse(time.RFC3339, sb.CreatedAt)
-		if err != nil {
-			return fmt.Sprintf("?(e parse-created-at %q)", sb.CreatedAt)
-		}
-	default:
-		return fmt.Sprintf("?(bad ttl offset %q)", ttl.OffsetFrom)
-	}
-	n := len(ttl.Duration)
-	count, unit := ttl.Duration[0:n-1], ttl.Duration[n-1:]
-	m, err := strconv.ParseInt(count, 10, 32)
+	eol, err := sandboxExpiry(sb)
 	if err != nil {
-		return "?(e parse dur)"
-	}
-	if m < 0 {
-		return "?(e negative dur)"
-	}
-	offset := time.Duration(m)
-	switch unit {
-	case "m":
-		offset *= time.Minute
-	case "h":
-		offset *= time.Hour
-	case "d":
-		offset *= 24 * time.Hour
-	case "w":
-		offset *= 24 * 7 * time.Hour
+		return fmt.Sprintf("?(%v)", err)
 	}
-	eol := ttlBase.Add(offset)
 	local := eol.Local().Format(time.RFC1123)
 	remaining := time.Until(eol)
 	return fmt.Sprintf("%s (%s)", local, units.HumanDuration(remaining))
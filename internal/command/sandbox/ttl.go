@@ -0,0 +1,200 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/client/sandboxes"
+	"github.com/signadot/go-sdk/models"
+	"github.com/spf13/cobra"
+)
+
+// expiryWarningThreshold is how close to its end of life a sandbox has to be
+// for sandbox get and list to warn about it.
+const expiryWarningThreshold = time.Hour
+
+func newTTL(sandbox *config.Sandbox) *cobra.Command {
+	cfg := &config.SandboxTTL{Sandbox: sandbox}
+
+	cmd := &cobra.Command{
+		Use:   "ttl NAME",
+		Short: "Show when a sandbox expires",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showTTL(cfg, cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+type sandboxTTLInfo struct {
+	Name       string     `json:"name"`
+	Duration   string     `json:"duration,omitempty"`
+	OffsetFrom string     `json:"offsetFrom,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	Remaining  string     `json:"remaining,omitempty"`
+}
+
+func showTTL(cfg *config.SandboxTTL, out io.Writer, name string) error {
+	if err := cfg.InitAPIConfig(); err != nil {
+		return err
+	}
+	params := sandboxes.NewGetSandboxParams().WithOrgName(cfg.Org).WithSandboxName(name)
+	resp, err := cfg.Client.Sandboxes.GetSandbox(params, nil)
+	if err != nil {
+		return err
+	}
+	info, err := getTTLInfo(resp.Payload)
+	if err != nil {
+		return err
+	}
+
+	switch cfg.OutputFormat {
+	case config.OutputFormatDefault:
+		return printTTLInfo(out, info)
+	case config.OutputFormatJSON:
+		return print.RawJSON(out, info)
+	case config.OutputFormatYAML:
+		return print.RawYAML(out, info)
+	default:
+		return fmt.Errorf("unsupported output format: %q", cfg.OutputFormat)
+	}
+}
+
+func getTTLInfo(sb *models.Sandbox) (*sandboxTTLInfo, error) {
+	info := &sandboxTTLInfo{Name: sb.Name}
+	if sb.Spec.TTL == nil {
+		return info, nil
+	}
+	eol, err := sandboxExpiry(sb)
+	if err != nil {
+		return nil, err
+	}
+	info.Duration = sb.Spec.TTL.Duration
+	info.OffsetFrom = sb.Spec.TTL.OffsetFrom
+	info.ExpiresAt = &eol
+	info.Remaining = units.HumanDuration(time.Until(eol))
+	return info, nil
+}
+
+func printTTLInfo(out io.Writer, info *sandboxTTLInfo) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(tw, "Name:\t%s\n", info.Name)
+	if info.ExpiresAt == nil {
+		fmt.Fprintf(tw, "TTL:\t- (forever)\n")
+		return tw.Flush()
+	}
+	fmt.Fprintf(tw, "TTL:\t%s from %s\n", info.Duration, info.OffsetFrom)
+	fmt.Fprintf(tw, "Expires:\t%s\n", info.ExpiresAt.Local().Format(time.RFC1123))
+	if time.Until(*info.ExpiresAt) <= 0 {
+		fmt.Fprintf(tw, "Remaining:\t- (expired)\n")
+	} else {
+		fmt.Fprintf(tw, "Remaining:\t%s\n", info.Remaining)
+	}
+
+	return tw.Flush()
+}
+
+// warnNearExpiry warns about the given sandboxes expiring within
+// expiryWarningThreshold.
+func warnNearExpiry(errOut io.Writer, sbs ...*models.Sandbox) {
+	for _, sb := range sbs {
+		if sb.Spec == nil || sb.Spec.TTL == nil {
+			continue
+		}
+		eol, err := sandboxExpiry(sb)
+		if err != nil {
+			continue
+		}
+		remaining := time.Until(eol)
+		if remaining <= 0 || remaining > expiryWarningThreshold {
+			continue
+		}
+		fmt.Fprintf(errOut, "WARNING: sandbox %q expires in %s, to keep it run: signadot sandbox extend %s --by 2h\n",
+			sb.Name, units.HumanDuration(remaining), sb.Name)
+	}
+}
+
+// sandboxExpiry returns the end of life of a sandbox with a TTL.
+func sandboxExpiry(sb *models.Sandbox) (time.Time, error) {
+	ttl := sb.Spec.TTL
+	var (
+		ttlBase time.Time
+		err     error
+	)
+	switch ttl.OffsetFrom {
+	case "updatedAt":
+		ttlBase, err = time.Parse(time.RFC3339, sb.UpdatedAt)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("e parse-updated-at %q", sb.UpdatedAt)
+		}
+	case "createdAt":
+		ttlBase, err = time.Parse(time.RFC3339, sb.CreatedAt)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("e parse-created-at %q", sb.CreatedAt)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("bad ttl offset %q", ttl.OffsetFrom)
+	}
+	offset, err := parseTTLDuration(ttl.Duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ttlBase.Add(offset), nil
+}
+
+// parseTTLDuration parses a sandbox TTL duration, a count of minutes (m),
+// hours (h), days (d) or weeks (w).
+func parseTTLDuration(d string) (time.Duration, error) {
+	n := len(d)
+	if n < 2 {
+		return 0, errors.New("e parse dur")
+	}
+	count, unit := d[0:n-1], d[n-1:]
+	m, err := strconv.ParseInt(count, 10, 32)
+	if err != nil {
+		return 0, errors.New("e parse dur")
+	}
+	if m < 0 {
+		return 0, errors.New("e negative dur")
+	}
+	offset := time.Duration(m)
+	switch unit {
+	case "m":
+		offset *= time.Minute
+	case "h":
+		offset *= time.Hour
+	case "d":
+		offset *= 24 * time.Hour
+	case "w":
+		offset *= 24 * 7 * time.Hour
+	default:
+		return 0, fmt.Errorf("e bad dur unit %q", unit)
+	}
+	return offset, nil
+}
+
+// formatTTLDuration formats d as a sandbox TTL duration, rounded up to the
+// minute, in the largest unit representing it exactly.
+func formatTTLDuration(d time.Duration) string {
+	mins := int64((d + time.Minute - 1) / time.Minute)
+	switch {
+	case mins%(7*24*60) == 0:
+		return fmt.Sprintf("%dw", mins/(7*24*60))
+	case mins%(24*60) == 0:
+		return fmt.Sprintf("%dd", mins/(24*60))
+	case mins%60 == 0:
+		return fmt.Sprintf("%dh", mins/60)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
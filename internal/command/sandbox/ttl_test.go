@@ -0,0 +1,35 @@
+package sandbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLDurationRoundTrip(t *testing.T) {
+	cases := []struct {
+		Duration time.Duration
+		Result   string
+	}{
+		{Duration: 30 * time.Minute, Result: "30m"},
+		{Duration: 90 * time.Minute, Result: "90m"},
+		{Duration: 2 * time.Hour, Result: "2h"},
+		{Duration: 2*time.Hour + time.Second, Result: "121m"},
+		{Duration: 48 * time.Hour, Result: "2d"},
+		{Duration: 14 * 24 * time.Hour, Result: "2w"},
+	}
+	for _, c := range cases {
+		got := formatTTLDuration(c.Duration)
+		if got != c.Result {
+			t.Errorf("formatTTLDuration(%s): got %q want %q", c.Duration, got, c.Result)
+			continue
+		}
+		d, err := parseTTLDuration(got)
+		if err != nil {
+			t.Errorf("parseTTLDuration(%q): %v", got, err)
+			continue
+		}
+		if d < c.Duration || d-c.Duration >= time.Minute {
+			t.Errorf("parseTTLDuration(%q): got %s want about %s", got, d, c.Duration)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package smarttest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/go-sdk/models"
+)
+
+// TestRunSuite returns the test report of the executions of a test run.
+func TestRunSuite(name string, txs []*models.TestExecution) report.Suite {
+	suite := report.Suite{Name: name}
+	var first, last string
+	for _, tx := range txs {
+		suite.Cases = append(suite.Cases, TestExecutionCase(tx))
+		if first == "" || tx.CreatedAt < first {
+			first = tx.CreatedAt
+		}
+		if tx.Status != nil && tx.Status.FinishedAt > last {
+			last = tx.Status.FinishedAt
+		}
+	}
+	suite.Timestamp = report.ParseTime(first)
+	suite.Time = report.Between(first, last)
+	return suite
+}
+
+// TestExecutionCase returns the test case of a test execution, failing if
+// the execution failed or any of its checks did.
+func TestExecutionCase(tx *models.TestExecution) report.Case {
+	c := report.Case{Classname: "smarttest"}
+	if tx.Spec != nil {
+		switch {
+		case tx.Spec.External != nil:
+			c.Name = tx.Spec.External.TestName
+			if tx.Spec.External.Path != "" {
+				c.Classname = tx.Spec.External.Path
+			}
+		case tx.Spec.Hosted != nil:
+			c.Name = tx.Spec.Hosted.TestName
+			c.Classname = "hosted"
+		}
+	}
+	if c.Name == "" {
+		c.Name = tx.ID
+	}
+	if tx.Status == nil {
+		c.Status = report.StatusError
+		c.Message = "test execution has no status"
+		return c
+	}
+	c.Time = report.Between(tx.CreatedAt, tx.Status.FinishedAt)
+	c.Output = testExecutionOutput(tx)
+
+	switch tx.Status.Phase {
+	case models.TestexecutionsPhaseSucceeded:
+		c.Status = report.StatusPassed
+		if msg := failedChecks(tx); msg != "" {
+			c.Status = report.StatusFailed
+			c.Message = msg
+		}
+	case models.TestexecutionsPhaseFailed:
+		c.Status = report.StatusFailed
+		c.Message = "test execution failed"
+		if fs := tx.Status.FinalState; fs != nil && fs.Failed != nil && fs.Failed.Message != "" {
+			c.Message = fs.Failed.Message
+		}
+	case models.TestexecutionsPhaseCanceled:
+		c.Status = report.StatusError
+		c.Message = "test execution canceled"
+		if fs := tx.Status.FinalState; fs != nil && fs.Canceled != nil && fs.Canceled.Message != "" {
+			c.Message = fs.Canceled.Message
+		}
+	default:
+		c.Status = report.StatusError
+		c.Message = fmt.Sprintf("test execution did not finish (%s)", tx.Status.Phase)
+	}
+	return c
+}
+
+// testExecutionOutput returns what a test execution captured, as the output
+// of its test case: test executions have no logs in the API, but the results
+// of their checks and the summary of their traffic diff.
+func testExecutionOutput(tx *models.TestExecution) string {
+	if tx.Results == nil {
+		return ""
+	}
+	var out strings.Builder
+	if cks := tx.Results.Checks; cks != nil {
+		for _, ck := range cks.Sandbox {
+			if len(ck.Errors) == 0 {
+				fmt.Fprintf(&out, "check %s: passed\n", ck.Name)
+				continue
+			}
+			for _, e := range ck.Errors {
+				fmt.Fprintf(&out, "check %s: failed: %v\n", ck.Name, e)
+			}
+		}
+	}
+	if diff := tx.Results.TrafficDiff; diff != nil {
+		captures, red, yellow, green := diffCounts(diff)
+		fmt.Fprintf(&out, "traffic diff: %d captured requests, %d high, %d medium and %d low relevance differences\n",
+			captures, red, yellow, green)
+	}
+	return out.String()
+}
+
+// failedChecks describes the failed sandbox checks of a test execution, or
+// returns "" if none failed.
+func failedChecks(tx *models.TestExecution) string {
+	if tx.Results == nil || tx.Results.Checks == nil {
+		return ""
+	}
+	passed, failed := checksPassedFailed(tx.Results.Checks)
+	if failed == 0 {
+		return ""
+	}
+	var errs []string
+	for _, ck := range tx.Results.Checks.Sandbox {
+		for _, e := range ck.Errors {
+			errs = append(errs, fmt.Sprintf("%s: %v", ck.Name, e))
+		}
+	}
+	return fmt.Sprintf("%d of %d checks failed\n%s", failed, passed+failed, strings.Join(errs, "\n"))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/signadot/cli/internal/config"
 	"github.com/signadot/cli/internal/repoconfig"
+	"github.com/signadot/cli/internal/report"
 	clusters "github.com/signadot/go-sdk/client/cluster"
 	routegroups "github.com/signadot/go-sdk/client/route_groups"
 	"github.com/signadot/go-sdk/client/sandboxes"
@@ -89,11 +90,15 @@ func run(ctx context.Context, cfg *config.SmartTestRun, wOut, wErr io.Writer,
 			// render the test executions summary
 			out.renderTestXsSummary(txs)
 		}
-		return nil
+	} else {
+		// render the structured output
+		if err := structuredOutput(cfg, wOut, runID, txs); err != nil {
+			return err
+		}
 	}
 
-	// render the structured output
-	return structuredOutput(cfg, wOut, runID, txs)
+	// write the test reports
+	return report.Write(cfg.Reports, TestRunSuite("smart tests run "+runID, txs))
 }
 
 func validateRun(cfg *config.SmartTestRun) error {
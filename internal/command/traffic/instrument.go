@@ -38,7 +38,7 @@ func ensureTrafficWatchMW(ctx context.Context, cfg *config.TrafficWatch,
 	if sb.Spec.Labels == nil {
 		sb.Spec.Labels = map[string]string{}
 	}
-	devboxID, err := devbox.GetID(ctx, cfg.API, false, "")
+	devboxID, err := instrumentingDevboxID(ctx, cfg)
 	if err != nil {
 		return noOpUndo, err
 	}
@@ -49,6 +49,16 @@ func ensureTrafficWatchMW(ctx context.Context, cfg *config.TrafficWatch,
 	return mkUndo(cfg), nil
 }
 
+// instrumentingDevboxID returns the ID of the devbox of the local session, or
+// the one of this machine if not connected.
+func instrumentingDevboxID(ctx context.Context, cfg *config.TrafficWatch) (string, error) {
+	status, err := sbmgr.GetStatus()
+	if err == nil && status.DevboxSession != nil {
+		return status.DevboxSession.DevboxId, nil
+	}
+	return devbox.GetID(ctx, cfg.API, false, "")
+}
+
 func removeTrafficWatch(sb *models.Sandbox) {
 	j := 0
 	for _, mw := range sb.Spec.Middleware {
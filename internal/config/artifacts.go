@@ -8,21 +8,61 @@ type Artifact struct {
 	*API
 }
 
+// ArtifactJob holds the flags selecting the job attempt whose artifacts to
+// access.
+type ArtifactJob struct {
+	Job     string
+	Attempt int64
+}
+
+func (c *ArtifactJob) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&c.Job, "job", "j", "", "job name where to get the attempt artifact")
+	cmd.MarkFlagRequired("job")
+
+	cmd.Flags().Int64VarP(&c.Attempt, "attempt", "a", 0, "number of the attempt to get")
+}
+
+type ArtifactList struct {
+	*Artifact
+	ArtifactJob
+}
+
+func (c *ArtifactList) AddFlags(cmd *cobra.Command) {
+	c.ArtifactJob.AddFlags(cmd)
+}
+
 type ArtifactDownload struct {
 	*Artifact
+	ArtifactJob
 
 	// Flags
-	Job        string
 	OutputFile string
-
-	//Attempt int64
+	All        bool
+	Dir        string
+	Parallel   int
 }
 
 func (c *ArtifactDownload) AddFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&c.Job, "job", "j", "", "job name where to get the attempt artifact")
-	cmd.MarkFlagRequired("job")
+	c.ArtifactJob.AddFlags(cmd)
 
 	cmd.Flags().StringVarP(&c.OutputFile, "output", "o", "", "path where the file would be downloaded")
 
-	//cmd.Flags().Int64VarP(&c.Attempt, "attempt", "a", 0, "number of the attempt to get")
+	cmd.Flags().BoolVar(&c.All, "all", false, "download all the artifacts of the attempt (requires --dir)")
+	cmd.Flags().StringVar(&c.Dir, "dir", "", "directory to download the artifacts to (requires --all)")
+	cmd.Flags().IntVar(&c.Parallel, "parallel", 4, "maximum number of artifacts downloaded at once (with --all)")
+	cmd.MarkFlagsMutuallyExclusive("all", "output")
+}
+
+type ArtifactCat struct {
+	*Artifact
+	ArtifactJob
+
+	// Flags
+	Raw bool
+}
+
+func (c *ArtifactCat) AddFlags(cmd *cobra.Command) {
+	c.ArtifactJob.AddFlags(cmd)
+
+	cmd.Flags().BoolVar(&c.Raw, "raw", false, "write the artifact as-is, without rendering it for the terminal")
 }
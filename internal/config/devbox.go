@@ -1,12 +1,25 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
 // Devbox contains configuration for devbox commands.
 type Devbox struct {
 	*API
+
+	// Flags
+	IDFile   string
+	Identity string
+}
+
+// AddFlags adds the flags selecting the devbox identity, shared by all
+// devbox commands.
+func (c *Devbox) AddFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&c.IDFile, "id-file", "", "file holding the devbox ID of this machine (default $SIGNADOT_DEVBOX_ID_FILE or ~/.signadot/.devbox-id)")
+	cmd.PersistentFlags().StringVar(&c.Identity, "identity", "", "named devbox identity, for several devboxes on one machine (default $SIGNADOT_DEVBOX_IDENTITY)")
 }
 
 // DevboxList contains configuration for listing devboxes.
@@ -43,3 +56,25 @@ type DevboxDelete struct {
 func (c *DevboxDelete) AddFlags(cmd *cobra.Command) {
 	// No flags currently
 }
+
+// DevboxShow contains configuration for showing a devbox.
+type DevboxShow struct {
+	*Devbox
+	ID string
+}
+
+// DevboxPrune contains configuration for pruning idle devboxes.
+type DevboxPrune struct {
+	*Devbox
+
+	// Flags
+	Idle   time.Duration
+	DryRun bool
+}
+
+// AddFlags adds flags for devbox prune command.
+func (c *DevboxPrune) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().Var((*longDuration)(&c.Idle), "idle", "delete the devboxes idle for at least this long, like 12h, 7d or 2w")
+	cmd.MarkFlagRequired("idle")
+	cmd.Flags().BoolVar(&c.DryRun, "dry-run", false, "only list the devboxes that would be deleted")
+}
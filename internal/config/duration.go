@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// longDuration is a time.Duration flag which also accepts days (d) and weeks
+// (w).
+type longDuration time.Duration
+
+func (d *longDuration) String() string {
+	if *d == 0 {
+		return ""
+	}
+	return time.Duration(*d).String()
+}
+
+func (d *longDuration) Set(s string) error {
+	v, err := parseLongDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = longDuration(v)
+	return nil
+}
+
+func (d *longDuration) Type() string {
+	return "duration"
+}
+
+// parseLongDuration parses a duration like time.ParseDuration, additionally
+// accepting a number of days (7d) or weeks (2w).
+func parseLongDuration(s string) (time.Duration, error) {
+	var v time.Duration
+	if n := len(s); n > 1 && (s[n-1] == 'd' || s[n-1] == 'w') {
+		count, err := strconv.ParseUint(s[:n-1], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		unit := 24 * time.Hour
+		if s[n-1] == 'w' {
+			unit *= 7
+		}
+		v = time.Duration(count) * unit
+	} else {
+		var err error
+		if v, err = time.ParseDuration(s); err != nil {
+			return 0, err
+		}
+	}
+	if v <= 0 {
+		return 0, errors.New("duration must be positive")
+	}
+	return v, nil
+}
@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/signadot/cli/internal/report"
+	"github.com/spf13/cobra"
 )
 
 type OutputFormat string
@@ -76,3 +80,40 @@ func (tvs *TemplateVals) String() string {
 	}
 	return b.String()
 }
+
+// KeyValueMap holds the values of a repeatable flag in key=value form, a
+// later value replacing an earlier one with the same key.
+type KeyValueMap map[string]string
+
+func (m KeyValueMap) String() string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+m[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements the pflag.Value interface.
+func (m KeyValueMap) Set(v string) error {
+	key, val, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("%q should be in form <key>=<value>", v)
+	}
+	m[key] = val
+	return nil
+}
+
+// Type implements the pflag.Value interface.
+func (m KeyValueMap) Type() string {
+	return "key=value"
+}
+
+// AddReportFlag adds the repeatable --report flag.
+func AddReportFlag(cmd *cobra.Command, rs *report.Reports) {
+	cmd.Flags().Var(rs, "report", "write a test report in FORMAT[=PATH] form, FORMAT being junit (to report.xml by default) or tap (to report.tap by default) (can be repeated)")
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyValueMapSet(t *testing.T) {
+	m := KeyValueMap{}
+	for _, v := range []string{"team=payments", "owner=alice", "empty=", "team=checkout"} {
+		if err := m.Set(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := KeyValueMap{"team": "checkout", "owner": "alice", "empty": ""}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("got %v, want %v", m, want)
+	}
+	if got := m.String(); got != "empty=,owner=alice,team=checkout" {
+		t.Errorf("got %q", got)
+	}
+	for _, v := range []string{"team", "=value"} {
+		if err := m.Set(v); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package config
 
-import "github.com/spf13/cobra"
+import (
+	"errors"
+	"time"
+
+	"github.com/signadot/cli/internal/report"
+	"github.com/spf13/cobra"
+)
 
 type HostedTest struct {
 	*API
@@ -42,10 +48,23 @@ type HostedTestRun struct {
 	Cluster    string
 	Sandbox    string
 	RouteGroup string
+	Wait       bool
+	Timeout    time.Duration
+	Reports    report.Reports
 }
 
 func (cfg *HostedTestRun) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&cfg.Cluster, "cluster", "c", "", "cluster name (required for test execution)")
 	cmd.Flags().StringVarP(&cfg.Sandbox, "sandbox", "s", "", "sandbox")
 	cmd.Flags().StringVarP(&cfg.RouteGroup, "routegroup", "r", "", "routegroup")
+	cmd.Flags().BoolVar(&cfg.Wait, "wait", false, "wait until the test execution is completed")
+	cmd.Flags().DurationVar(&cfg.Timeout, "timeout", 0, "timeout when waiting for the test execution, if 0 is specified, no timeout will be applied (default 0)")
+	AddReportFlag(cmd, &cfg.Reports)
+}
+
+func (cfg *HostedTestRun) Validate() error {
+	if len(cfg.Reports) > 0 && !cfg.Wait {
+		return errors.New("--report requires --wait")
+	}
+	return nil
 }
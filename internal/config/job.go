@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/signadot/cli/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +20,7 @@ type JobSubmit struct {
 	Timeout      time.Duration
 	TemplateVals TemplateVals
 	Wait         bool
+	Reports      report.Reports
 }
 
 func (c *JobSubmit) AddFlags(cmd *cobra.Command) {
@@ -28,6 +30,7 @@ func (c *JobSubmit) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&c.Attach, "attach", false, "waits until the job is completed, displaying the stdout and stderr streams")
 	cmd.Flags().DurationVar(&c.Timeout, "timeout", 0, "timeout when waiting for the job, if 0 is specified, no timeout will be applied and the command will wait until completion or cancellation of the job (default 0)")
 	cmd.Flags().BoolVar(&c.Wait, "wait", false, "waits until the job is completed")
+	AddReportFlag(cmd, &c.Reports)
 }
 
 type JobDelete struct {
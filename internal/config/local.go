@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/signadot/cli/internal/builder"
 	"github.com/signadot/libconnect/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -100,15 +101,16 @@ type LocalConnect struct {
 	*Local
 
 	// Flags
-	Cluster      string
-	Unprivileged bool
-	Wait         ConnectWait
-	WaitTimeout  time.Duration
+	Cluster        string
+	Unprivileged   bool
+	Wait           ConnectWait
+	WaitTimeout    time.Duration
+	Devbox         string
+	DevboxIdentity string
 
 	// Hidden Flags
 	DumpCIConfig bool
 	PProfAddr    string
-	Devbox       string
 }
 
 func (c *LocalConnect) AddFlags(cmd *cobra.Command) {
@@ -127,8 +129,8 @@ func (c *LocalConnect) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().MarkHidden("gops-root-addr")
 	cmd.Flags().StringVar(&c.GOPSAddrNonRoot, "gops-non-root-addr", "", "gops root address")
 	cmd.Flags().MarkHidden("gops-non-root-addr")
-	cmd.Flags().StringVar(&c.Devbox, "devbox", "", "specify devbox ID to use for this connection")
-	cmd.Flags().MarkHidden("devbox")
+	cmd.Flags().StringVar(&c.Devbox, "devbox", "", "devbox ID to use for this connection, instead of the one of this machine (see 'signadot devbox list')")
+	cmd.Flags().StringVar(&c.DevboxIdentity, "devbox-identity", "", "named devbox identity to use for this connection (default $SIGNADOT_DEVBOX_IDENTITY, see 'signadot devbox --help')")
 }
 
 type ConnectWait int
@@ -198,16 +200,31 @@ type LocalProxy struct {
 	*Local
 
 	// Flags
+	Filename      string
 	Sandbox       string
 	RouteGroup    string
 	Cluster       string
 	ProxyMappings []ProxyMapping
+	ForwardAddr   string
+	Detach        bool
 
 	// Hidden Flags
 	PProfAddr string
 }
 
 func (lp *LocalProxy) Validate() error {
+	if lp.Detach && lp.ForwardAddr != "" {
+		return errors.New("'--forward' cannot be used with '--detach'")
+	}
+	if lp.Filename != "" {
+		if lp.Sandbox != "" || lp.RouteGroup != "" || lp.Cluster != "" || len(lp.ProxyMappings) > 0 || lp.ForwardAddr != "" {
+			return errors.New("'--filename' cannot be combined with '--sandbox', '--routegroup', '--cluster', '--map' or '--forward'")
+		}
+		return nil
+	}
+	if lp.ForwardAddr != "" && len(lp.ProxyMappings) > 0 {
+		return errors.New("'--forward' cannot be combined with '--map'")
+	}
 	c := 0
 	if lp.Sandbox != "" {
 		c += 1
@@ -285,18 +302,27 @@ func (pms *proxyMappings) String() string {
 
 // Set appends a new argument  to instance of Nargs
 func (pms *proxyMappings) Set(arg string) error {
+	pm, err := ParseProxyMapping(arg)
+	if err != nil {
+		return err
+	}
+	*pms = append(*pms, *pm)
+	return nil
+}
+
+// ParseProxyMapping parses a mapping in the format of the --map flag.
+func ParseProxyMapping(arg string) (*ProxyMapping, error) {
 	regex := regexp.MustCompile(`^(.+?)://(.+?)\@(.+)$`)
 	matches := regex.FindStringSubmatch(arg)
 	if matches == nil || len(matches) != 4 {
-		return fmt.Errorf("invalid format, expected \"<target-protocol>://<target-addr>@<bind-addr>\"")
+		return nil, fmt.Errorf("invalid format, expected \"<target-protocol>://<target-addr>@<bind-addr>\"")
 	}
 
-	*pms = append(*pms, ProxyMapping{
+	return &ProxyMapping{
 		TargetProto: matches[1],
 		TargetAddr:  matches[2],
 		BindAddr:    matches[3],
-	})
-	return nil
+	}, nil
 }
 
 // Type is a no-op
@@ -305,14 +331,59 @@ func (pms *proxyMappings) Type() string {
 }
 
 func (lp *LocalProxy) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&lp.Filename, "filename", "f", "", "YAML file declaring the proxies, reloaded when it changes")
 	cmd.Flags().StringVarP(&lp.Sandbox, "sandbox", "s", "", "run the proxy in the context of the specificed sandbox")
 	cmd.Flags().StringVarP(&lp.RouteGroup, "routegroup", "r", "", "run the proxy in the context of the specificed routegroup")
 	cmd.Flags().StringVarP(&lp.Cluster, "cluster", "c", "", "target cluster")
 	cmd.Flags().VarP((*proxyMappings)(&lp.ProxyMappings), "map", "m", "--map <target-protocol>://<target-addr>@<bind-addr>")
+	cmd.Flags().StringVar(&lp.ForwardAddr, "forward", "", "run a forward proxy (HTTP and SOCKS5) on this address, routing cluster hosts through the control plane")
+	cmd.Flags().BoolVar(&lp.Detach, "detach", false, "register the proxies with the local sandbox manager (see 'local connect') and exit, leaving them running")
 	cmd.Flags().StringVar(&lp.PProfAddr, "pprof", "", "pprof listen address")
 	cmd.Flags().MarkHidden("pprof")
 }
 
+type LocalProxyStatus struct {
+	*LocalProxy
+}
+
+type LocalProxyStop struct {
+	*LocalProxy
+}
+
+type LocalRun struct {
+	*Local
+
+	// Flags
+	Sandbox       string
+	LocalWorkload string
+	Container     string
+	FilesDir      string
+	NoWatch       bool
+	WatchInterval time.Duration
+}
+
+func (lr *LocalRun) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&lr.Sandbox, "sandbox", "s", "", "sandbox whose environment will be injected")
+	cmd.Flags().StringVarP(&lr.LocalWorkload, "local", "l", "", "local workload name, defaults to the first local workload in the sandbox")
+	cmd.Flags().StringVarP(&lr.Container, "container", "c", "", "container name, defaults to the first container in the local workload")
+	cmd.Flags().StringVarP(&lr.FilesDir, "files-dir", "d", "", "directory where the sandbox files are materialized, defaults to a temporary directory")
+	cmd.Flags().BoolVar(&lr.NoWatch, "no-watch", false, "do not restart the process when the sandbox spec changes")
+	cmd.Flags().DurationVar(&lr.WatchInterval, "watch-interval", 5*time.Second, "interval at which the sandbox spec is checked for changes")
+	cmd.Flags().MarkHidden("watch-interval")
+
+	cmd.MarkFlagRequired("sandbox")
+}
+
+func (lr *LocalRun) Validate() error {
+	if lr.Sandbox == "" {
+		return errors.New("--sandbox is required")
+	}
+	if lr.WatchInterval <= 0 {
+		return errors.New("--watch-interval must be positive")
+	}
+	return nil
+}
+
 type LocalOverride struct {
 	*Local
 }
@@ -321,20 +392,32 @@ type LocalOverrideCreate struct {
 	*LocalOverride
 
 	// Flags
+	Filename string
 	Sandbox  string
 	Port     int64
 	To       string
 	Workload string
 	Detach   bool
 
-	// Policy
+	// Policy Flags
 	ExcludedStatusCodes []int `json:"excludedStatusCodes"`
 
 	WaitTimeout time.Duration
+
+	// Recording Flags
+	RecordDir string
+	Inspect   bool
+
+	// Policy is the override policy resulting from the file and the flags,
+	// computed by LoadFile
+	Policy builder.OverridePolicy
 }
 
 func (lo *LocalOverrideCreate) AddFlags(cmd *cobra.Command) {
 	// Flags
+	cmd.Flags().StringVarP(&lo.Filename, "filename", "f", "",
+		"YAML or JSON file declaring the override, explicit flags take precedence")
+
 	cmd.Flags().StringVar(&lo.Sandbox, "sandbox", "",
 		"name of the sandbox whose traffic will be overridden")
 
@@ -358,10 +441,58 @@ func (lo *LocalOverrideCreate) AddFlags(cmd *cobra.Command) {
 		"comma-separated list of HTTP status codes to bypass override. "+
 			"Responses with these codes will fall through to the sandboxed destination (e.g., 404,503)")
 
-	cmd.MarkFlagRequired("sandbox")
-	cmd.MarkFlagRequired("workload")
-	cmd.MarkFlagRequired("workload-port")
-	cmd.MarkFlagRequired("with")
+	// Recording
+	cmd.Flags().StringVar(&lo.RecordDir, "record-dir", "",
+		"record the overridden requests and the local responses to this directory, in the format of 'traffic record'")
+
+	cmd.Flags().BoolVar(&lo.Inspect, "inspect", false,
+		"inspect the overridden traffic in TUI mode")
+}
+
+type localOverrideFile struct {
+	Sandbox      string                  `json:"sandbox"`
+	Workload     string                  `json:"workload"`
+	WorkloadPort int64                   `json:"workloadPort"`
+	With         string                  `json:"with"`
+	Policy       *builder.OverridePolicy `json:"policy"`
+}
+
+// LoadFile reads the override file (if any) and computes the override
+// policy, giving precedence to the flags explicitly set in cmd.
+func (lo *LocalOverrideCreate) LoadFile(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	file := &localOverrideFile{Policy: &builder.OverridePolicy{}}
+	if lo.Filename != "" {
+		d, err := os.ReadFile(lo.Filename)
+		if err != nil {
+			return fmt.Errorf("error reading override file %q: %w", lo.Filename, err)
+		}
+		if err := yaml.UnmarshalStrict(d, file); err != nil {
+			return fmt.Errorf("error unmarshalling override file %q: %w", lo.Filename, err)
+		}
+		if file.Policy == nil {
+			file.Policy = &builder.OverridePolicy{}
+		}
+	}
+	if !flags.Changed("sandbox") && file.Sandbox != "" {
+		lo.Sandbox = file.Sandbox
+	}
+	if !flags.Changed("workload") && file.Workload != "" {
+		lo.Workload = file.Workload
+	}
+	if !flags.Changed("workload-port") && file.WorkloadPort != 0 {
+		lo.Port = file.WorkloadPort
+	}
+	if !flags.Changed("with") && file.With != "" {
+		lo.To = file.With
+	}
+
+	policy := file.Policy
+	if flags.Changed("except-status") {
+		policy.ExcludedStatusCodes = lo.ExcludedStatusCodes
+	}
+	lo.Policy = *policy
+	return nil
 }
 
 func (lo *LocalOverrideCreate) Validate() error {
@@ -374,17 +505,19 @@ func (lo *LocalOverrideCreate) Validate() error {
 	}
 
 	if lo.Port <= 0 || lo.Port > 65535 {
-		return errors.New("--port must be a value between 1 and 65535")
+		return errors.New("--workload-port must be a value between 1 and 65535")
 	}
 
 	if lo.To == "" {
-		return errors.New("--to is required")
+		return errors.New("--with is required")
 	}
 
-	for _, code := range lo.ExcludedStatusCodes {
-		if code < 100 || code > 599 {
-			return errors.New("invalid except-status response code, should be between 100 and 599")
-		}
+	if err := lo.Policy.Validate(); err != nil {
+		return err
+	}
+
+	if lo.Detach && (lo.RecordDir != "" || lo.Inspect) {
+		return errors.New("--record-dir and --inspect cannot be used with --detach")
 	}
 
 	to, err := parseTo(lo.To)
@@ -426,3 +559,48 @@ func (lod *LocalOverrideDelete) AddFlags(cmd *cobra.Command) {
 type LocalOverrideList struct {
 	*LocalOverride
 }
+
+type LocalOverrideRestore struct {
+	*LocalOverride
+
+	// Flags
+	Sandbox string
+	Detach  bool
+}
+
+func (lor *LocalOverrideRestore) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&lor.Sandbox, "sandbox", "", "only restore the overrides of this sandbox")
+	cmd.Flags().BoolVarP(&lor.Detach, "detach", "d", false,
+		"restore the overrides without attaching to them")
+}
+
+type LocalCompose struct {
+	*Local
+}
+
+type LocalComposeUp struct {
+	*LocalCompose
+
+	// Flags
+	Filenames []string
+	Sandbox   string
+	Services  map[string]string
+	NoHosts   bool
+}
+
+func (lc *LocalComposeUp) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&lc.Filenames, "file", "f", nil, "compose configuration file(s), defaults to COMPOSE_FILE or the ones docker compose finds in the current directory")
+	cmd.Flags().StringVarP(&lc.Sandbox, "sandbox", "s", "", "sandbox whose environment will be injected")
+	cmd.Flags().StringToStringVar(&lc.Services, "service", nil,
+		"<local>=<service> mapping of a sandbox local workload to a compose service, defaults to the service with the same name")
+	cmd.Flags().BoolVar(&lc.NoHosts, "no-hosts", false, "do not inject the cluster hosts into the containers")
+
+	cmd.MarkFlagRequired("sandbox")
+}
+
+func (lc *LocalComposeUp) Validate() error {
+	if lc.Sandbox == "" {
+		return errors.New("--sandbox is required")
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
 
 type Plan struct {
 	*API
@@ -40,6 +44,13 @@ func (c *PlanCreate) AddFlags(cmd *cobra.Command) {
 
 type PlanGet struct {
 	*Plan
+
+	// Flags
+	Graph GraphFormat
+}
+
+func (c *PlanGet) AddFlags(cmd *cobra.Command) {
+	AddGraphFlag(cmd, &c.Graph)
 }
 
 type PlanRecompile struct {
@@ -60,3 +71,53 @@ type PlanDelete struct {
 type PlanSchema struct {
 	*Plan
 }
+
+type PlanLint struct {
+	*Plan
+
+	// Flags
+	Filename      string
+	TemplateVals  TemplateVals
+	RefreshSchema bool
+}
+
+func (c *PlanLint) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&c.Filename, "filename", "f", "", "YAML or JSON file containing the plan spec")
+	cmd.MarkFlagRequired("filename")
+	cmd.Flags().Var(&c.TemplateVals, "set", "--set var=val")
+	cmd.Flags().BoolVar(&c.RefreshSchema, "refresh-schema", false, "fetch the plan schema again instead of using the cached copy")
+}
+
+// GraphFormat is the format in which the step graph of a plan is rendered.
+type GraphFormat string
+
+const (
+	GraphFormatNone    GraphFormat = ""
+	GraphFormatASCII   GraphFormat = "ascii"
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatMermaid GraphFormat = "mermaid"
+)
+
+func (g GraphFormat) String() string {
+	return string(g)
+}
+
+func (g *GraphFormat) Set(v string) error {
+	switch GraphFormat(v) {
+	case GraphFormatASCII, GraphFormatDOT, GraphFormatMermaid:
+		*g = GraphFormat(v)
+		return nil
+	default:
+		return fmt.Errorf("unknown graph format %q (should be ascii, dot, or mermaid)", v)
+	}
+}
+
+func (g *GraphFormat) Type() string {
+	return "string"
+}
+
+// AddGraphFlag adds the --graph flag, which takes an optional format.
+func AddGraphFlag(cmd *cobra.Command, g *GraphFormat) {
+	cmd.Flags().Var(g, "graph", "show the step graph instead of the details {ascii,dot,mermaid}")
+	cmd.Flags().Lookup("graph").NoOptDefVal = string(GraphFormatASCII)
+}
@@ -8,6 +8,13 @@ type PlanExecution struct {
 
 type PlanExecGet struct {
 	*PlanExecution
+
+	// Flags
+	Graph GraphFormat
+}
+
+func (c *PlanExecGet) AddFlags(cmd *cobra.Command) {
+	AddGraphFlag(cmd, &c.Graph)
 }
 
 type PlanExecCancel struct {
@@ -60,3 +67,38 @@ func (c *PlanExecLogs) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&c.All, "all", false, "export captured logs for all steps")
 	cmd.Flags().StringVar(&c.Dir, "dir", "", "directory to export logs to (requires --all)")
 }
+
+type PlanExecCompare struct {
+	*PlanExecution
+
+	// Flags
+	Logs bool
+}
+
+func (c *PlanExecCompare) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&c.Logs, "logs", true, "diff the captured logs of the steps (pass --logs=false to skip downloading them)")
+}
+
+type PlanExecWatch struct {
+	*PlanExecution
+
+	// Flags
+	Dir string
+}
+
+func (c *PlanExecWatch) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.Dir, "dir", ".", "directory to download artifact outputs to")
+}
+
+type PlanExecRetry struct {
+	*PlanExecution
+
+	// Flags
+	FromStep string
+	PlanExecWait
+}
+
+func (c *PlanExecRetry) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.FromStep, "from-step", "", "don't pass on the outputs of this step and of the steps depending on it")
+	c.PlanExecWait.AddFlags(cmd)
+}
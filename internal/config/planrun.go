@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"time"
 
+	"github.com/signadot/cli/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -16,10 +18,12 @@ type PlanRun struct {
 	RouteGroup string
 	Params     TemplateVals
 	Secrets    TemplateVals
-	Wait       bool
-	Attach    bool
-	Timeout   time.Duration
-	OutputDir string
+	PlanExecWait
+
+	// Matrix flags
+	Matrix       string
+	MatrixParams TemplateVals
+	MaxParallel  int
 }
 
 func (c *PlanRun) AddFlags(cmd *cobra.Command) {
@@ -30,8 +34,37 @@ func (c *PlanRun) AddFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive("sandbox", "route-group")
 	cmd.Flags().Var(&c.Params, "param", "parameter in key=value form (can be repeated)")
 	cmd.Flags().Var(&c.Secrets, "param-secret", "bind a plan param to an org secret: param-name=secret-name (can be repeated)")
+	c.PlanExecWait.AddFlags(cmd)
+	cmd.Flags().StringVar(&c.Matrix, "matrix", "", "YAML or JSON file mapping params to lists of values, to run an execution per combination")
+	cmd.Flags().Var(&c.MatrixParams, "matrix-param", "param in key=v1,v2,... form to run an execution per value (can be repeated)")
+	cmd.Flags().IntVar(&c.MaxParallel, "max-parallel", 4, "maximum number of matrix executions running at once")
+}
+
+// IsMatrix reports whether the run fans out over a matrix of params.
+func (c *PlanRun) IsMatrix() bool {
+	return c.Matrix != "" || len(c.MatrixParams) > 0
+}
+
+// PlanExecWait holds the flags controlling how a new execution is followed.
+type PlanExecWait struct {
+	Wait      bool
+	Attach    bool
+	Timeout   time.Duration
+	OutputDir string
+	Reports   report.Reports
+}
+
+func (c *PlanExecWait) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&c.Wait, "wait", true, "wait for execution to complete")
 	cmd.Flags().BoolVar(&c.Attach, "attach", false, "stream structured events (logs, outputs, result) to stdout")
 	cmd.Flags().DurationVar(&c.Timeout, "timeout", 0, "timeout for waiting (0 means no timeout)")
 	cmd.Flags().StringVar(&c.OutputDir, "output-dir", "", "directory to export all outputs to on completion")
+	AddReportFlag(cmd, &c.Reports)
+}
+
+func (c *PlanExecWait) Validate() error {
+	if len(c.Reports) > 0 && !c.Wait {
+		return errors.New("--report requires waiting for the execution; drop --wait=false")
+	}
+	return nil
 }
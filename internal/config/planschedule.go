@@ -0,0 +1,65 @@
+package config
+
+import "github.com/spf13/cobra"
+
+type PlanSchedule struct {
+	*Plan
+}
+
+type PlanScheduleCreate struct {
+	*PlanSchedule
+
+	// Flags
+	Name       string
+	Tag        string
+	PlanID     string
+	Cron       string
+	Cluster    string
+	Sandbox    string
+	RouteGroup string
+	Params     TemplateVals
+	Secrets    TemplateVals
+}
+
+func (c *PlanScheduleCreate) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.Name, "name", "", "name of the schedule (defaults to the tag or plan ID)")
+	cmd.Flags().StringVar(&c.Tag, "tag", "", "run the plan referenced by this tag, resolved at each run")
+	cmd.Flags().StringVar(&c.PlanID, "plan", "", "run the plan with this ID")
+	cmd.MarkFlagsMutuallyExclusive("tag", "plan")
+	cmd.MarkFlagsOneRequired("tag", "plan")
+	cmd.Flags().StringVar(&c.Cron, "cron", "", `when to run, as a cron expression in local time (e.g. "0 2 * * *" or "@daily")`)
+	cmd.MarkFlagRequired("cron")
+	cmd.Flags().StringVar(&c.Cluster, "cluster", "", "target cluster for the executions")
+	cmd.Flags().StringVar(&c.Sandbox, "sandbox", "", "run in the context of a sandbox")
+	cmd.Flags().StringVar(&c.RouteGroup, "route-group", "", "run in the context of a route group")
+	cmd.MarkFlagsMutuallyExclusive("sandbox", "route-group")
+	cmd.Flags().Var(&c.Params, "param", "parameter in key=value form (can be repeated)")
+	cmd.Flags().Var(&c.Secrets, "param-secret", "bind a plan param to an org secret: param-name=secret-name (can be repeated)")
+}
+
+type PlanScheduleList struct {
+	*PlanSchedule
+}
+
+type PlanSchedulePause struct {
+	*PlanSchedule
+}
+
+type PlanScheduleResume struct {
+	*PlanSchedule
+}
+
+type PlanScheduleDelete struct {
+	*PlanSchedule
+}
+
+type PlanScheduleRunLocal struct {
+	*PlanSchedule
+
+	// Flags
+	HistoryDir string
+}
+
+func (c *PlanScheduleRunLocal) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.HistoryDir, "history-dir", "", "directory to record the runs in (default ~/.signadot/plan-schedule-history)")
+}
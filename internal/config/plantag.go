@@ -29,3 +29,47 @@ type PlanTagList struct {
 type PlanTagDelete struct {
 	*PlanTag
 }
+
+type PlanTagHistory struct {
+	*PlanTag
+}
+
+// PlanTagGuard holds the flags guarding the moves of a tag.
+type PlanTagGuard struct {
+	RequirePassingExec bool
+}
+
+func (c *PlanTagGuard) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&c.RequirePassingExec, "require-passing-exec", false,
+		"only move the tag if the latest finished execution of the target plan completed")
+}
+
+type PlanTagRollback struct {
+	*PlanTag
+
+	// Flags
+	To string
+	PlanTagGuard
+}
+
+func (c *PlanTagRollback) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.To, "to", "", "plan ID to move the tag back to (default: the plan it pointed at before the current one)")
+	c.PlanTagGuard.AddFlags(cmd)
+}
+
+type PlanTagPromote struct {
+	*PlanTag
+
+	// Flags
+	From string
+	To   string
+	PlanTagGuard
+}
+
+func (c *PlanTagPromote) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.From, "from", "", "tag whose plan to promote")
+	cmd.Flags().StringVar(&c.To, "to", "", "tag to point at the plan of --from")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+	c.PlanTagGuard.AddFlags(cmd)
+}
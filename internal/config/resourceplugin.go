@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -54,3 +57,70 @@ func (c *ResourcePluginList) AddFlags(cmd *cobra.Command) {
 type ResourcePluginVersions struct {
 	*ResourcePlugin
 }
+
+type ResourcePluginTest struct {
+	*ResourcePlugin
+
+	// Flags
+	Filename     string
+	TemplateVals TemplateVals
+	Inputs       KeyValueMap
+	Runtime      string
+	ResourceName string
+	Sandbox      string
+	StepTimeout  time.Duration
+}
+
+func (c *ResourcePluginTest) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&c.Filename, "filename", "f", "", "YAML or JSON file containing the resource plugin")
+	cmd.MarkFlagRequired("filename")
+	cmd.Flags().Var(&c.TemplateVals, "set", "--set var=val")
+	c.Inputs = make(KeyValueMap)
+	cmd.Flags().Var(c.Inputs, "input", "resource parameter passed to the steps, in the form key=value (can be specified multiple times)")
+	cmd.Flags().StringVar(&c.Runtime, "runtime", "kube", "where to run the steps: kube (the cluster of 'signadot local connect') or docker")
+	cmd.Flags().StringVar(&c.ResourceName, "resource-name", "test", "name of the resource, as in a sandbox spec")
+	cmd.Flags().StringVar(&c.Sandbox, "sandbox", "resourceplugin-test", "name of the sandbox the steps are run for")
+	cmd.Flags().DurationVar(&c.StepTimeout, "step-timeout", 5*time.Minute, "timeout of each step")
+}
+
+func (c *ResourcePluginTest) Validate() error {
+	switch c.Runtime {
+	case "kube", "docker":
+		return nil
+	default:
+		return fmt.Errorf("unsupported runtime %q, expected kube or docker", c.Runtime)
+	}
+}
+
+type ResourcePluginPublish struct {
+	*ResourcePlugin
+
+	// Flags
+	Filename     string
+	TemplateVals TemplateVals
+	Bump         string
+}
+
+func (c *ResourcePluginPublish) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&c.Filename, "filename", "f", "", "YAML or JSON file containing the resource plugin, named without a version")
+	cmd.MarkFlagRequired("filename")
+	cmd.Flags().Var(&c.TemplateVals, "set", "--set var=val")
+	cmd.Flags().StringVar(&c.Bump, "bump", "patch", "part of the highest published version to increment: major, minor or patch")
+}
+
+func (c *ResourcePluginPublish) Validate() error {
+	switch c.Bump {
+	case "major", "minor", "patch":
+		return nil
+	default:
+		return fmt.Errorf("invalid --bump %q, expected major, minor or patch", c.Bump)
+	}
+}
+
+type ResourcePluginDiff struct {
+	*ResourcePlugin
+}
+
+type ResourcePluginUsage struct {
+	*ResourcePlugin
+}
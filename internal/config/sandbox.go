@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -55,6 +59,22 @@ type SandboxList struct {
 	*Sandbox
 }
 
+type SandboxTTL struct {
+	*Sandbox
+}
+
+type SandboxExtend struct {
+	*Sandbox
+
+	// Flags
+	By time.Duration
+}
+
+func (c *SandboxExtend) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().Var((*longDuration)(&c.By), "by", "extend the sandbox lifetime by this long, like 30m, 2h or 1d")
+	cmd.MarkFlagRequired("by")
+}
+
 type SandboxGetFiles struct {
 	*Sandbox
 	Local     string
@@ -86,3 +106,106 @@ func (c *SandboxGetEnv) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&c.Container, "container", "c", "", "container name, defaults to the first container in the local workload")
 	cmd.Flags().BoolVarP(&c.ShowSource, "show-source", "s", false, "show source in comments")
 }
+
+type SandboxClone struct {
+	*Sandbox
+
+	// Flags
+	Labels      KeyValueMap
+	Locals      []SandboxCloneLocal
+	KeepLocals  bool
+	Wait        bool
+	WaitTimeout time.Duration
+}
+
+// SandboxCloneLocal replaces the fork of a workload in a cloned sandbox by a
+// local workload, mapping one of its ports to a local address.
+type SandboxCloneLocal struct {
+	Workload string
+	Port     int64
+	ToLocal  string
+}
+
+func (c *SandboxClone) AddFlags(cmd *cobra.Command) {
+	c.Labels = make(KeyValueMap)
+	cmd.Flags().Var(c.Labels, "set", "set a label of the new sandbox, in the form key=value (can be specified multiple times)")
+	cmd.Flags().Var((*sandboxCloneLocals)(&c.Locals), "local", "run the forked workload NAME locally, in the form NAME[:PORT]=ADDR (can be specified multiple times)")
+	cmd.Flags().BoolVar(&c.KeepLocals, "keep-locals", false, "keep the local workloads and overrides of the source sandbox, running them on this workstation")
+	cmd.Flags().BoolVar(&c.Wait, "wait", true, "wait for the sandbox status to be Ready before returning")
+	cmd.Flags().DurationVar(&c.WaitTimeout, "wait-timeout", 3*time.Minute, "timeout when waiting for the sandbox to be Ready")
+}
+
+type sandboxCloneLocals []SandboxCloneLocal
+
+func (ls *sandboxCloneLocals) String() string {
+	parts := make([]string, 0, len(*ls))
+	for _, l := range *ls {
+		parts = append(parts, fmt.Sprintf("%s:%d=%s", l.Workload, l.Port, l.ToLocal))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (ls *sandboxCloneLocals) Set(v string) error {
+	name, addr, ok := strings.Cut(v, "=")
+	if !ok || name == "" || addr == "" {
+		return fmt.Errorf("--local expects NAME[:PORT]=ADDR, got %q", v)
+	}
+	_, addrPort, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("--local %q: invalid address %q: %w", v, addr, err)
+	}
+	// the workload port defaults to the local one
+	portStr := addrPort
+	if n, p, found := strings.Cut(name, ":"); found {
+		name, portStr = n, p
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return fmt.Errorf("--local %q: invalid port %q", v, portStr)
+	}
+	*ls = append(*ls, SandboxCloneLocal{
+		Workload: name,
+		Port:     int64(port),
+		ToLocal:  addr,
+	})
+	return nil
+}
+
+func (ls *sandboxCloneLocals) Type() string {
+	return "local"
+}
+
+type SandboxInit struct {
+	*Sandbox
+
+	// Flags
+	Cluster   string
+	Namespace string
+	FromKube  bool
+	Forks     []string
+	Locals    []string
+	Name      string
+	Filename  string
+}
+
+func (c *SandboxInit) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.Cluster, "cluster", "", "cluster whose DevMesh enabled workloads are listed")
+	cmd.MarkFlagRequired("cluster")
+	cmd.Flags().StringVar(&c.Namespace, "namespace", "", "only list the workloads in these namespaces (comma separated list)")
+	cmd.Flags().BoolVar(&c.FromKube, "from-kube", false, "list the workloads with the kube client of 'signadot local connect' instead of the API")
+	cmd.Flags().StringArrayVar(&c.Forks, "fork", nil, "fork the workload NAMESPACE/NAME (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&c.Locals, "local", nil, "run the workload NAMESPACE/NAME[:PORT] locally (can be specified multiple times)")
+	cmd.Flags().StringVar(&c.Name, "name", "@{user}-dev", "name of the sandbox")
+	cmd.Flags().StringVarP(&c.Filename, "filename", "f", "", "file to write the sandbox spec to (default stdout)")
+}
+
+type SandboxDescribe struct {
+	*Sandbox
+
+	// Flags
+	TailLines uint
+}
+
+func (c *SandboxDescribe) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().UintVarP(&c.TailLines, "tail", "t", 10, "lines of recent log to display per workload and resource step")
+}
@@ -0,0 +1,59 @@
+package config
+
+import "testing"
+
+func TestSandboxCloneLocalsSet(t *testing.T) {
+	cases := []struct {
+		value   string
+		want    SandboxCloneLocal
+		wantErr bool
+	}{
+		{
+			value: "route=localhost:8083",
+			want:  SandboxCloneLocal{Workload: "route", Port: 8083, ToLocal: "localhost:8083"},
+		},
+		{
+			value: "frontend:80=localhost:8080",
+			want:  SandboxCloneLocal{Workload: "frontend", Port: 80, ToLocal: "localhost:8080"},
+		},
+		{
+			value: "frontend:80=[::1]:8080",
+			want:  SandboxCloneLocal{Workload: "frontend", Port: 80, ToLocal: "[::1]:8080"},
+		},
+		{value: "route", wantErr: true},
+		{value: "=localhost:8083", wantErr: true},
+		{value: "route=", wantErr: true},
+		{value: "route=localhost", wantErr: true},
+		{value: "route:http=localhost:8083", wantErr: true},
+		{value: "route:0=localhost:8083", wantErr: true},
+		{value: "route:70000=localhost:8083", wantErr: true},
+	}
+	for _, c := range cases {
+		var ls sandboxCloneLocals
+		err := ls.Set(c.value)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %+v", c.value, ls)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", c.value, err)
+			continue
+		}
+		if len(ls) != 1 || ls[0] != c.want {
+			t.Errorf("%q: got %+v, want %+v", c.value, ls, c.want)
+		}
+	}
+
+	// the flag can be given several times
+	var ls sandboxCloneLocals
+	for _, v := range []string{"route=localhost:8083", "frontend:80=localhost:8080"} {
+		if err := ls.Set(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := ls.String(); got != "route:8083=localhost:8083,frontend:80=localhost:8080" {
+		t.Errorf("got %q", got)
+	}
+}
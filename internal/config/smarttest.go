@@ -1,13 +1,10 @@
 package config
 
 import (
-	"bytes"
 	"errors"
-	"fmt"
-	"sort"
-	"strings"
 	"time"
 
+	"github.com/signadot/cli/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -44,9 +41,13 @@ type SmartTestRun struct {
 	Publish    bool
 	Timeout    time.Duration
 	NoWait     bool
+	Reports    report.Reports
 }
 
 func (smr *SmartTestRun) Validate() error {
+	if len(smr.Reports) > 0 && smr.NoWait {
+		return errors.New("--report can't be combined with --no-wait")
+	}
 	c := 0
 	if smr.Cluster != "" {
 		c++
@@ -74,28 +75,11 @@ func (smr *SmartTestRun) Validate() error {
 type TestExecLabels map[string]string
 
 func (rl TestExecLabels) String() string {
-	keys := make([]string, 0, len(rl))
-	for k := range rl {
-		keys = append(keys, k)
-	}
-	res := bytes.NewBuffer(nil)
-	sort.Stable(sort.StringSlice(keys))
-	for i, key := range keys {
-		if i != 0 {
-			fmt.Fprintf(res, ",")
-		}
-		fmt.Fprintf(res, "%s=%s", key, rl[key])
-	}
-	return res.String()
+	return KeyValueMap(rl).String()
 }
 
 func (rl TestExecLabels) Set(v string) error {
-	key, val, ok := strings.Cut(v, "=")
-	if !ok {
-		return fmt.Errorf("%q should be in form <key>=<value>", v)
-	}
-	rl[key] = val
-	return nil
+	return KeyValueMap(rl).Set(v)
 }
 
 func (tl TestExecLabels) Type() string {
@@ -122,6 +106,7 @@ func (c *SmartTestRun) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&c.Publish, "publish", false, "publish test results")
 	cmd.Flags().DurationVar(&c.Timeout, "timeout", 0, "timeout when waiting for the tests to complete, if 0 is specified, no timeout will be applied (default 0)")
 	cmd.Flags().BoolVar(&c.NoWait, "no-wait", false, "do not wait until the tests are completed")
+	AddReportFlag(cmd, &c.Reports)
 
 	c.AddLabels = make(map[string]string)
 	cmd.Flags().Var(&c.AddLabels, "set-label", "set a label in form key=value for all test executions in the run (can be specified multiple times)")
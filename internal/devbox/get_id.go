@@ -160,15 +160,28 @@ func registerAndPersist(ctx context.Context, apiConfig *config.API, claim bool,
 	return id, nil
 }
 
+// IDFile returns the file holding the ID of the devbox of this machine,
+// ~/.signadot/.devbox-id unless another file or a named identity is
+// selected (see SelectIdentity).
 func IDFile() (string, error) {
+	if file := selectedIDFile(); file != "" {
+		return file, nil
+	}
+	identity := Identity()
+	if err := validateIdentity(identity); err != nil {
+		return "", err
+	}
 	sdir, err := system.GetSignadotDir()
 	if err != nil {
 		return "", err
 	}
+	if identity != "" {
+		return filepath.Join(sdir, ".devbox-id."+identity), nil
+	}
 	return filepath.Join(sdir, ".devbox-id"), nil
 }
 
-// GetDefaultDevboxID reads the devbox ID from ~/.signadot/.devbox-id.
+// GetDefaultDevboxID reads the devbox ID from the devbox ID file (see IDFile).
 // If the file is not found, it returns an empty string and no error.
 // For other errors, it returns the error.
 func GetDefaultDevboxID() (string, error) {
@@ -189,7 +202,8 @@ func GetDefaultDevboxID() (string, error) {
 }
 
 // RegisterDevbox registers a devbox with the API and returns the devbox ID.
-// If name is empty, it will use the hostname. If claim is true, it will also claim a session.
+// If name is empty, it will use the hostname (suffixed with the devbox
+// identity, if any). If claim is true, it will also claim a session.
 func RegisterDevbox(ctx context.Context, cfg *config.API, claim bool, name string) (string, error) {
 	return getIDByAPI(ctx, cfg, claim, name)
 }
@@ -227,6 +241,10 @@ func getDevboxMeta(name string) (map[string]string, error) {
 	}
 	if name == "" {
 		name = host
+		if identity := Identity(); identity != "" {
+			// devboxes are registered by machine and name
+			name = host + "-" + identity
+		}
 	}
 	mid, err := system.GetMachineID()
 	if err != nil {
@@ -0,0 +1,67 @@
+package devbox
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+const (
+	// IDFileEnv selects the file holding the devbox ID, overriding the
+	// default ~/.signadot/.devbox-id
+	IDFileEnv = "SIGNADOT_DEVBOX_ID_FILE"
+	// IdentityEnv selects a named devbox identity, whose ID is kept in
+	// ~/.signadot/.devbox-id.<identity>
+	IdentityEnv = "SIGNADOT_DEVBOX_IDENTITY"
+)
+
+var identityRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// selected holds the identity selected by command line flags, which takes
+// precedence over the environment.
+var selected struct {
+	idFile   string
+	identity string
+}
+
+// SelectIdentity selects the devbox ID file to use in this process, either
+// explicitly or through a named identity. Named identities allow several
+// devboxes on the same machine, like containers or VMs sharing a home
+// directory. Empty values fall back to the environment.
+func SelectIdentity(idFile, identity string) error {
+	if idFile != "" && identity != "" {
+		return fmt.Errorf("only one of a devbox ID file or a devbox identity can be specified")
+	}
+	if err := validateIdentity(identity); err != nil {
+		return err
+	}
+	selected.idFile = idFile
+	selected.identity = identity
+	return nil
+}
+
+// Identity returns the name of the selected devbox identity, or the empty
+// string for the default one.
+func Identity() string {
+	if selected.idFile != "" || selected.identity != "" {
+		return selected.identity
+	}
+	if os.Getenv(IDFileEnv) != "" {
+		return ""
+	}
+	return os.Getenv(IdentityEnv)
+}
+
+func selectedIDFile() string {
+	if selected.idFile != "" || selected.identity != "" {
+		return selected.idFile
+	}
+	return os.Getenv(IDFileEnv)
+}
+
+func validateIdentity(identity string) error {
+	if identity != "" && !identityRegexp.MatchString(identity) {
+		return fmt.Errorf("invalid devbox identity %q: only letters, digits, '.', '_' and '-' are allowed", identity)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package devbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// sessionHistoryFile is the file, in the signadot dir, where the devbox
+// sessions started on this machine are recorded
+const sessionHistoryFile = "devbox-sessions.jsons"
+
+// SessionRecord is a devbox session started on this machine.
+type SessionRecord struct {
+	DevboxID  string     `json:"devboxID"`
+	SessionID string     `json:"sessionID"`
+	Cluster   string     `json:"cluster,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
+
+// recordSessionEvent appends a session start or end to the session history
+// kept in signadotDir.
+func recordSessionEvent(signadotDir string, rec *SessionRecord) error {
+	f, err := os.OpenFile(filepath.Join(signadotDir, sessionHistoryFile),
+		os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(rec)
+}
+
+// LoadSessionHistory returns the sessions of the given devbox started on this
+// machine, most recent first.
+func LoadSessionHistory(signadotDir, devboxID string) ([]*SessionRecord, error) {
+	f, err := os.Open(filepath.Join(signadotDir, sessionHistoryFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// start and end events are recorded separately, merge them by session
+	sessions := map[string]*SessionRecord{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ev := &SessionRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), ev); err != nil || ev.DevboxID != devboxID {
+			continue
+		}
+		rec, ok := sessions[ev.SessionID]
+		if !ok {
+			sessions[ev.SessionID] = ev
+			continue
+		}
+		if ev.StartedAt != nil {
+			rec.StartedAt = ev.StartedAt
+		}
+		if ev.EndedAt != nil {
+			rec.EndedAt = ev.EndedAt
+		}
+		if ev.Cluster != "" {
+			rec.Cluster = ev.Cluster
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	res := make([]*SessionRecord, 0, len(sessions))
+	for _, rec := range sessions {
+		res = append(res, rec)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].lastActivity().After(res[j].lastActivity())
+	})
+	return res, nil
+}
+
+// LastActivity returns when the devbox was last used from this machine
+// according to its session history, or the zero time if unknown.
+func LastActivity(history []*SessionRecord) time.Time {
+	var last time.Time
+	for _, rec := range history {
+		if t := rec.lastActivity(); t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+func (r *SessionRecord) lastActivity() time.Time {
+	if r.EndedAt != nil {
+		return *r.EndedAt
+	}
+	if r.StartedAt != nil {
+		return *r.StartedAt
+	}
+	return time.Time{}
+}
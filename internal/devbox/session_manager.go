@@ -73,6 +73,9 @@ func (dsm *SessionManager) Start(ctx context.Context) {
 		"devboxID", dsm.ciConfig.DevboxID,
 		"sessionID", currentSessionID)
 
+	now := time.Now()
+	dsm.recordSession(&SessionRecord{StartedAt: &now})
+
 	// Do initial renewal immediately
 	go dsm.renewSession(ctx)
 
@@ -208,6 +211,22 @@ func (dsm *SessionManager) Stop(ctx context.Context) {
 
 	// Release session on shutdown
 	dsm.releaseSession()
+
+	now := time.Now()
+	dsm.recordSession(&SessionRecord{EndedAt: &now})
+}
+
+// recordSession records the start or end of the session in the local session
+// history (see 'devbox show').
+func (dsm *SessionManager) recordSession(rec *SessionRecord) {
+	rec.DevboxID = dsm.ciConfig.DevboxID
+	rec.SessionID = dsm.ciConfig.DevboxSessionID
+	if dsm.ciConfig.ConnectionConfig != nil {
+		rec.Cluster = dsm.ciConfig.ConnectionConfig.Cluster
+	}
+	if err := recordSessionEvent(dsm.ciConfig.SignadotDir, rec); err != nil {
+		dsm.log.Warn("Failed to record devbox session", "error", err)
+	}
 }
 
 // setError records an error
@@ -0,0 +1,224 @@
+// Package docker resolves local addresses which refer to docker containers,
+// either by container name or by compose service, to a host:port reachable
+// from the local machine.
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+const (
+	// docker://<container>:<port>
+	ContainerScheme = "docker"
+	// compose://[<project>/]<service>:<port>
+	ComposeScheme = "compose"
+
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+)
+
+// Address is a local address referring to a port of a docker container.
+type Address struct {
+	Scheme string
+	// Project is the (optional) compose project, only used in the compose
+	// scheme.
+	Project string
+	// Name is the container name in the docker scheme and the service name
+	// in the compose scheme.
+	Name string
+	Port string
+}
+
+// IsContainerAddress tells whether addr refers to a docker container.
+func IsContainerAddress(addr string) bool {
+	return strings.HasPrefix(addr, ContainerScheme+"://") ||
+		strings.HasPrefix(addr, ComposeScheme+"://")
+}
+
+// ParseAddress parses a docker://<container>:<port> or a
+// compose://[<project>/]<service>:<port> address.
+func ParseAddress(addr string) (*Address, error) {
+	scheme, rest, ok := strings.Cut(addr, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid container address %q: missing scheme", addr)
+	}
+	name, port, err := net.SplitHostPort(rest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container address %q: %w", addr, err)
+	}
+	if port == "" {
+		return nil, fmt.Errorf("invalid container address %q: missing port", addr)
+	}
+	res := &Address{Scheme: scheme, Name: name, Port: port}
+	switch scheme {
+	case ContainerScheme:
+	case ComposeScheme:
+		if project, service, ok := strings.Cut(name, "/"); ok {
+			res.Project, res.Name = project, service
+		}
+	default:
+		return nil, fmt.Errorf("invalid container address %q: unknown scheme %q (should be %s or %s)",
+			addr, scheme, ContainerScheme, ComposeScheme)
+	}
+	if res.Name == "" {
+		return nil, fmt.Errorf("invalid container address %q: missing name", addr)
+	}
+	return res, nil
+}
+
+func (a *Address) String() string {
+	name := a.Name
+	if a.Project != "" {
+		name = a.Project + "/" + name
+	}
+	return fmt.Sprintf("%s://%s", a.Scheme, net.JoinHostPort(name, a.Port))
+}
+
+// Resolve returns the host:port at which the addressed container port can
+// be reached. Ports published on the host are preferred over the container
+// IP address, as the latter is not routable from the host in all setups
+// (e.g. Docker Desktop).
+func (a *Address) Resolve(ctx context.Context) (string, error) {
+	id := a.Name
+	if a.Scheme == ComposeScheme {
+		var err error
+		id, err = a.composeContainer(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+	info, err := inspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return info.address(a.Port)
+}
+
+// ResolveAddress resolves addr if it is a container address and returns it
+// unchanged otherwise.
+func ResolveAddress(ctx context.Context, addr string) (string, error) {
+	if !IsContainerAddress(addr) {
+		return addr, nil
+	}
+	a, err := ParseAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	return a.Resolve(ctx)
+}
+
+func (a *Address) composeContainer(ctx context.Context) (string, error) {
+	args := []string{"ps", "--no-trunc", "--format", "{{.ID}}",
+		"--filter", "status=running",
+		"--filter", fmt.Sprintf("label=%s=%s", composeServiceLabel, a.Name),
+	}
+	if a.Project != "" {
+		args = append(args, "--filter", fmt.Sprintf("label=%s=%s", composeProjectLabel, a.Project))
+	}
+	out, err := run(ctx, args...)
+	if err != nil {
+		return "", err
+	}
+	ids := strings.Fields(string(out))
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no running container for compose service %q", a.Name)
+	case 1:
+		return ids[0], nil
+	default:
+		if a.Project == "" {
+			return "", fmt.Errorf("compose service %q matches %d containers, specify the project as compose://<project>/%s:%s",
+				a.Name, len(ids), a.Name, a.Port)
+		}
+		// scaled service, use any replica
+		sort.Strings(ids)
+		return ids[0], nil
+	}
+}
+
+type portBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+type containerInfo struct {
+	Name  string `json:"Name"`
+	State struct {
+		Running bool `json:"Running"`
+	} `json:"State"`
+	NetworkSettings struct {
+		Ports    map[string][]portBinding `json:"Ports"`
+		Networks map[string]struct {
+			IPAddress string `json:"IPAddress"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+func inspect(ctx context.Context, id string) (*containerInfo, error) {
+	out, err := run(ctx, "inspect", "--type", "container", id)
+	if err != nil {
+		return nil, err
+	}
+	var infos []containerInfo
+	if err := json.Unmarshal(out, &infos); err != nil {
+		return nil, fmt.Errorf("unable to parse docker inspect output for %q: %w", id, err)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no such container %q", id)
+	}
+	return &infos[0], nil
+}
+
+func (ci *containerInfo) address(port string) (string, error) {
+	if !ci.State.Running {
+		return "", fmt.Errorf("container %s is not running", strings.TrimPrefix(ci.Name, "/"))
+	}
+	for _, b := range ci.NetworkSettings.Ports[port+"/tcp"] {
+		if b.HostPort == "" {
+			continue
+		}
+		host := b.HostIP
+		switch host {
+		case "", "0.0.0.0":
+			host = "127.0.0.1"
+		case "::":
+			host = "::1"
+		}
+		return net.JoinHostPort(host, b.HostPort), nil
+	}
+	networks := make([]string, 0, len(ci.NetworkSettings.Networks))
+	for name := range ci.NetworkSettings.Networks {
+		networks = append(networks, name)
+	}
+	sort.Strings(networks)
+	for _, name := range networks {
+		if ip := ci.NetworkSettings.Networks[name].IPAddress; ip != "" {
+			return net.JoinHostPort(ip, port), nil
+		}
+	}
+	return "", fmt.Errorf("container %s has neither a published port %s nor an IP address",
+		strings.TrimPrefix(ci.Name, "/"), port)
+}
+
+func run(ctx context.Context, args ...string) ([]byte, error) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("docker %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("unable to run docker: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
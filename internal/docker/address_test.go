@@ -0,0 +1,100 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	tcs := []struct {
+		addr string
+		exp  *Address
+	}{
+		{
+			addr: "docker://route:8080",
+			exp:  &Address{Scheme: ContainerScheme, Name: "route", Port: "8080"},
+		},
+		{
+			addr: "compose://route:8080",
+			exp:  &Address{Scheme: ComposeScheme, Name: "route", Port: "8080"},
+		},
+		{
+			addr: "compose://hotrod/route:8080",
+			exp:  &Address{Scheme: ComposeScheme, Project: "hotrod", Name: "route", Port: "8080"},
+		},
+		{addr: "docker://route"},
+		{addr: "docker://:8080"},
+		{addr: "compose://hotrod/:8080"},
+		{addr: "tcp://route:8080"},
+		{addr: "localhost:8080"},
+	}
+	for _, tc := range tcs {
+		a, err := ParseAddress(tc.addr)
+		if tc.exp == nil {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", tc.addr, a)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.addr, err)
+			continue
+		}
+		if *a != *tc.exp {
+			t.Errorf("%s: got %+v, expected %+v", tc.addr, a, tc.exp)
+		}
+		if a.String() != tc.addr {
+			t.Errorf("%s: round trip gave %s", tc.addr, a)
+		}
+	}
+}
+
+func TestContainerInfoAddress(t *testing.T) {
+	tcs := []struct {
+		inspect string
+		port    string
+		exp     string
+	}{
+		{
+			inspect: `{"Name": "/route", "State": {"Running": true},
+				"NetworkSettings": {
+					"Ports": {"8080/tcp": [{"HostIp": "0.0.0.0", "HostPort": "18080"}]},
+					"Networks": {"bridge": {"IPAddress": "172.17.0.2"}}}}`,
+			port: "8080",
+			exp:  "127.0.0.1:18080",
+		},
+		{
+			inspect: `{"Name": "/route", "State": {"Running": true},
+				"NetworkSettings": {
+					"Ports": {"8080/tcp": null},
+					"Networks": {"b": {"IPAddress": "172.18.0.3"}, "a": {"IPAddress": ""}}}}`,
+			port: "8080",
+			exp:  "172.18.0.3:8080",
+		},
+		{
+			inspect: `{"Name": "/route", "State": {"Running": false},
+				"NetworkSettings": {"Networks": {"bridge": {"IPAddress": "172.17.0.2"}}}}`,
+			port: "8080",
+		},
+	}
+	for i, tc := range tcs {
+		ci := &containerInfo{}
+		if err := json.Unmarshal([]byte(tc.inspect), ci); err != nil {
+			t.Fatal(err)
+		}
+		addr, err := ci.address(tc.port)
+		if tc.exp == "" {
+			if err == nil {
+				t.Errorf("[%d] expected error, got %s", i, addr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d] unexpected error %v", i, err)
+			continue
+		}
+		if addr != tc.exp {
+			t.Errorf("[%d] got %s, expected %s", i, addr, tc.exp)
+		}
+	}
+}
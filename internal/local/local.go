@@ -13,10 +13,28 @@ import (
 	lcconfig "github.com/signadot/libconnect/config"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func GetLocalKubeClient() (client.Client, error) {
+	restConfig, err := GetLocalKubeConfig()
+	if err != nil {
+		return nil, err
+	}
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		return nil, fmt.Errorf("couldn't add client-go types to scheme: %w", err)
+	}
+	if err := rolloutapi.AddToScheme(s); err != nil {
+		return nil, fmt.Errorf("couldn't add argo rollout types to scheme: %w", err)
+	}
+	return client.New(restConfig, client.Options{Scheme: s})
+}
+
+// GetLocalKubeConfig returns the REST config of the cluster to which signadot
+// is connected, resolved from the kubeconfig and context of the connection.
+func GetLocalKubeConfig() (*rest.Config, error) {
 	st, err := GetLocalStatus()
 	if err != nil {
 		return nil, err
@@ -29,19 +47,8 @@ func GetLocalKubeClient() (client.Client, error) {
 		return nil, fmt.Errorf("no connection config")
 	}
 	connConfig := ciConfig.ConnectionConfig
-	restConfig, err := lcconfig.GetRESTConfig(connConfig.GetKubeConfigPath(),
+	return lcconfig.GetRESTConfig(connConfig.GetKubeConfigPath(),
 		connConfig.KubeContext)
-	if err != nil {
-		return nil, err
-	}
-	s := runtime.NewScheme()
-	if err := clientgoscheme.AddToScheme(s); err != nil {
-		return nil, fmt.Errorf("couldn't add client-go types to scheme: %w", err)
-	}
-	if err := rolloutapi.AddToScheme(s); err != nil {
-		return nil, fmt.Errorf("couldn't add argo rollout types to scheme: %w", err)
-	}
-	return client.New(restConfig, client.Options{Scheme: s})
 }
 
 func GetLocalStatus() (*sbmapi.StatusResponse, error) {
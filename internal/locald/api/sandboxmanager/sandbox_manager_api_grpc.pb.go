@@ -25,6 +25,11 @@ type SandboxManagerAPIClient interface {
 	// This method returns all the available resource outputs for a sandbox given
 	// its routing key.
 	GetResourceOutputs(ctx context.Context, in *GetResourceOutputsRequest, opts ...grpc.CallOption) (*GetResourceOutputsResponse, error)
+	// This method registers local proxies to be run by the sandbox manager,
+	// replacing the registered ones with the same name
+	RegisterProxies(ctx context.Context, in *RegisterProxiesRequest, opts ...grpc.CallOption) (*RegisterProxiesResponse, error)
+	// This method stops and removes a registered local proxy
+	RemoveProxy(ctx context.Context, in *RemoveProxyRequest, opts ...grpc.CallOption) (*RemoveProxyResponse, error)
 }
 
 type sandboxManagerAPIClient struct {
@@ -62,6 +67,24 @@ func (c *sandboxManagerAPIClient) GetResourceOutputs(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *sandboxManagerAPIClient) RegisterProxies(ctx context.Context, in *RegisterProxiesRequest, opts ...grpc.CallOption) (*RegisterProxiesResponse, error) {
+	out := new(RegisterProxiesResponse)
+	err := c.cc.Invoke(ctx, "/sandboxmanager.SandboxManagerAPI/RegisterProxies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sandboxManagerAPIClient) RemoveProxy(ctx context.Context, in *RemoveProxyRequest, opts ...grpc.CallOption) (*RemoveProxyResponse, error) {
+	out := new(RemoveProxyResponse)
+	err := c.cc.Invoke(ctx, "/sandboxmanager.SandboxManagerAPI/RemoveProxy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SandboxManagerAPIServer is the server API for SandboxManagerAPI service.
 // All implementations must embed UnimplementedSandboxManagerAPIServer
 // for forward compatibility
@@ -73,6 +96,11 @@ type SandboxManagerAPIServer interface {
 	// This method returns all the available resource outputs for a sandbox given
 	// its routing key.
 	GetResourceOutputs(context.Context, *GetResourceOutputsRequest) (*GetResourceOutputsResponse, error)
+	// This method registers local proxies to be run by the sandbox manager,
+	// replacing the registered ones with the same name
+	RegisterProxies(context.Context, *RegisterProxiesRequest) (*RegisterProxiesResponse, error)
+	// This method stops and removes a registered local proxy
+	RemoveProxy(context.Context, *RemoveProxyRequest) (*RemoveProxyResponse, error)
 	mustEmbedUnimplementedSandboxManagerAPIServer()
 }
 
@@ -89,6 +117,12 @@ func (UnimplementedSandboxManagerAPIServer) Shutdown(context.Context, *ShutdownR
 func (UnimplementedSandboxManagerAPIServer) GetResourceOutputs(context.Context, *GetResourceOutputsRequest) (*GetResourceOutputsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetResourceOutputs not implemented")
 }
+func (UnimplementedSandboxManagerAPIServer) RegisterProxies(context.Context, *RegisterProxiesRequest) (*RegisterProxiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterProxies not implemented")
+}
+func (UnimplementedSandboxManagerAPIServer) RemoveProxy(context.Context, *RemoveProxyRequest) (*RemoveProxyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProxy not implemented")
+}
 func (UnimplementedSandboxManagerAPIServer) mustEmbedUnimplementedSandboxManagerAPIServer() {}
 
 // UnsafeSandboxManagerAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -156,6 +190,42 @@ func _SandboxManagerAPI_GetResourceOutputs_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SandboxManagerAPI_RegisterProxies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterProxiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SandboxManagerAPIServer).RegisterProxies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sandboxmanager.SandboxManagerAPI/RegisterProxies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SandboxManagerAPIServer).RegisterProxies(ctx, req.(*RegisterProxiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SandboxManagerAPI_RemoveProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SandboxManagerAPIServer).RemoveProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sandboxmanager.SandboxManagerAPI/RemoveProxy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SandboxManagerAPIServer).RemoveProxy(ctx, req.(*RemoveProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SandboxManagerAPI_ServiceDesc is the grpc.ServiceDesc for SandboxManagerAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,6 +245,14 @@ var SandboxManagerAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetResourceOutputs",
 			Handler:    _SandboxManagerAPI_GetResourceOutputs_Handler,
 		},
+		{
+			MethodName: "RegisterProxies",
+			Handler:    _SandboxManagerAPI_RegisterProxies_Handler,
+		},
+		{
+			MethodName: "RemoveProxy",
+			Handler:    _SandboxManagerAPI_RemoveProxy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/locald/api/sandboxmanager/sandbox_manager_api.proto",
@@ -0,0 +1,191 @@
+package sandboxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/signadot/cli/internal/config"
+	commonapi "github.com/signadot/cli/internal/locald/api"
+	sbapi "github.com/signadot/cli/internal/locald/api/sandboxmanager"
+	"github.com/signadot/cli/internal/locald/sandboxmanager/apiclient"
+	"github.com/signadot/libconnect/common/controlplaneproxy"
+	"google.golang.org/protobuf/proto"
+)
+
+// localProxy is a proxy registered with 'local proxy --detach'
+type localProxy struct {
+	spec   *sbapi.LocalProxySpec
+	proxy  *controlplaneproxy.Proxy
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// localProxies runs the local proxies registered with the sandbox manager,
+// so that they survive the termination of the 'local proxy' command.
+type localProxies struct {
+	log      *slog.Logger
+	ciConfig *config.ConnectInvocationConfig
+
+	mu      sync.Mutex
+	ctx     context.Context
+	proxies map[string]*localProxy
+}
+
+func newLocalProxies(ctx context.Context, log *slog.Logger, ciConfig *config.ConnectInvocationConfig) *localProxies {
+	return &localProxies{
+		log:      log,
+		ciConfig: ciConfig,
+		ctx:      ctx,
+		proxies:  map[string]*localProxy{},
+	}
+}
+
+// register starts the given proxies, replacing the running ones with the
+// same name. Either all the proxies are started, or none: on error, the
+// proxies started are stopped and the replaced ones are started again.
+func (lp *localProxies) register(specs []*sbapi.LocalProxySpec) error {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	names := map[string]bool{}
+	for _, spec := range specs {
+		if names[spec.Name] {
+			return fmt.Errorf("duplicate proxy %q", spec.Name)
+		}
+		names[spec.Name] = true
+	}
+
+	// stop the replaced proxies first, as they could keep the bind address
+	var replaced []*localProxy
+	for _, spec := range specs {
+		if p, ok := lp.proxies[spec.Name]; ok {
+			lp.stop(p)
+			delete(lp.proxies, spec.Name)
+			replaced = append(replaced, p)
+		}
+	}
+	started := make([]*localProxy, 0, len(specs))
+	for _, spec := range specs {
+		p, err := lp.start(spec)
+		if err != nil {
+			err = fmt.Errorf("error starting proxy %q: %w", spec.Name, err)
+			return errors.Join(err, lp.rollback(started, replaced))
+		}
+		lp.proxies[spec.Name] = p
+		started = append(started, p)
+	}
+	return nil
+}
+
+// rollback undoes a failed registration, stopping the proxies started and
+// starting the replaced ones again.
+func (lp *localProxies) rollback(started, replaced []*localProxy) error {
+	for _, p := range started {
+		lp.stop(p)
+		delete(lp.proxies, p.spec.Name)
+	}
+	var errs []error
+	for _, old := range replaced {
+		p, err := lp.start(old.spec)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error restoring proxy %q: %w", old.spec.Name, err))
+			continue
+		}
+		lp.proxies[old.spec.Name] = p
+	}
+	return errors.Join(errs...)
+}
+
+func (lp *localProxies) start(spec *sbapi.LocalProxySpec) (*localProxy, error) {
+	spec = proto.Clone(spec).(*sbapi.LocalProxySpec)
+	headers := spec.Headers
+	ctlPlaneProxy, err := controlplaneproxy.NewProxy(&controlplaneproxy.Config{
+		Log:        lp.log.With("proxy", spec.Name),
+		ProxyURL:   lp.ciConfig.ProxyURL,
+		TargetURL:  spec.TargetUrl,
+		Cluster:    spec.Cluster,
+		RoutingKey: spec.RoutingKey,
+		BindAddr:   spec.BindAddr,
+		GetInjectHeaders: func() (http.Header, error) {
+			h, err := apiclient.GetAuthHeaders(lp.ciConfig)
+			if err != nil {
+				return nil, err
+			}
+			for _, k := range slices.Sorted(maps.Keys(headers)) {
+				h.Set(k, headers[k])
+			}
+			return h, nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(lp.ctx)
+	p := &localProxy{
+		spec:   spec,
+		proxy:  ctlPlaneProxy,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		ctlPlaneProxy.Run(ctx)
+	}()
+	lp.log.Info("Started local proxy", "name", spec.Name, "target", spec.TargetUrl,
+		"bind", spec.BindAddr)
+	return p, nil
+}
+
+func (lp *localProxies) stop(p *localProxy) {
+	p.proxy.Close(context.Background())
+	p.cancel()
+	<-p.done
+	lp.log.Info("Stopped local proxy", "name", p.spec.Name)
+}
+
+// remove stops and removes the named proxy, returning false if there is no
+// such proxy.
+func (lp *localProxies) remove(name string) bool {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	p, ok := lp.proxies[name]
+	if !ok {
+		return false
+	}
+	lp.stop(p)
+	delete(lp.proxies, name)
+	return true
+}
+
+func (lp *localProxies) closeAll() {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	for name, p := range lp.proxies {
+		lp.stop(p)
+		delete(lp.proxies, name)
+	}
+}
+
+func (lp *localProxies) status() []*sbapi.LocalProxyStatus {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	res := make([]*sbapi.LocalProxyStatus, 0, len(lp.proxies))
+	for _, name := range slices.Sorted(maps.Keys(lp.proxies)) {
+		p := lp.proxies[name]
+		st := p.proxy.Status()
+		res = append(res, &sbapi.LocalProxyStatus{
+			Spec:   p.spec,
+			Health: commonapi.ToGRPCServiceHealth(&st.ServiceHealth),
+		})
+	}
+	return res
+}
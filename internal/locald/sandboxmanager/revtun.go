@@ -8,6 +8,7 @@ import (
 
 	"log/slog"
 
+	"github.com/signadot/cli/internal/docker"
 	tunapiv1 "github.com/signadot/libconnect/apiv1"
 	"github.com/signadot/libconnect/revtun"
 	rtproto "github.com/signadot/libconnect/revtun/protocol"
@@ -24,10 +25,15 @@ type rt struct {
 	rtClosed                <-chan struct{}
 	rtToClose               chan struct{}
 	rtErr                   error
+
+	// container local addresses => resolved addresses
+	containerAddrs map[string]string
 }
 
+// newRevtun creates the reverse tunnel of a local, using resolved for the
+// addresses of its container local addresses (see resolveContainerAddresses).
 func newRevtun(log *slog.Logger, rtc revtun.Client, rk string,
-	xw *tunapiv1.ExternalWorkload) (*rt, error) {
+	xw *tunapiv1.ExternalWorkload, resolved map[string]resolvedAddress) (*rt, error) {
 	// define the revtun config (that will be used to setup the reverse tunnel)
 	rtConfig := &rtproto.Config{
 		SandboxRoutingKey:         rk,
@@ -35,14 +41,27 @@ func newRevtun(log *slog.Logger, rtc revtun.Client, rk string,
 		ExternalWorkloadNamespace: xw.Baseline.Namespace,
 		Forwards:                  []rtproto.Forward{},
 	}
+	containerAddrs := map[string]string{}
 	for _, pm := range xw.WorkloadPortMapping {
 		kind, err := kindToRemoteURLTLD(xw.Baseline.Kind)
 		if err != nil {
 			return nil, err
 		}
+		localAddr := pm.LocalAddress
+		if docker.IsContainerAddress(localAddr) {
+			ra, ok := resolved[localAddr]
+			switch {
+			case !ok:
+				return nil, fmt.Errorf("container address %q has not been resolved", localAddr)
+			case ra.err != nil:
+				return nil, ra.err
+			}
+			localAddr = ra.addr
+			containerAddrs[pm.LocalAddress] = localAddr
+		}
 		rtConfig.Forwards = append(rtConfig.Forwards,
 			rtproto.Forward{
-				LocalURL: fmt.Sprintf("tcp://%s", pm.LocalAddress),
+				LocalURL: fmt.Sprintf("tcp://%s", localAddr),
 				RemoteURL: fmt.Sprintf("tcp://%s.%s.%s:%d",
 					xw.Baseline.Name,
 					xw.Baseline.Namespace,
@@ -57,6 +76,8 @@ func newRevtun(log *slog.Logger, rtc revtun.Client, rk string,
 		rtClient:  rtc,
 		rtConfig:  rtConfig,
 		rtToClose: make(chan struct{}),
+
+		containerAddrs: containerAddrs,
 	}
 	go res.monitor()
 	return res, nil
@@ -92,6 +113,57 @@ func (t *rt) monitor() {
 	}
 }
 
+// containersMoved tells whether any of the containers targeted by the tunnel
+// resolves to another address than the one the tunnel was setup with, as it
+// happens when a container is restarted or recreated. Addresses which can't
+// be resolved, like on a transient docker failure, don't count as moved.
+func (t *rt) containersMoved(resolved map[string]resolvedAddress) bool {
+	for addr, old := range t.containerAddrs {
+		cur, ok := resolved[addr]
+		if !ok || cur.err != nil {
+			continue
+		}
+		if cur.addr != old {
+			t.log.Info("container address has changed", "address", addr,
+				"old", old, "new", cur.addr)
+			return true
+		}
+	}
+	return false
+}
+
+// resolvedAddress is the result of resolving a container local address.
+type resolvedAddress struct {
+	addr string
+	err  error
+}
+
+// resolveContainerAddresses resolves the container local addresses of the
+// given locals. This involves docker, so the sandbox controller resolves them
+// before taking its lock, and passes them to newRevtun and containersMoved.
+func resolveContainerAddresses(log *slog.Logger, xws []*tunapiv1.ExternalWorkload) map[string]resolvedAddress {
+	res := map[string]resolvedAddress{}
+	for _, xw := range xws {
+		for _, pm := range xw.WorkloadPortMapping {
+			addr := pm.LocalAddress
+			if !docker.IsContainerAddress(addr) {
+				continue
+			}
+			if _, ok := res[addr]; ok {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			resolved, err := docker.ResolveAddress(ctx, addr)
+			cancel()
+			if err != nil {
+				log.Debug("unable to resolve container address", "address", addr, "error", err)
+			}
+			res[addr] = resolvedAddress{addr: resolved, err: err}
+		}
+	}
+	return res
+}
+
 func kindToRemoteURLTLD(kind string) (string, error) {
 	switch kind {
 	case "Deployment":
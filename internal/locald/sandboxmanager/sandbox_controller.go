@@ -125,6 +125,10 @@ func (ctrl *sbController) triggerReconcile() {
 }
 
 func (ctrl *sbController) reconcile() {
+	// resolving container addresses involves docker, don't hold the lock
+	// while doing so
+	resolved := resolveContainerAddresses(ctrl.log, ctrl.externalWorkloads())
+
 	ctrl.Lock()
 	defer ctrl.Unlock()
 	if ctrl.sandbox == nil {
@@ -170,12 +174,19 @@ func (ctrl *sbController) reconcile() {
 				rt.clusterNotConnectedTime = nil
 			}
 		}
+		if has && rt.containersMoved(resolved) {
+			// the local containers have been restarted, setup the revtun
+			// against their new addresses
+			has = false
+			ctrl.closeRevTunnel(xwName)
+			delete(ctrl.revtuns, xwName)
+		}
 		if has {
 			continue
 		}
 
 		// create revtun
-		rt, err := newRevtun(ctrl.log, ctrl.revtunClient, ctrl.sandbox.RoutingKey, xw)
+		rt, err := newRevtun(ctrl.log, ctrl.revtunClient, ctrl.sandbox.RoutingKey, xw, resolved)
 		if err != nil {
 			ctrl.log.Error("error creating revtun", "error", err)
 			continue
@@ -195,6 +206,18 @@ func (ctrl *sbController) reconcile() {
 	}
 }
 
+// externalWorkloads returns the current locals of the sandbox. They are
+// replaced, not modified, when the sandbox is updated, so they can be used
+// without holding the lock.
+func (ctrl *sbController) externalWorkloads() []*tunapiv1.ExternalWorkload {
+	ctrl.Lock()
+	defer ctrl.Unlock()
+	if ctrl.sandbox == nil {
+		return nil
+	}
+	return ctrl.sandbox.ExternalWorkloads
+}
+
 func (ctrl *sbController) closeRevTunnel(xwName string) {
 	revtun := ctrl.revtuns[xwName]
 	if revtun == nil {
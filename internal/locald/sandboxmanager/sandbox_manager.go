@@ -143,11 +143,13 @@ func (m *sandboxManager) Run(ctx context.Context) error {
 		log: m.log,
 	}
 
+	localProxies := newLocalProxies(runCtx, m.log, m.ciConfig)
+
 	sbmWatcher := newSandboxManagerWatcher(m.log, m.ciConfig.DevboxSessionID, m.revtunClient, oiu, m.shutdownCh)
 
 	// Register our service in gRPC server
 	m.sbmServer = newSandboxManagerGRPCServer(m.log, m.ciConfig, m.portForward, m.ctlPlaneProxy,
-		sbmWatcher, oiu, m.shutdownCh, m.devboxSessionMgr)
+		sbmWatcher, oiu, m.shutdownCh, m.devboxSessionMgr, localProxies)
 	sbapi.RegisterSandboxManagerAPIServer(m.grpcServer, m.sbmServer)
 
 	// Run the gRPC server
@@ -202,6 +204,7 @@ func (m *sandboxManager) Run(ctx context.Context) error {
 	m.log.Info("Shutting down")
 	m.grpcServer.GracefulStop()
 	sbmWatcher.stop()
+	localProxies.closeAll()
 	m.devboxSessionMgr.Stop(ctx)
 	if m.portForward != nil {
 		m.portForward.Close()
@@ -50,12 +50,16 @@ type sbmServer struct {
 
 	// devbox session manager
 	devboxSessionMgr *devbox.SessionManager
+
+	// proxies registered with 'local proxy --detach'
+	localProxies *localProxies
 }
 
 func newSandboxManagerGRPCServer(log *slog.Logger, ciConfig *config.ConnectInvocationConfig,
 	portForward *portforward.PortForward, ctlPlaneProxy *controlplaneproxy.Proxy,
 	sbmWatcher *sbmWatcher, oiu *operatorInfoUpdater,
-	shutdownCh chan struct{}, devboxSessionMgr *devbox.SessionManager) *sbmServer {
+	shutdownCh chan struct{}, devboxSessionMgr *devbox.SessionManager,
+	localProxies *localProxies) *sbmServer {
 	srv := &sbmServer{
 		log:              log,
 		oiu:              oiu,
@@ -65,6 +69,7 @@ func newSandboxManagerGRPCServer(log *slog.Logger, ciConfig *config.ConnectInvoc
 		sbmWatcher:       sbmWatcher,
 		shutdownCh:       shutdownCh,
 		devboxSessionMgr: devboxSessionMgr,
+		localProxies:     localProxies,
 	}
 	return srv
 }
@@ -86,6 +91,7 @@ func (s *sbmServer) Status(ctx context.Context, req *sbapi.StatusRequest) (*sbap
 		Watcher:           s.watcherStatus(),
 		Sandboxes:         s.sbStatuses(),
 		DevboxSession:     s.devboxSessionStatus(),
+		LocalProxies:      s.localProxies.status(),
 	}
 	resp.Hosts, resp.Localnet = s.rootStatus()
 	return resp, nil
@@ -125,6 +131,25 @@ func (s *sbmServer) GetResourceOutputs(ctx context.Context, req *sbapi.GetResour
 	return res, nil
 }
 
+func (s *sbmServer) RegisterProxies(ctx context.Context, req *sbapi.RegisterProxiesRequest) (*sbapi.RegisterProxiesResponse, error) {
+	for _, spec := range req.Proxies {
+		if spec.Name == "" || spec.TargetUrl == "" || spec.BindAddr == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "name, target url and bind address are required")
+		}
+	}
+	if err := s.localProxies.register(req.Proxies); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to register proxies: %s", err.Error())
+	}
+	return &sbapi.RegisterProxiesResponse{}, nil
+}
+
+func (s *sbmServer) RemoveProxy(ctx context.Context, req *sbapi.RemoveProxyRequest) (*sbapi.RemoveProxyResponse, error) {
+	if !s.localProxies.remove(req.Name) {
+		return nil, status.Errorf(codes.NotFound, "proxy %q not found", req.Name)
+	}
+	return &sbapi.RemoveProxyResponse{}, nil
+}
+
 func (s *sbmServer) rootStatus() (*commonapi.HostsStatus, *commonapi.LocalNetStatus) {
 	if !s.ciConfig.WithRootManager {
 		// We are running without a root manager
@@ -19,6 +19,9 @@ var (
 	ErrSandboxManagerUnavailable = errors.New(
 		`sandboxmanager is not running, start it with "signadot local connect"`)
 	ErrTunnelAPIMethodUnimplemented = errors.New("tunnel-api unsupported method")
+	ErrLocalProxyNotFound           = errors.New("local proxy not found")
+	ErrLocalProxiesUnsupported      = errors.New(
+		`the running sandboxmanager does not support detached proxies, reconnect with "signadot local connect"`)
 )
 
 func GetStatus() (*sbmapi.StatusResponse, error) {
@@ -201,6 +204,54 @@ func GetResourceOutputs(ctx context.Context, sbRoutingKey string) ([]ResourceOut
 	return res, nil
 }
 
+// RegisterProxies registers local proxies to be run by the sandbox manager,
+// replacing the registered ones with the same name.
+func RegisterProxies(ctx context.Context, specs []*sbmapi.LocalProxySpec) error {
+	grpcConn, err := connectSandboxManager()
+	if err != nil {
+		return err
+	}
+	defer grpcConn.Close()
+
+	sbManagerClient := sbmapi.NewSandboxManagerAPIClient(grpcConn)
+	_, err = sbManagerClient.RegisterProxies(ctx, &sbmapi.RegisterProxiesRequest{
+		Proxies: specs,
+	})
+	if err != nil {
+		return processLocalProxyError("unable to register proxies with sandboxmanager", err)
+	}
+	return nil
+}
+
+// RemoveProxy stops and removes a local proxy registered with the sandbox
+// manager.
+func RemoveProxy(ctx context.Context, name string) error {
+	grpcConn, err := connectSandboxManager()
+	if err != nil {
+		return err
+	}
+	defer grpcConn.Close()
+
+	sbManagerClient := sbmapi.NewSandboxManagerAPIClient(grpcConn)
+	_, err = sbManagerClient.RemoveProxy(ctx, &sbmapi.RemoveProxyRequest{
+		Name: name,
+	})
+	if err != nil {
+		return processLocalProxyError("unable to remove proxy from sandboxmanager", err)
+	}
+	return nil
+}
+
+func processLocalProxyError(action string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ErrLocalProxyNotFound
+	case codes.Unimplemented:
+		return ErrLocalProxiesUnsupported
+	}
+	return processGRPCError(action, err)
+}
+
 func connectSandboxManager() (*grpc.ClientConn, error) {
 	grpcConn, err := grpc.NewClient("127.0.0.1:6666", grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
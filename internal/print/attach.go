@@ -31,20 +31,37 @@ type AttachEvent struct {
 	PlanID string `json:"planID,omitempty"` // for created events: the source plan
 	Phase  string `json:"phase,omitempty"`  // for result events
 	Error  string `json:"error,omitempty"`  // for result events (if failed); for output events, an artifact-side error
+
+	Cell string `json:"cell,omitempty"` // the matrix cell of the execution, for plan run --matrix
 }
 
 // AttachWriter writes structured events to an io.Writer in either
 // JSON (one object per line) or slog-style text format.
 type AttachWriter struct {
-	mu   sync.Mutex
+	mu   *sync.Mutex
 	out  io.Writer
 	json bool
+	cell string
+	fn   func(AttachEvent)
 }
 
 // NewAttachWriter creates an AttachWriter. If jsonMode is true, events
 // are written as JSON lines; otherwise as slog-style text.
 func NewAttachWriter(out io.Writer, jsonMode bool) *AttachWriter {
-	return &AttachWriter{out: out, json: jsonMode}
+	return &AttachWriter{mu: &sync.Mutex{}, out: out, json: jsonMode}
+}
+
+// NewAttachFunc creates an AttachWriter which hands the events to fn
+// instead of writing them, for consumers such as TUIs.
+func NewAttachFunc(fn func(AttachEvent)) *AttachWriter {
+	return &AttachWriter{mu: &sync.Mutex{}, fn: fn}
+}
+
+// WithCell returns a writer tagging the events with a matrix cell, which
+// shares the output of w so that events of concurrent executions don't
+// interleave.
+func (w *AttachWriter) WithCell(cell string) *AttachWriter {
+	return &AttachWriter{mu: w.mu, out: w.out, json: w.json, cell: cell, fn: w.fn}
 }
 
 // Emit writes an event.
@@ -52,9 +69,16 @@ func (w *AttachWriter) Emit(e AttachEvent) {
 	if e.Time.IsZero() {
 		e.Time = time.Now().UTC()
 	}
+	if e.Cell == "" {
+		e.Cell = w.cell
+	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	if w.fn != nil {
+		w.fn(e)
+		return
+	}
 	if w.json {
 		data, _ := json.Marshal(e)
 		w.out.Write(data)
@@ -69,6 +93,9 @@ func formatText(e AttachEvent) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "time=%s", e.Time.Format(time.TimeOnly))
 	fmt.Fprintf(&b, " type=%s", e.Type)
+	if e.Cell != "" {
+		fmt.Fprintf(&b, " cell=%s", quoteIfNeeded(e.Cell))
+	}
 
 	switch e.Type {
 	case "log":
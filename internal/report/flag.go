@@ -0,0 +1,65 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is a format of test report understood by CI systems.
+type Format string
+
+const (
+	FormatJUnit Format = "junit"
+	FormatTAP   Format = "tap"
+)
+
+// Report is a test report to write to Path.
+type Report struct {
+	Format Format
+	Path   string
+}
+
+func (r Report) String() string {
+	return string(r.Format) + "=" + r.Path
+}
+
+// Reports holds the values of repeated --report flags.
+type Reports []Report
+
+// defaultPaths are the files reports are written to when no path is given.
+var defaultPaths = map[Format]string{
+	FormatJUnit: "report.xml",
+	FormatTAP:   "report.tap",
+}
+
+// Set implements the pflag.Value interface.
+func (rs *Reports) Set(v string) error {
+	format, path, hasPath := strings.Cut(v, "=")
+	defaultPath, ok := defaultPaths[Format(format)]
+	if !ok {
+		return fmt.Errorf("unknown report format %q (should be junit or tap)", format)
+	}
+	if !hasPath {
+		path = defaultPath
+	}
+	// reports don't go to stdout, where they would be mixed with the output
+	// of the command
+	if path == "" || path == "-" {
+		return fmt.Errorf("missing path of the %s report, as in %s=%s", format, format, defaultPath)
+	}
+	*rs = append(*rs, Report{Format: Format(format), Path: path})
+	return nil
+}
+
+// Type implements the pflag.Value interface.
+func (rs *Reports) Type() string {
+	return "string"
+}
+
+func (rs *Reports) String() string {
+	strs := make([]string, 0, len(*rs))
+	for _, r := range *rs {
+		strs = append(strs, r.String())
+	}
+	return strings.Join(strs, ",")
+}
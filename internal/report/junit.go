@@ -0,0 +1,103 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the suites as a JUnit XML document.
+func WriteJUnit(w io.Writer, suites []Suite) error {
+	doc := junitTestSuites{
+		Failures: Count(suites, StatusFailed),
+		Errors:   Count(suites, StatusError),
+		Skipped:  Count(suites, StatusSkipped),
+	}
+	var total time.Duration
+	for _, s := range suites {
+		js := junitTestSuite{
+			Name:     s.Name,
+			Tests:    len(s.Cases),
+			Failures: Count([]Suite{s}, StatusFailed),
+			Errors:   Count([]Suite{s}, StatusError),
+			Skipped:  Count([]Suite{s}, StatusSkipped),
+			Time:     junitTime(s.Time),
+		}
+		if !s.Timestamp.IsZero() {
+			js.Timestamp = s.Timestamp.UTC().Format("2006-01-02T15:04:05")
+		}
+		for _, c := range s.Cases {
+			jc := junitTestCase{
+				Name:      c.Name,
+				Classname: c.Classname,
+				Time:      junitTime(c.Time),
+				SystemOut: c.Output,
+			}
+			switch c.Status {
+			case StatusFailed:
+				jc.Failure = &junitMessage{Message: c.Message, Text: c.Message}
+			case StatusError:
+				jc.Error = &junitMessage{Message: c.Message, Text: c.Message}
+			case StatusSkipped:
+				jc.Skipped = &junitMessage{Message: c.Message}
+			}
+			js.Cases = append(js.Cases, jc)
+		}
+		doc.Tests += len(s.Cases)
+		total += s.Time
+		doc.Suites = append(doc.Suites, js)
+	}
+	doc.Time = junitTime(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,99 @@
+// Package report writes the results of test runs in formats understood by CI
+// systems (JUnit XML and TAP).
+package report
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusError   Status = "error"
+	StatusSkipped Status = "skipped"
+)
+
+// Case is a test case: a plan step, a smart test or a job.
+type Case struct {
+	Name      string
+	Classname string
+	Status    Status
+	Time      time.Duration
+	// Message explains a failure, error or skip.
+	Message string
+	// Output holds the logs captured while running the case.
+	Output string
+}
+
+// Suite is a group of test cases run together.
+type Suite struct {
+	Name      string
+	Timestamp time.Time
+	Time      time.Duration
+	Cases     []Case
+}
+
+// Count returns the number of cases of the suites with the given status.
+func Count(suites []Suite, status Status) int {
+	n := 0
+	for _, s := range suites {
+		for _, c := range s.Cases {
+			if c.Status == status {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Write writes the suites in each of the requested reports.
+func Write(reports Reports, suites ...Suite) error {
+	for _, r := range reports {
+		if err := writeReport(r, suites); err != nil {
+			return fmt.Errorf("writing %s report: %w", r.Format, err)
+		}
+	}
+	return nil
+}
+
+func writeReport(r Report, suites []Suite) error {
+	f, err := os.Create(r.Path)
+	if err != nil {
+		return err
+	}
+	switch r.Format {
+	case FormatJUnit:
+		err = WriteJUnit(f, suites)
+	case FormatTAP:
+		err = WriteTAP(f, suites)
+	default:
+		err = fmt.Errorf("unsupported report format: %q", r.Format)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// ParseTime parses an API timestamp, returning the zero time if it can't.
+func ParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Between returns the time elapsed between two API timestamps, or 0 if
+// either is missing.
+func Between(start, end string) time.Duration {
+	s, e := ParseTime(start), ParseTime(end)
+	if s.IsZero() || e.IsZero() || e.Before(s) {
+		return 0
+	}
+	return e.Sub(s)
+}
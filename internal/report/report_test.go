@@ -0,0 +1,80 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSuites = []Suite{{
+	Name: "plan-1",
+	Time: 3 * time.Second,
+	Cases: []Case{
+		{Name: "build", Classname: "plan-1", Status: StatusPassed, Time: 1500 * time.Millisecond},
+		{Name: "test", Classname: "plan-1", Status: StatusFailed, Message: "exit code 1", Output: "FAIL: TestX\n"},
+		{Name: "deploy", Classname: "plan-1", Status: StatusSkipped, Message: "not run"},
+	},
+}}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, testSuites); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`<testsuites tests="3" failures="1" errors="0" skipped="1" time="3.000">`,
+		`<testsuite name="plan-1" tests="3" failures="1" errors="0" skipped="1" time="3.000">`,
+		`<testcase name="build" classname="plan-1" time="1.500"></testcase>`,
+		`<failure message="exit code 1">exit code 1</failure>`,
+		`<system-out>FAIL: TestX&#xA;</system-out>`,
+		`<skipped message="not run"></skipped>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %s in\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteTAP(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := WriteTAP(&buf, testSuites); err != nil {
+		t.Fatal(err)
+	}
+	want := `TAP version 13
+1..3
+# plan-1
+ok 1 - build
+not ok 2 - test
+  ---
+  message: "exit code 1"
+  severity: fail
+  output: |
+    FAIL: TestX
+  ...
+ok 3 - deploy # SKIP not run
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReportsSet(t *testing.T) {
+	var rs Reports
+	for _, v := range []string{"junit=out/junit.xml", "tap=out/report.tap", "junit", "tap"} {
+		if err := rs.Set(v); err != nil {
+			t.Fatalf("Set(%q): %v", v, err)
+		}
+	}
+	if got := rs.String(); got != "junit=out/junit.xml,tap=out/report.tap,junit=report.xml,tap=report.tap" {
+		t.Errorf("got %q", got)
+	}
+	for _, v := range []string{"junit=", "tap=-", "xunit=report.xml", "xunit"} {
+		if err := rs.Set(v); err == nil {
+			t.Errorf("Set(%q): expected an error", v)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTAP writes the suites as a TAP version 13 stream, with a test point
+// per case. Failing cases are followed by a YAML block holding the failure
+// message and the captured output.
+func WriteTAP(w io.Writer, suites []Suite) error {
+	n := 0
+	for _, s := range suites {
+		n += len(s.Cases)
+	}
+	fmt.Fprintf(w, "TAP version 13\n1..%d\n", n)
+
+	i := 0
+	for _, s := range suites {
+		fmt.Fprintf(w, "# %s\n", tapLine(s.Name))
+		for _, c := range s.Cases {
+			i++
+			name := tapLine(c.Name)
+			switch c.Status {
+			case StatusPassed:
+				fmt.Fprintf(w, "ok %d - %s\n", i, name)
+			case StatusSkipped:
+				fmt.Fprintf(w, "ok %d - %s # SKIP %s\n", i, name, tapLine(c.Message))
+			default:
+				fmt.Fprintf(w, "not ok %d - %s\n", i, name)
+				writeTAPDiagnostics(w, c)
+			}
+		}
+	}
+	return nil
+}
+
+func writeTAPDiagnostics(w io.Writer, c Case) {
+	severity := "fail"
+	if c.Status == StatusError {
+		severity = "error"
+	}
+	fmt.Fprintln(w, "  ---")
+	if c.Message != "" {
+		// JSON strings are valid YAML double-quoted scalars.
+		msg, _ := json.Marshal(c.Message)
+		fmt.Fprintf(w, "  message: %s\n", msg)
+	}
+	fmt.Fprintf(w, "  severity: %s\n", severity)
+	if c.Time > 0 {
+		fmt.Fprintf(w, "  duration_ms: %d\n", c.Time.Milliseconds())
+	}
+	if out := strings.TrimRight(c.Output, "\n"); out != "" {
+		fmt.Fprintln(w, "  output: |")
+		for _, line := range strings.Split(out, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	fmt.Fprintln(w, "  ...")
+}
+
+// tapLine keeps a description on one line and escapes "#", which starts a
+// directive in TAP.
+func tapLine(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "#", `\#`)
+}
@@ -0,0 +1,103 @@
+// Package textdiff computes line diffs of texts.
+package textdiff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Line is a line of a diff: Op is ' ' for a line in both sides, '-' for a
+// line only in the first and '+' for a line only in the second.
+type Line struct {
+	Op   byte
+	Text string
+}
+
+// Split splits a text into lines, ignoring a final newline.
+func Split(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// Lines returns the line diff of a and b, based on their longest common
+// subsequence.
+func Lines(a, b []string) []Line {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var res []Line
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, Line{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, Line{'-', a[i]})
+			i++
+		default:
+			res = append(res, Line{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, Line{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		res = append(res, Line{'+', b[j]})
+	}
+	return res
+}
+
+// HasChanges reports whether a diff has lines which aren't in both sides.
+func HasChanges(lines []Line) bool {
+	for _, l := range lines {
+		if l.Op != ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintHunks prints the changed lines of a diff with up to context
+// unchanged lines around them, separating distant changes with "@@".
+func PrintHunks(out io.Writer, lines []Line, context int) {
+	show := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == ' ' {
+			continue
+		}
+		for j := max(0, i-context); j <= min(len(lines)-1, i+context); j++ {
+			show[j] = true
+		}
+	}
+	printed := false
+	for i, l := range lines {
+		if !show[i] {
+			continue
+		}
+		if i > 0 && !show[i-1] && printed {
+			fmt.Fprintln(out, "@@")
+		}
+		fmt.Fprintf(out, "%c %s\n", l.Op, l.Text)
+		printed = true
+	}
+}
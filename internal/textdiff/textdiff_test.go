@@ -0,0 +1,64 @@
+package textdiff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "identical",
+			a:    "a\nb",
+			b:    "a\nb",
+			want: " a| b",
+		},
+		{
+			name: "changed line",
+			a:    "a\nb\nc",
+			b:    "a\nx\nc",
+			want: " a|-b|+x| c",
+		},
+		{
+			name: "added and removed lines",
+			a:    "a\nb\nc",
+			b:    "b\nc\nd",
+			want: "-a| b| c|+d",
+		},
+		{
+			name: "empty side",
+			a:    "",
+			b:    "a\nb",
+			want: "+a|+b",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for _, l := range Lines(Split(c.a), Split(c.b)) {
+				got = append(got, string(l.Op)+l.Text)
+			}
+			if s := strings.Join(got, "|"); s != c.want {
+				t.Errorf("got %q, want %q", s, c.want)
+			}
+		})
+	}
+}
+
+func TestPrintHunks(t *testing.T) {
+	t.Parallel()
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9"
+	b := "1\nx\n3\n4\n5\n6\n7\n8\ny"
+	var buf bytes.Buffer
+	PrintHunks(&buf, Lines(Split(a), Split(b)), 1)
+	want := "  1\n- 2\n+ x\n  3\n@@\n  8\n- 9\n+ y\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
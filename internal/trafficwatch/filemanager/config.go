@@ -19,6 +19,18 @@ type RequestMetadata struct {
 
 	DoneAt   time.Time `json:"doneAt"`
 	Protocol Protocol
+
+	// Override is set for the requests recorded by a local override
+	Override *OverrideDecision `json:"override,omitempty"`
+}
+
+// OverrideDecision is the decision taken by the override middleware for a
+// request: served by the local service (overridden) or by the sandbox.
+type OverrideDecision struct {
+	Overridden bool `json:"overridden"`
+	// StatusCode is the status code of the local response, 0 if the local
+	// service couldn't be reached.
+	StatusCode int `json:"statusCode"`
 }
 
 type OnRequest func(reqMeta *RequestMetadata)
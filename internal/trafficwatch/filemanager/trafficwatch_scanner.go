@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
@@ -38,16 +37,12 @@ func NewTrafficWatchScanner(cfg *ScannerConfig) (*TrafficWatchScanner, error) {
 		return nil, fmt.Errorf("format is required")
 	}
 
-	var metaName string
 	switch cfg.Format {
-	case config.OutputFormatJSON:
-		metaName = "meta.jsons"
-	case config.OutputFormatYAML:
-		metaName = "meta.yamls"
+	case config.OutputFormatJSON, config.OutputFormatYAML:
 	default:
 		return nil, fmt.Errorf("invalid format")
 	}
-	metaPath := filepath.Join(cfg.TrafficDir, metaName)
+	metaPath := GetMetaStreamPath(cfg.TrafficDir, cfg.Format)
 
 	return &TrafficWatchScanner{
 		ScannerConfig: *cfg,
@@ -187,6 +182,9 @@ func (tw *TrafficWatchScanner) handleRequestEvent(reqEvent *RequestMetadata) {
 
 		// set the done at time
 		reqMeta.DoneAt = reqEvent.DoneAt
+		if reqEvent.Override != nil {
+			reqMeta.Override = reqEvent.Override
+		}
 
 		// set the protocol
 		resp, _ := LoadHttpResponse(GetSourceResponsePath(tw.TrafficDir, reqID))
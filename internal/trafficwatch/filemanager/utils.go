@@ -7,8 +7,27 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/signadot/cli/internal/config"
 )
 
+// GetMetaStreamPath returns the path of the file where the metadata of the
+// requests of a record directory are streamed.
+func GetMetaStreamPath(recordDir string, format config.OutputFormat) string {
+	if format == config.OutputFormatYAML {
+		return filepath.Join(recordDir, "meta.yamls")
+	}
+	return filepath.Join(recordDir, "meta.jsons")
+}
+
+// GetSourceMetaPath returns the path of the metadata of a recorded request.
+func GetSourceMetaPath(recordDir, requestID string, format config.OutputFormat) string {
+	if format == config.OutputFormatYAML {
+		return filepath.Join(recordDir, requestID, "meta.yaml")
+	}
+	return filepath.Join(recordDir, requestID, "meta.json")
+}
+
 func GetSourceRequestPath(recordDir, requestID string) string {
 	return filepath.Join(recordDir, requestID, "request")
 }
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/signadot/cli/internal/tui/views/planexecwatch"
+)
+
+type PlanExecWatchTUI struct {
+	src planexecwatch.Source
+}
+
+func NewPlanExecWatch(src planexecwatch.Source) TUI {
+	return &PlanExecWatchTUI{
+		src: src,
+	}
+}
+
+func (t *PlanExecWatchTUI) Run() error {
+	view := planexecwatch.NewMainView(t.src)
+
+	p := tea.NewProgram(view, tea.WithAltScreen())
+	_, err := p.Run()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package planexecwatch
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/signadot/cli/internal/tui/components"
+)
+
+// keyMap holds the keybindings of the watch: the common ones plus the
+// actions on the execution.
+type keyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	Tab        key.Binding
+	FollowMode key.Binding
+	Help       key.Binding
+	Quit       key.Binding
+	Cancel     key.Binding
+	Download   key.Binding
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		Up:   components.Keys.Up,
+		Down: components.Keys.Down,
+		Tab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next pane"),
+		),
+		FollowMode: components.Keys.FollowMode,
+		Help:       components.Keys.Help,
+		Quit:       components.Keys.Quit,
+		Cancel: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "cancel execution"),
+		),
+		Download: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "download artifact"),
+		),
+	}
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view. It's part
+// of the key.Map interface.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Tab, k.Cancel, k.Download, k.Help, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view. It's part of the
+// key.Map interface.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Tab},
+		{k.FollowMode, k.Cancel, k.Download},
+		{k.Help, k.Quit},
+	}
+}
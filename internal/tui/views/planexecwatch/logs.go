@@ -0,0 +1,30 @@
+package planexecwatch
+
+import "strings"
+
+// maxLogLines bounds the lines kept per step stream, dropping the oldest.
+const maxLogLines = 10000
+
+// stepLogs holds the log lines received for a step.
+type stepLogs struct {
+	stdout []string
+	stderr []string
+}
+
+func (l *stepLogs) add(stream, msg string) {
+	lines := strings.Split(strings.TrimRight(msg, "\n"), "\n")
+	switch stream {
+	case "stderr":
+		l.stderr = appendLines(l.stderr, lines)
+	default:
+		l.stdout = appendLines(l.stdout, lines)
+	}
+}
+
+func appendLines(buf, lines []string) []string {
+	buf = append(buf, lines...)
+	if n := len(buf) - maxLogLines; n > 0 {
+		buf = append(buf[:0], buf[n:]...)
+	}
+	return buf
+}
@@ -0,0 +1,36 @@
+package planexecwatch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStepLogsAdd(t *testing.T) {
+	t.Parallel()
+	l := &stepLogs{}
+	l.add("stdout", "building\n")
+	l.add("stderr", "warning: x\nwarning: y\n")
+	l.add("", "done")
+	if got := strings.Join(l.stdout, "|"); got != "building|done" {
+		t.Errorf("got stdout %q", got)
+	}
+	if got := strings.Join(l.stderr, "|"); got != "warning: x|warning: y" {
+		t.Errorf("got stderr %q", got)
+	}
+}
+
+func TestAppendLines(t *testing.T) {
+	t.Parallel()
+	var buf []string
+	for i := 0; i < maxLogLines; i++ {
+		buf = appendLines(buf, []string{fmt.Sprint(i)})
+	}
+	buf = appendLines(buf, []string{"a", "b"})
+	if len(buf) != maxLogLines {
+		t.Fatalf("got %d lines, want %d", len(buf), maxLogLines)
+	}
+	if buf[0] != "2" || buf[len(buf)-2] != "a" || buf[len(buf)-1] != "b" {
+		t.Errorf("got lines %q...%q, want the oldest ones dropped", buf[:2], buf[len(buf)-2:])
+	}
+}
@@ -0,0 +1,541 @@
+package planexecwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/cli/internal/report"
+	"github.com/signadot/cli/internal/tui/colors"
+	"github.com/signadot/cli/internal/tui/components"
+	"github.com/signadot/go-sdk/models"
+)
+
+const pollInterval = 2 * time.Second
+
+// Source gives the watch access to the execution it monitors.
+type Source interface {
+	// ExecutionID returns the ID of the execution.
+	ExecutionID() string
+	// Get fetches the execution.
+	Get(ctx context.Context) (*models.PlanExecution, error)
+	// StreamLogs streams the logs of all the steps to w, until ctx is done
+	// or the execution ends.
+	StreamLogs(ctx context.Context, w *print.AttachWriter) error
+	// Cancel cancels the execution.
+	Cancel(ctx context.Context) error
+	// Download downloads an output, named <name> when plan-level and
+	// <step>/<name> when step-level, and returns the path written.
+	Download(ctx context.Context, name string) (string, error)
+}
+
+type pane int
+
+const (
+	paneSteps pane = iota
+	paneOutputs
+	paneStdout
+	paneStderr
+	numPanes
+)
+
+// MainView represents the plan execution watch view
+type MainView struct {
+	src  Source
+	ctx  context.Context
+	stop context.CancelFunc
+
+	exec    *models.PlanExecution
+	outputs []outputRow
+	logs    map[string]*stepLogs
+	times   stepTimes
+
+	selectedStep   int
+	selectedOutput int
+	focus          pane
+	followMode     bool
+	confirmCancel  bool
+
+	stdout viewport.Model
+	stderr viewport.Model
+
+	width  int
+	height int
+
+	statusComponent *components.StatusComponent
+	help            help.Model
+	keys            keyMap
+	msgChan         chan tea.Msg
+}
+
+// NewMainView creates a new main view
+func NewMainView(src Source) *MainView {
+	ctx, stop := context.WithCancel(context.Background())
+	return &MainView{
+		src:        src,
+		ctx:        ctx,
+		stop:       stop,
+		logs:       map[string]*stepLogs{},
+		times:      stepTimes{},
+		followMode: true,
+		stdout:     viewport.New(40, 10),
+		stderr:     viewport.New(40, 10),
+		statusComponent: components.NewStatusComponent(
+			components.StatusLoading,
+			"Fetching execution "+src.ExecutionID(),
+		),
+		help:    components.NewHelpModel(),
+		keys:    newKeyMap(),
+		msgChan: make(chan tea.Msg),
+	}
+}
+
+// Init initializes the main view
+func (m *MainView) Init() tea.Cmd {
+	go func() {
+		aw := print.NewAttachFunc(func(e print.AttachEvent) {
+			if e.Type == "log" {
+				m.send(logMsg{Event: e})
+			}
+		})
+		err := m.src.StreamLogs(m.ctx, aw)
+		m.send(logsDoneMsg{Error: err})
+	}()
+	return tea.Batch(m.fetch(), waitForMsg(m.msgChan))
+}
+
+// send hands a message from a background goroutine to the program, unless
+// the view has quit.
+func (m *MainView) send(msg tea.Msg) {
+	select {
+	case m.msgChan <- msg:
+	case <-m.ctx.Done():
+	}
+}
+
+func waitForMsg(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func (m *MainView) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ex, err := m.src.Get(m.ctx)
+		return execMsg{Exec: ex, Error: err}
+	}
+}
+
+func poll() tea.Cmd {
+	return tea.Tick(pollInterval, func(time.Time) tea.Msg {
+		return pollTickMsg{}
+	})
+}
+
+// Update handles messages
+func (m *MainView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.resize()
+		return m, nil
+
+	case pollTickMsg:
+		return m, m.fetch()
+
+	case execMsg:
+		if msg.Error != nil {
+			if errors.Is(msg.Error, context.Canceled) {
+				return m, nil
+			}
+			m.statusComponent.UpdateStatus(components.StatusError).
+				UpdateStatusMessage(msg.Error.Error())
+			return m, poll()
+		}
+		m.setExecution(msg.Exec)
+		if m.isTerminal() {
+			return m, nil
+		}
+		return m, poll()
+
+	case logMsg:
+		e := msg.Event
+		l := m.logs[e.Step]
+		if l == nil {
+			l = &stepLogs{}
+			m.logs[e.Step] = l
+		}
+		l.add(e.Stream, e.Msg)
+		if e.Step == m.selectedStepID() {
+			m.refreshLogs()
+		}
+		return m, waitForMsg(m.msgChan)
+
+	case logsDoneMsg:
+		if msg.Error != nil && !errors.Is(msg.Error, context.Canceled) {
+			m.statusComponent.UpdateStatus(components.StatusWarning).
+				UpdateStatusMessage("log stream ended: " + msg.Error.Error())
+		}
+		return m, nil
+
+	case cancelledMsg:
+		if msg.Error != nil {
+			m.statusComponent.UpdateStatus(components.StatusError).
+				UpdateStatusMessage("cancelling execution: " + msg.Error.Error())
+			return m, nil
+		}
+		m.statusComponent.UpdateStatus(components.StatusInfo).
+			UpdateStatusMessage("Cancelling execution " + m.src.ExecutionID())
+		return m, nil
+
+	case downloadedMsg:
+		if msg.Error != nil {
+			m.statusComponent.UpdateStatus(components.StatusError).
+				UpdateStatusMessage(fmt.Sprintf("downloading %s: %v", msg.Name, msg.Error))
+			return m, nil
+		}
+		m.statusComponent.UpdateStatus(components.StatusSuccess).
+			UpdateStatusMessage(fmt.Sprintf("Downloaded %s to %s", msg.Name, msg.Path))
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *MainView) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !key.Matches(msg, m.keys.Cancel) {
+		m.confirmCancel = false
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		m.stop()
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Help):
+		m.help.ShowAll = !m.help.ShowAll
+		m.resize()
+
+	case key.Matches(msg, m.keys.Tab):
+		m.focus = (m.focus + 1) % numPanes
+
+	case key.Matches(msg, m.keys.FollowMode):
+		m.followMode = !m.followMode
+		if m.followMode {
+			m.stdout.GotoBottom()
+			m.stderr.GotoBottom()
+		}
+
+	case key.Matches(msg, m.keys.Cancel):
+		return m, m.cancelExecution()
+
+	case key.Matches(msg, m.keys.Download):
+		return m, m.downloadOutput()
+
+	case key.Matches(msg, m.keys.Up), key.Matches(msg, m.keys.Down):
+		delta := 1
+		if key.Matches(msg, m.keys.Up) {
+			delta = -1
+		}
+		switch m.focus {
+		case paneSteps:
+			m.selectStep(m.selectedStep + delta)
+		case paneOutputs:
+			m.selectedOutput = clamp(m.selectedOutput+delta, len(m.outputs))
+		case paneStdout:
+			m.stdout, _ = m.stdout.Update(msg)
+		case paneStderr:
+			m.stderr, _ = m.stderr.Update(msg)
+		}
+
+	default:
+		// Let the focused log pane handle its own scrolling keys.
+		var cmd tea.Cmd
+		switch m.focus {
+		case paneStdout:
+			m.stdout, cmd = m.stdout.Update(msg)
+		case paneStderr:
+			m.stderr, cmd = m.stderr.Update(msg)
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+// cancelExecution asks for confirmation on the first press, then cancels.
+func (m *MainView) cancelExecution() tea.Cmd {
+	if m.isTerminal() {
+		m.statusComponent.UpdateStatus(components.StatusInfo).
+			UpdateStatusMessage(fmt.Sprintf("Execution already %s", m.exec.Status.Phase))
+		return nil
+	}
+	if !m.confirmCancel {
+		m.confirmCancel = true
+		m.statusComponent.UpdateStatus(components.StatusWarning).
+			UpdateStatusMessage("Press c again to cancel execution " + m.src.ExecutionID())
+		return nil
+	}
+	m.confirmCancel = false
+	return func() tea.Msg {
+		return cancelledMsg{Error: m.src.Cancel(m.ctx)}
+	}
+}
+
+func (m *MainView) downloadOutput() tea.Cmd {
+	if m.focus != paneOutputs || len(m.outputs) == 0 {
+		m.statusComponent.UpdateStatus(components.StatusWarning).
+			UpdateStatusMessage("Select an artifact output in the outputs pane to download it")
+		return nil
+	}
+	o := m.outputs[m.selectedOutput]
+	switch {
+	case !o.Artifact:
+		m.statusComponent.UpdateStatus(components.StatusInfo).
+			UpdateStatusMessage(fmt.Sprintf("%s is an inline output: %s", o.Name, formatValue(o.Value)))
+		return nil
+	case !o.Ready:
+		m.statusComponent.UpdateStatus(components.StatusWarning).
+			UpdateStatusMessage(fmt.Sprintf("Artifact %s is not ready yet", o.Name))
+		return nil
+	}
+	m.statusComponent.UpdateStatus(components.StatusLoading).
+		UpdateStatusMessage("Downloading " + o.Name)
+	return func() tea.Msg {
+		path, err := m.src.Download(m.ctx, o.Name)
+		return downloadedMsg{Name: o.Name, Path: path, Error: err}
+	}
+}
+
+func (m *MainView) setExecution(ex *models.PlanExecution) {
+	selected := m.selectedStepID()
+	m.exec = ex
+	m.outputs = collectOutputs(ex)
+	m.selectedOutput = clamp(m.selectedOutput, len(m.outputs))
+
+	steps := m.steps()
+	m.times.observe(steps, time.Now())
+	idx := 0
+	for i, s := range steps {
+		if s.ID == selected {
+			idx = i
+			break
+		}
+	}
+	m.selectStep(idx)
+
+	if ex.Status == nil {
+		return
+	}
+	phase := string(ex.Status.Phase)
+	switch {
+	case ex.Status.Phase == models.PlansExecutionPhaseCompleted:
+		m.statusComponent.UpdateStatus(components.StatusSuccess).
+			UpdateStatusMessage("Execution " + phase)
+	case ex.Status.Phase == models.PlansExecutionPhaseFailed:
+		msg := "Execution " + phase
+		if ex.Status.Error != "" {
+			msg += ": " + ex.Status.Error
+		}
+		m.statusComponent.UpdateStatus(components.StatusError).UpdateStatusMessage(msg)
+	case ex.Status.Phase == models.PlansExecutionPhaseCancelled:
+		m.statusComponent.UpdateStatus(components.StatusWarning).
+			UpdateStatusMessage("Execution " + phase)
+	case m.statusComponent.Status == components.StatusLoading:
+		m.statusComponent.UpdateStatus(components.StatusInfo).
+			UpdateStatusMessage("Watching execution " + ex.ID)
+	}
+}
+
+func (m *MainView) steps() []*models.PlanStepStatus {
+	if m.exec == nil || m.exec.Status == nil {
+		return nil
+	}
+	var steps []*models.PlanStepStatus
+	for _, s := range m.exec.Status.Steps {
+		if s != nil {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+func (m *MainView) selectedStepID() string {
+	steps := m.steps()
+	if m.selectedStep < len(steps) {
+		return steps[m.selectedStep].ID
+	}
+	return ""
+}
+
+func (m *MainView) selectStep(i int) {
+	prev := m.selectedStepID()
+	m.selectedStep = clamp(i, len(m.steps()))
+	if m.selectedStepID() != prev {
+		m.stdout.GotoTop()
+		m.stderr.GotoTop()
+	}
+	m.refreshLogs()
+}
+
+// refreshLogs shows the logs of the selected step in the log panes.
+func (m *MainView) refreshLogs() {
+	var stdout, stderr string
+	if l := m.logs[m.selectedStepID()]; l != nil {
+		stdout = strings.Join(l.stdout, "\n")
+		stderr = strings.Join(l.stderr, "\n")
+	}
+	m.stdout.SetContent(stdout)
+	m.stderr.SetContent(stderr)
+	if m.followMode {
+		m.stdout.GotoBottom()
+		m.stderr.GotoBottom()
+	}
+}
+
+func (m *MainView) isTerminal() bool {
+	if m.exec == nil || m.exec.Status == nil {
+		return false
+	}
+	switch m.exec.Status.Phase {
+	case models.PlansExecutionPhaseCompleted,
+		models.PlansExecutionPhaseFailed,
+		models.PlansExecutionPhaseCancelled:
+		return true
+	}
+	return false
+}
+
+func clamp(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// View renders the main view
+func (m *MainView) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+
+	header := m.renderHeader()
+	status := m.renderStatus()
+	l := m.layout(header, status)
+
+	now := time.Now()
+	steps := renderSteps(m.steps(), m.times, m.selectedStep, l.leftWidth-2, now)
+	outputs := renderOutputs(m.outputs, m.selectedOutput, m.focus == paneOutputs, l.leftWidth-2)
+
+	left := lipgloss.JoinVertical(lipgloss.Left,
+		m.box("Steps", visible(steps, m.selectedStep, l.stepsHeight-3), paneSteps, l.leftWidth, l.stepsHeight),
+		m.box("Outputs", visible(outputs, m.selectedOutput, l.outputsHeight-3), paneOutputs, l.leftWidth, l.outputsHeight),
+	)
+	stepID := m.selectedStepID()
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		m.box(logsTitle(stepID, "stdout"), m.stdout.View(), paneStdout, l.rightWidth, l.stdoutHeight),
+		m.box(logsTitle(stepID, "stderr"), m.stderr.View(), paneStderr, l.rightWidth, l.stderrHeight),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.JoinHorizontal(lipgloss.Top, left, right),
+		status,
+	)
+}
+
+// layout holds the outer sizes of the panes.
+type layout struct {
+	leftWidth     int
+	rightWidth    int
+	stepsHeight   int
+	outputsHeight int
+	stdoutHeight  int
+	stderrHeight  int
+}
+
+func (m *MainView) layout(header, status string) layout {
+	bodyHeight := m.height - lipgloss.Height(header) - lipgloss.Height(status)
+	l := layout{leftWidth: m.width / 3}
+	l.rightWidth = m.width - l.leftWidth
+	l.stepsHeight = bodyHeight * 3 / 5
+	l.outputsHeight = bodyHeight - l.stepsHeight
+	l.stdoutHeight = bodyHeight / 2
+	l.stderrHeight = bodyHeight - l.stdoutHeight
+	return l
+}
+
+// resize fits the log panes to the window. Box contents lose 2 columns and
+// rows to the border, and a row to the title.
+func (m *MainView) resize() {
+	l := m.layout(m.renderHeader(), m.renderStatus())
+	m.stdout.Width, m.stdout.Height = max(l.rightWidth-2, 1), max(l.stdoutHeight-3, 1)
+	m.stderr.Width, m.stderr.Height = max(l.rightWidth-2, 1), max(l.stderrHeight-3, 1)
+	if m.followMode {
+		m.stdout.GotoBottom()
+		m.stderr.GotoBottom()
+	}
+}
+
+func (m *MainView) renderStatus() string {
+	return m.statusComponent.
+		SetShortHelpMessage(m.help.View(m.keys)).
+		Render(m.followMode)
+}
+
+func (m *MainView) renderHeader() string {
+	parts := []string{titleStyle.Render("Execution " + m.src.ExecutionID())}
+	if m.exec != nil {
+		if m.exec.Spec != nil && m.exec.Spec.PlanID != "" {
+			parts = append(parts, "plan "+m.exec.Spec.PlanID)
+		}
+		if st := m.exec.Status; st != nil {
+			parts = append(parts, renderPhase(string(st.Phase)))
+			start, end := report.ParseTime(st.CreatedAt), report.ParseTime(st.CompletedAt)
+			if end.IsZero() {
+				end = time.Now()
+			}
+			if !start.IsZero() && end.After(start) {
+				parts = append(parts, end.Sub(start).Round(time.Second).String())
+			}
+		}
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(parts, dimStyle.Render(" · ")))
+}
+
+func logsTitle(stepID, stream string) string {
+	if stepID == "" {
+		return stream
+	}
+	return stepID + " " + stream
+}
+
+// box renders a bordered pane of the given outer size, highlighted when
+// focused.
+func (m *MainView) box(title, content string, p pane, width, height int) string {
+	border := colors.Gray
+	if m.focus == p {
+		border = colors.Blue
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Width(max(width-2, 0)).
+		Height(max(height-2, 0)).
+		MaxHeight(max(height, 0)).
+		Render(titleStyle.Render(title) + "\n" + content)
+}
@@ -0,0 +1,155 @@
+package planexecwatch
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/models"
+)
+
+type fakeSource struct {
+	cancelled  int
+	downloaded []string
+}
+
+func (s *fakeSource) ExecutionID() string { return "exec-1" }
+
+func (s *fakeSource) Get(ctx context.Context) (*models.PlanExecution, error) {
+	return nil, nil
+}
+
+func (s *fakeSource) StreamLogs(ctx context.Context, w *print.AttachWriter) error {
+	return nil
+}
+
+func (s *fakeSource) Cancel(ctx context.Context) error {
+	s.cancelled++
+	return nil
+}
+
+func (s *fakeSource) Download(ctx context.Context, name string) (string, error) {
+	s.downloaded = append(s.downloaded, name)
+	return "/tmp/" + name, nil
+}
+
+func newTestView(t *testing.T) (*MainView, *fakeSource) {
+	src := &fakeSource{}
+	m := NewMainView(src)
+	t.Cleanup(m.stop)
+	m.setExecution(&models.PlanExecution{
+		ID: "exec-1",
+		Status: &models.PlanExecutionStatus{
+			Phase: models.PlansExecutionPhaseRunning,
+			Steps: []*models.PlanStepStatus{
+				{ID: "build", Phase: models.PlansStepPhaseCompleted},
+				{ID: "test", Phase: models.PlansStepPhaseRunning},
+			},
+			Outputs: []*models.PlanOutputStatus{
+				{Name: "report", Artifact: &models.PlanArtifactRef{Ready: true}},
+				{Name: "version", Value: "1.2.3"},
+			},
+		},
+	})
+	return m, src
+}
+
+func press(m *MainView, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		_, cmd = m.Update(k)
+	}
+	return cmd
+}
+
+var (
+	keyUp   = tea.KeyMsg{Type: tea.KeyUp}
+	keyDown = tea.KeyMsg{Type: tea.KeyDown}
+	keyTab  = tea.KeyMsg{Type: tea.KeyTab}
+)
+
+func keyRune(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestMainViewSelect(t *testing.T) {
+	m, _ := newTestView(t)
+	press(m, keyDown, keyDown)
+	if got := m.selectedStepID(); got != "test" {
+		t.Errorf("selected step %q, want test", got)
+	}
+	press(m, keyUp)
+	if got := m.selectedStepID(); got != "build" {
+		t.Errorf("selected step %q, want build", got)
+	}
+
+	press(m, keyTab)
+	if m.focus != paneOutputs {
+		t.Fatalf("focus %d after tab, want the outputs pane", m.focus)
+	}
+	press(m, keyDown)
+	if m.selectedOutput != 1 {
+		t.Errorf("selected output %d, want 1", m.selectedOutput)
+	}
+	press(m, keyTab, keyTab, keyTab)
+	if m.focus != paneSteps {
+		t.Errorf("focus %d after cycling the panes, want the steps pane", m.focus)
+	}
+}
+
+func TestMainViewCancel(t *testing.T) {
+	m, src := newTestView(t)
+	if cmd := press(m, keyRune('c')); cmd != nil || !m.confirmCancel {
+		t.Fatal("first c should ask for confirmation")
+	}
+	// another key drops the confirmation
+	press(m, keyDown)
+	if m.confirmCancel {
+		t.Fatal("confirmation kept after another key")
+	}
+	cmd := press(m, keyRune('c'), keyRune('c'))
+	if cmd == nil {
+		t.Fatal("second c should cancel the execution")
+	}
+	if _, ok := cmd().(cancelledMsg); !ok || src.cancelled != 1 {
+		t.Errorf("got %d cancellations, want 1", src.cancelled)
+	}
+}
+
+func TestMainViewDownload(t *testing.T) {
+	m, src := newTestView(t)
+	// nothing is downloaded outside the outputs pane
+	if cmd := press(m, keyRune('d')); cmd != nil {
+		t.Fatal("download outside the outputs pane")
+	}
+	press(m, keyTab)
+	cmd := press(m, keyRune('d'))
+	if cmd == nil {
+		t.Fatal("no download of the selected artifact")
+	}
+	if msg, ok := cmd().(downloadedMsg); !ok || msg.Path != "/tmp/report" {
+		t.Errorf("got %+v, want report downloaded", msg)
+	}
+	// inline outputs aren't downloaded
+	if cmd := press(m, keyDown, keyRune('d')); cmd != nil {
+		t.Error("download of an inline output")
+	}
+	if len(src.downloaded) != 1 {
+		t.Errorf("got downloads %v, want only report", src.downloaded)
+	}
+}
+
+func TestMainViewQuit(t *testing.T) {
+	m, _ := newTestView(t)
+	cmd := press(m, keyRune('q'))
+	if cmd == nil {
+		t.Fatal("no command on quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q doesn't quit")
+	}
+	if m.ctx.Err() == nil {
+		t.Error("background work not stopped on quit")
+	}
+}
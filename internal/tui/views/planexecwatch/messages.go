@@ -0,0 +1,31 @@
+package planexecwatch
+
+import (
+	"github.com/signadot/cli/internal/print"
+	"github.com/signadot/go-sdk/models"
+)
+
+type pollTickMsg struct{}
+
+type execMsg struct {
+	Exec  *models.PlanExecution
+	Error error
+}
+
+type logMsg struct {
+	Event print.AttachEvent
+}
+
+type logsDoneMsg struct {
+	Error error
+}
+
+type cancelledMsg struct {
+	Error error
+}
+
+type downloadedMsg struct {
+	Name  string
+	Path  string
+	Error error
+}
@@ -0,0 +1,192 @@
+package planexecwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/signadot/cli/internal/tui/colors"
+	"github.com/signadot/go-sdk/models"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(colors.Blue)
+
+	selectedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(colors.BrightCyan)
+
+	dimStyle = lipgloss.NewStyle().
+			Foreground(colors.Gray)
+)
+
+// outputRow is an output of the execution, named <name> when plan-level
+// and <step>/<name> when step-level, as in 'signadot plan x get-output'.
+type outputRow struct {
+	Name     string
+	Artifact bool
+	Ready    bool
+	Size     int64
+	Value    any
+}
+
+func collectOutputs(ex *models.PlanExecution) []outputRow {
+	if ex == nil || ex.Status == nil {
+		return nil
+	}
+	var rows []outputRow
+	planLevel := map[string]bool{}
+	for _, o := range ex.Status.Outputs {
+		if o == nil {
+			continue
+		}
+		if o.StepRef != nil {
+			planLevel[o.StepRef.StepID+"/"+o.Name] = true
+		}
+		rows = append(rows, newOutputRow(o.Name, o.Value, o.Artifact))
+	}
+	for _, s := range ex.Status.Steps {
+		if s == nil {
+			continue
+		}
+		for _, o := range s.Outputs {
+			if o == nil || planLevel[s.ID+"/"+o.Name] {
+				continue
+			}
+			rows = append(rows, newOutputRow(s.ID+"/"+o.Name, o.Value, o.Artifact))
+		}
+	}
+	return rows
+}
+
+func newOutputRow(name string, value any, art *models.PlanArtifactRef) outputRow {
+	row := outputRow{Name: name, Value: value}
+	if art != nil {
+		row.Artifact = true
+		row.Ready = art.Ready
+		row.Size = art.Size
+	}
+	return row
+}
+
+func (o outputRow) describe() string {
+	if !o.Artifact {
+		return formatValue(o.Value)
+	}
+	if !o.Ready {
+		return "artifact (pending)"
+	}
+	return fmt.Sprintf("artifact (%s)", units.HumanSize(float64(o.Size)))
+}
+
+func formatValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	}
+	d, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(d)
+}
+
+func phaseColor(phase string) lipgloss.Color {
+	switch phase {
+	case "completed":
+		return colors.Green
+	case "failed":
+		return colors.Red
+	case "cancelled":
+		return colors.Orange
+	case "running":
+		return colors.Cyan
+	case "skipped":
+		return colors.Gray
+	}
+	return colors.LightGray
+}
+
+func renderPhase(phase string) string {
+	return lipgloss.NewStyle().Foreground(phaseColor(phase)).Render(phase)
+}
+
+func renderSteps(steps []*models.PlanStepStatus, times stepTimes, selected int, width int, now time.Time) []string {
+	if len(steps) == 0 {
+		return []string{dimStyle.Render("No steps yet.")}
+	}
+	maxID := 0
+	for _, s := range steps {
+		maxID = max(maxID, len(s.ID))
+	}
+	lines := make([]string, 0, len(steps))
+	for i, s := range steps {
+		cursor := "  "
+		id := fmt.Sprintf("%-*s", maxID, s.ID)
+		if i == selected {
+			cursor = "> "
+			id = selectedStyle.Render(id)
+		}
+		phase := fmt.Sprintf("%-10s", s.Phase)
+		line := cursor + id + "  " + renderPhase(phase)
+		if d, partial := times.duration(s.ID, now); d > 0 {
+			if partial {
+				line += "  ≥" + d.String()
+			} else {
+				line += "  " + d.String()
+			}
+		}
+		lines = append(lines, truncate(line, width))
+	}
+	return lines
+}
+
+func renderOutputs(outputs []outputRow, selected int, focused bool, width int) []string {
+	if len(outputs) == 0 {
+		return []string{dimStyle.Render("No outputs yet.")}
+	}
+	maxName := 0
+	for _, o := range outputs {
+		maxName = max(maxName, len(o.Name))
+	}
+	lines := make([]string, 0, len(outputs))
+	for i, o := range outputs {
+		cursor := "  "
+		name := fmt.Sprintf("%-*s", maxName, o.Name)
+		if focused && i == selected {
+			cursor = "> "
+			name = selectedStyle.Render(name)
+		}
+		lines = append(lines, truncate(cursor+name+"  "+o.describe(), width))
+	}
+	return lines
+}
+
+// visible returns the lines fitting in rows, scrolled so that the selected
+// line shows.
+func visible(lines []string, selected, rows int) string {
+	if rows <= 0 {
+		return ""
+	}
+	start := 0
+	if selected >= rows {
+		start = selected - rows + 1
+	}
+	end := min(len(lines), start+rows)
+	return strings.Join(lines[start:end], "\n")
+}
+
+// truncate cuts a rendered line to width cells.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(s)
+}
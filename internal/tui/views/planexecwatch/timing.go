@@ -0,0 +1,57 @@
+package planexecwatch
+
+import (
+	"time"
+
+	"github.com/signadot/go-sdk/models"
+)
+
+// stepTimes tracks how long steps run, from the phase changes observed
+// while watching, as step statuses carry no timestamps.
+type stepTimes map[string]*stepTime
+
+type stepTime struct {
+	start, end time.Time
+	// partial is set for the steps already running when first observed,
+	// whose durations are lower bounds.
+	partial bool
+}
+
+// observe records the phases of the steps at time now.
+func (t stepTimes) observe(steps []*models.PlanStepStatus, now time.Time) {
+	for _, s := range steps {
+		if s == nil {
+			continue
+		}
+		st, seen := t[s.ID]
+		if !seen {
+			st = &stepTime{}
+			t[s.ID] = st
+		}
+		switch s.Phase {
+		case models.PlansStepPhaseRunning:
+			if st.start.IsZero() {
+				st.start = now
+				st.partial = !seen
+			}
+		case models.PlansStepPhaseCompleted, models.PlansStepPhaseFailed, models.PlansStepPhaseSkipped:
+			if !st.start.IsZero() && st.end.IsZero() {
+				st.end = now
+			}
+		}
+	}
+}
+
+// duration returns how long a step ran, up to now if it is running, or 0 if
+// it wasn't seen running.
+func (t stepTimes) duration(id string, now time.Time) (time.Duration, bool) {
+	st := t[id]
+	if st == nil || st.start.IsZero() {
+		return 0, false
+	}
+	end := st.end
+	if end.IsZero() {
+		end = now
+	}
+	return end.Sub(st.start).Round(time.Second), st.partial
+}
@@ -0,0 +1,50 @@
+package planexecwatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/signadot/go-sdk/models"
+)
+
+func TestStepTimes(t *testing.T) {
+	t.Parallel()
+	step := func(id string, phase models.PlansStepPhase) *models.PlanStepStatus {
+		return &models.PlanStepStatus{ID: id, Phase: phase}
+	}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := stepTimes{}
+	times.observe([]*models.PlanStepStatus{
+		step("build", models.PlansStepPhaseRunning),
+		step("test", "pending"),
+		step("lint", models.PlansStepPhaseCompleted),
+	}, t0)
+	times.observe([]*models.PlanStepStatus{
+		step("build", models.PlansStepPhaseCompleted),
+		step("test", models.PlansStepPhaseRunning),
+		step("lint", models.PlansStepPhaseCompleted),
+	}, t0.Add(10*time.Second))
+	times.observe([]*models.PlanStepStatus{
+		step("build", models.PlansStepPhaseCompleted),
+		step("test", models.PlansStepPhaseRunning),
+		step("lint", models.PlansStepPhaseCompleted),
+	}, t0.Add(15*time.Second))
+
+	now := t0.Add(22 * time.Second)
+	cases := []struct {
+		id      string
+		want    time.Duration
+		partial bool
+	}{
+		{"build", 10 * time.Second, true},
+		{"test", 12 * time.Second, false},
+		{"lint", 0, false},
+		{"deploy", 0, false},
+	}
+	for _, c := range cases {
+		d, partial := times.duration(c.id, now)
+		if d != c.want || partial != c.partial {
+			t.Errorf("duration(%s) = %s, %v, want %s, %v", c.id, d, partial, c.want, c.partial)
+		}
+	}
+}
@@ -267,8 +267,11 @@ func (l *LeftPane) renderRequestItem(req *filemanager.RequestMetadata, selected
 	}
 	proto = lipgloss.NewStyle().Foreground(colors.White).Render(proto)
 
-	// date-time  protocol  host
+	// date-time  protocol  [override decision]  host
 	line1 := fmt.Sprintf("%s  %-5s  ", formattedTime, proto)
+	if req.Override != nil {
+		line1 += renderOverrideDecision(req.Override) + "  "
+	}
 	line1 += truncateURL(host, l.width-lipgloss.Width(line1)-1)
 	// method  fullPath
 	line2 := fmt.Sprintf("%-6s  ", method)
@@ -291,6 +294,18 @@ func (l *LeftPane) renderRequestItem(req *filemanager.RequestMetadata, selected
 	return lipgloss.NewStyle().PaddingLeft(2).Render(content + "\n")
 }
 
+func renderOverrideDecision(d *filemanager.OverrideDecision) string {
+	if d.Overridden {
+		return lipgloss.NewStyle().Foreground(colors.Cyan).
+			Render(fmt.Sprintf("local %d", d.StatusCode))
+	}
+	if d.StatusCode == 0 {
+		return lipgloss.NewStyle().Foreground(colors.Blue).Render("sandbox (local down)")
+	}
+	return lipgloss.NewStyle().Foreground(colors.Blue).
+		Render(fmt.Sprintf("sandbox (local %d)", d.StatusCode))
+}
+
 func (l *LeftPane) renderEmptyState() string {
 	return lipgloss.NewStyle().
 		Align(lipgloss.Center).
@@ -235,6 +235,16 @@ func (r *RightPane) renderRequestTab(reqMeta *filemanager.RequestMetadata, req *
 	content.WriteString(r.getLineRenderMeta("Method", req.Method))
 	content.WriteString(r.getLineRenderMeta("Routing Key", reqMeta.RoutingKey))
 	content.WriteString(r.getLineRenderMeta("Workload", reqMeta.DestWorkload))
+	if d := reqMeta.Override; d != nil {
+		servedBy := fmt.Sprintf("sandbox (fell through, local status %d)", d.StatusCode)
+		switch {
+		case d.Overridden:
+			servedBy = fmt.Sprintf("local service (overridden, status %d)", d.StatusCode)
+		case d.StatusCode == 0:
+			servedBy = "sandbox (fell through, local service unreachable)"
+		}
+		content.WriteString(r.getLineRenderMeta("Served By", servedBy))
+	}
 	content.WriteString(r.getLineRenderMeta("File",
 		filemanager.GetSourceRequestPath(r.recordDir, reqMeta.MiddlewareRequestID)))
 	content.WriteString("\n")
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/signadot/cli/internal/config"
+	"github.com/signadot/go-sdk/client/artifacts"
+	"github.com/signadot/go-sdk/models"
+)
+
+// jobLogIndexes are the system artifacts indexing the job logs, which are of
+// no use to users.
+var jobLogIndexes = map[string]bool{"stderr.index": true, "stdout.index": true}
+
+// GetJobArtifacts returns the artifacts of a job attempt, but the indexes of
+// the job logs.
+func GetJobArtifacts(ctx context.Context, cfg *config.API,
+	jobName string, attempt int64) ([]*models.JobArtifact, error) {
+	params := artifacts.NewListJobAttemptArtifactsParams().
+		WithContext(ctx).
+		WithOrgName(cfg.Org).
+		WithJobName(jobName).
+		WithJobAttempt(attempt)
+	resp, err := cfg.Client.Artifacts.ListJobAttemptArtifacts(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var list []*models.JobArtifact
+	for _, a := range resp.Payload {
+		if a == nil || jobLogIndexes[a.Path] {
+			continue
+		}
+		list = append(list, a)
+	}
+	return list, nil
+}
+
+// ByteCountSI formats a size in bytes with SI units, like "1.5 kB".
+func ByteCountSI(b int64) string {
+	const unit = 1000
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB",
+		float64(b)/float64(div), "kMGTPE"[exp])
+}